@@ -58,9 +58,14 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while setting up staking services layer")
 	}
+	if err = services.InitBtcLagMetrics(ctx); err != nil {
+		// Only the lag metrics are affected until the next events
+		log.Warn().Err(err).Msg("error while initializing the btc lag metrics")
+	}
 	// Start the event queue processing
 	queues := queue.New(&cfg.Queue, services)
 	queues.StartReceivingMessages()
+	services.AddReadinessCheck("queue", queues.Ping)
 
 	apiServer, err := api.New(ctx, cfg, services)
 	if err != nil {
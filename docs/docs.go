@@ -32,9 +32,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/healthcheck/live": {
+            "get": {
+                "description": "Checks that the server is up, without checking its dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Liveness check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Server is up and running",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthcheck/ready": {
+            "get": {
+                "description": "Checks that the dependencies of the server, i.e the database, the cache and the queues, are reachable within the readiness check timeout, with the status of each of them",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Readiness check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "All the dependencies are up",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ReadinessPublic"
+                        }
+                    },
+                    "503": {
+                        "description": "Some of the dependencies are down",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ReadinessPublic"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/delegation": {
             "get": {
-                "description": "Retrieves a delegation by a given transaction hash",
+                "description": "Retrieves a delegation by a given transaction hash, optionally including its position in the staking cap\nfill order, i.e the active stake in sats of the non-overflow delegations filling the staking cap ahead of it",
                 "produces": [
                     "application/json"
                 ],
@@ -45,6 +85,18 @@ const docTemplate = `{
                         "name": "staking_tx_hash_hex",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the cap position and the staking cap, defaults to false",
+                        "name": "include_cap_position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone name the timestamps are formatted in, defaults to UTC",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -63,81 +115,136 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/finality-providers": {
+        "/v1/delegation/by-output": {
             "get": {
-                "description": "Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order.",
+                "description": "Retrieves a delegation of a staker by the staking output, identified by the staking tx hash and the output index",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Active Finality Providers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pagination key to fetch the next page of finality providers",
-                        "name": "pagination_key",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "txid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Index of the staking output in the staking transaction",
+                        "name": "vout",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone name the timestamps are formatted in, defaults to UTC",
+                        "name": "tz",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "description": "Delegation",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_FpDetailsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-services_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/global-params": {
+        "/v1/delegation/history": {
             "get": {
-                "description": "Retrieves the global parameters for Babylon, including finality provider details.",
+                "description": "Retrieves the state transitions of a delegation identified by its staking transaction hash,\nfrom its creation onwards in the order they were recorded. The creation of the delegations made before\nthe state changes are recorded is derived from their staking transaction and flagged as synthesized,\ntheir transitions made before the recording started are missing.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Babylon global parameters",
+                "summary": "Get the state history of a delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Global parameters",
+                        "description": "List of the delegation state changes",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationStateChangePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/staker/delegation/check": {
-            "get": {
-                "description": "Check if a staker has an active delegation by the staker BTC address (Taproot only)\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframe is \"today\" which checks after UTC 12AM of the current day",
+        "/v1/delegations": {
+            "post": {
+                "description": "Retrieves the delegations identified by the given staking transaction hashes, keyed by the\nnormalized lowercase hash. Hashes without a matching delegation are included with a null value.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Staker BTC address in Taproot format",
-                        "name": "address",
-                        "in": "query",
-                        "required": true
+                        "description": "List of at most 100 staking transaction hashes",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DelegationsBatchRequestPayload"
+                        }
                     },
                     {
-                        "enum": [
-                            "today"
-                        ],
                         "type": "string",
-                        "description": "Check if the delegation is active within the provided timeframe",
-                        "name": "timeframe",
+                        "description": "IANA timezone name the timestamps are formatted in, defaults to UTC",
+                        "name": "tz",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Result",
+                        "description": "Delegations keyed by staking tx hash",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Result"
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_services_DelegationPublic"
                         }
                     },
                     "400": {
-                        "description": "Error: Bad Request",
+                        "description": "Invalid request payload",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -145,19 +252,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/staker/delegations": {
+        "/v1/delegations/unbonding": {
             "get": {
-                "description": "Retrieves delegations for a given staker",
+                "description": "Retrieves delegations that have started unbonding but whose unbonding timelock has not yet elapsed,\nordered by the unbonding start height, along with the remaining blocks and estimated completion time.",
                 "produces": [
                     "application/json"
                 ],
+                "summary": "Get delegations in the unbonding window",
                 "parameters": [
                     {
+                        "type": "boolean",
+                        "description": "Whether overflow delegations are included, defaults to the server configuration",
+                        "name": "include_overflow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations whose staking tx has at least the given confirmations, the server minimum applies if stricter",
+                        "name": "min_confirmations",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "start_height",
+                            "remaining_blocks"
+                        ],
                         "type": "string",
-                        "description": "Staker BTC Public Key",
-                        "name": "staker_btc_pk",
-                        "in": "query",
-                        "required": true
+                        "description": "Sort order of the delegations, both in ascending order",
+                        "name": "sort_by",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -168,9 +291,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of delegations and pagination token",
+                        "description": "List of unbonding delegations and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_UnbondingDelegationPublic"
                         }
                     },
                     "400": {
@@ -182,43 +305,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/stats": {
+        "/v1/events/delegations": {
             "get": {
-                "description": "Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.",
+                "description": "Retrieves the state changes across all delegations in the order they were recorded.\nThe pagination key is always returned, consumers are expected to keep the last one\nand poll with it to fetch the state changes recorded since.\nThe delegations created before the state changes were first recorded are not part of the feed.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Overall Stats",
+                "summary": "Get the feed of delegation state changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of state changes",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Overall stats for babylon staking",
+                        "description": "List of delegation state changes and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-services_OverallStatsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationStateChangePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/stats/staker": {
+        "/v1/finality-provider": {
             "get": {
-                "description": "Fetches details of top stakers by their active total value locked (ActiveTvl) in descending order.",
+                "description": "Fetches the details of the finality provider with the given pk.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Top Staker Stats by Active TVL",
+                "summary": "Get Finality Provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pagination key to fetch the next page of top stakers",
-                        "name": "pagination_key",
-                        "in": "query"
+                        "description": "Finality Provider BTC Public Key",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of top stakers by active tvl",
+                        "description": "Finality provider details",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerStatsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-services_FpDetailsPublic"
                         }
                     },
                     "400": {
@@ -226,37 +364,72 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/unbonding": {
-            "post": {
-                "description": "Unbonds a delegation by processing the provided transaction details. This is an async operation.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/finality-provider/delegations": {
+            "get": {
+                "description": "Retrieves the delegations to a given finality provider, sorted by the staking start height in descending order.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Unbond delegation",
+                "summary": "Get Finality Provider Delegations",
                 "parameters": [
                     {
-                        "description": "Unbonding Request Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UnbondDelegationRequestPayload"
-                        }
+                        "type": "string",
+                        "description": "Finality Provider BTC Public Key",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "unbonding_requested",
+                            "unbonding",
+                            "unbonded",
+                            "withdrawn"
+                        ],
+                        "type": "string",
+                        "description": "Comma separated list of the states of the delegations to return",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone name the timestamps are formatted in, defaults to UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of delegations in the page, defaults to the configured page size and cannot exceed the configured max page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Request accepted and will be processed asynchronously"
+                    "200": {
+                        "description": "List of delegations and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationPublic"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Error: Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -264,225 +437,2538 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/unbonding/eligibility": {
+        "/v1/finality-provider/stats": {
             "get": {
-                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding.",
+                "description": "Retrieves the total staking value and the number of distinct stakers of the delegations to a finality provider.\nOnly the delegations whose BTC is still locked are accounted for, i.e. the unbonded and withdrawn delegations are excluded.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check unbonding eligibility",
+                "summary": "Get Finality Provider Stake Stats",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staking Transaction Hash Hex",
-                        "name": "staking_tx_hash_hex",
+                        "description": "Finality Provider BTC Public Key",
+                        "name": "finality_provider_pk_hex",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The delegation is eligible for unbonding"
+                        "description": "Stake stats of the finality provider",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_FpStakeStatsPublic"
+                        }
                     },
                     "400": {
-                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "description": "Error: Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_babylonchain_staking-api-service_internal_types.Error": {
-            "type": "object",
-            "properties": {
-                "err": {},
-                "errorCode": {
-                    "$ref": "#/definitions/types.ErrorCode"
-                },
-                "statusCode": {
-                    "type": "integer"
-                }
-            }
-        },
-        "handlers.PublicResponse-array_services_DelegationPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/services.DelegationPublic"
-                    }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
-                }
-            }
         },
-        "handlers.PublicResponse-array_services_FpDetailsPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/services.FpDetailsPublic"
-                    }
-                },
-                "pagination": {
+        "/v1/finality-providers": {
+            "get": {
+                "description": "Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order.\nUse ` + "`" + `sort_by=active_staker_count` + "`" + ` to sort them by the number of distinct stakers with active delegations instead,\nor ` + "`" + `sort_by=self_stake` + "`" + ` to sort them by the active stake they delegated to themselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Active Finality Providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of finality providers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active_tvl",
+                            "active_staker_count",
+                            "self_stake"
+                        ],
+                        "type": "string",
+                        "description": "Sort order of the finality providers",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the finality providers with at least this self stake, requires sort_by=self_stake",
+                        "name": "min_self_stake",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return the finality providers whose moniker contains this value, case-insensitive",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_FpDetailsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/batch": {
+            "post": {
+                "description": "Fetches the details of the finality providers matching the given pks, keyed by pk hex.\nUnknown pks are omitted from the result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Finality Providers by pks",
+                "parameters": [
+                    {
+                        "description": "List of finality provider pks",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FinalityProvidersBatchRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finality providers keyed by pk hex",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_services_FpDetailsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/delegation-counts": {
+            "get": {
+                "description": "Fetches the number of active delegations and distinct stakers of the finality providers, keyed by pk hex.\nThe delegations whose unbonding is requested are active until the unbonding tx is confirmed.\nThe finality providers without any active delegation are omitted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Finality Provider Delegation Counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of finality providers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether overflow delegations are included, defaults to the server configuration",
+                        "name": "include_overflow",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation counts keyed by finality provider pk hex",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_services_FpDelegationCountPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/staker-counts": {
+            "get": {
+                "description": "Fetches the number of distinct stakers with an active delegation to the finality providers, keyed by pk hex.\nThe delegations whose unbonding is requested are active until the unbonding tx is confirmed.\nThe finality providers without any active delegation are omitted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Finality Provider Staker Counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of finality providers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether overflow delegations are included, defaults to the server configuration",
+                        "name": "include_overflow",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active staker counts keyed by finality provider pk hex",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_int64"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/global-params": {
+            "get": {
+                "description": "Retrieves the global parameters for Babylon, including finality provider details.\nAll the versions are returned unless a single one is requested.\nThe response carries an ETag, a 304 is returned if it matches the If-None-Match header.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Babylon global parameters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only return the given params version",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the params held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Global parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsPublic"
+                        }
+                    },
+                    "304": {
+                        "description": "Global parameters not modified"
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/activity/daily": {
+            "get": {
+                "description": "Retrieves per UTC day the number and the total staking value of the delegations created by the staker,\nin ascending order of day. The range is inclusive and cannot exceed 366 days. It defaults to the last 30 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the range in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the range in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily activity of the staker",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerDailyActivityPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/dashboard": {
+            "get": {
+                "description": "Retrieves the headline figures of the staker at once: the total stake, the stake eligible for unbonding,\nthe number of finality providers delegated to, the rank by active tvl and the daily activity of the last 30 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dashboard of the staker",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerDashboardPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation-summary": {
+            "get": {
+                "description": "Retrieves the number of delegations of the staker in each state, keyed by state.\nEvery state is included, with a zero count if the staker has no delegation in it.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation counts keyed by state",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_int64"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation/check": {
+            "get": {
+                "description": "Check if a staker has an active delegation by the staker BTC address (Taproot only)\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframes are \"today\" which checks after 12AM of the current day in the ` + "`" + `tz` + "`" + ` timezone (UTC by default),\n\"week\" and \"month\" which check within the last 7 and 30 days respectively",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot format",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "today",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Check if the delegation is active within the provided timeframe",
+                        "name": "timeframe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone name the day boundary of the today timeframe is in, defaults to UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Check if the delegation is active after the given Unix timestamp in seconds, instead of a timeframe",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations": {
+            "get": {
+                "description": "Retrieves delegations for a given staker\nWith ` + "`" + `sort_by` + "`" + `, the pagination key is tied to the requested sort, resuming it with a different ` + "`" + `sort_by` + "`" + ` or ` + "`" + `sort_order` + "`" + ` returns 400.\nPaging through a fixed sort never skips nor repeats delegations, as ties are broken by the staking tx hash.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key, required unless address is provided",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot format, as an alternative to staker_btc_pk",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "unbonding_requested",
+                            "unbonding",
+                            "unbonded",
+                            "withdrawn"
+                        ],
+                        "type": "string",
+                        "description": "Comma separated list of the states of the delegations to return",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "early_unbonding",
+                            "natural_expiry"
+                        ],
+                        "type": "string",
+                        "description": "Only return the ended delegations with the given unbonding type",
+                        "name": "unbonding_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return the delegations to the given finality provider",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether overflow delegations are included, defaults to the server configuration",
+                        "name": "include_overflow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the delegations at or below (if true) or above (if false) the min staking amount of their params version",
+                        "name": "below_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations with exactly the given staking timelock, cannot be combined with the range bounds",
+                        "name": "staking_timelock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations with a staking timelock at or above the given value",
+                        "name": "min_staking_timelock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations with a staking timelock at or below the given value",
+                        "name": "max_staking_timelock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations whose staking tx has at least the given confirmations, the server minimum applies if stricter",
+                        "name": "min_confirmations",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "staking_amount",
+                            "start_height"
+                        ],
+                        "type": "string",
+                        "description": "Sort the delegations by the given field, the staking tx hash breaking ties. Defaults to the staking start height in descending order",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Order of the sort_by field, defaults to desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the id, state and last update time of each delegation",
+                        "name": "minimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of the delegation fields to return, e.g. staking_tx_hash_hex,state,staking_value. Cannot be combined with minimal",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone name the timestamps are formatted in, defaults to UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of delegations in the page, defaults to the configured page size and cannot exceed the configured max page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations, only valid with the sort it was returned for",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format, CSV can also be requested with a text/csv Accept header. The CSV export streams all the delegations from the pagination key onwards, ignoring limit, minimal and fields",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV export of the delegations, if requested",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/batch": {
+            "post": {
+                "description": "Retrieves the first page of delegations of each of the given stakers, keyed by staker pk hex.\nStakers without any delegation are included with an empty list. The following pages can be\nfetched from the staker delegations endpoint with the returned ` + "`" + `next_key` + "`" + ` as pagination key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "description": "List of at most 50 staker BTC public keys",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StakerDelegationsBatchRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegations keyed by staker pk hex",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-map_string_services_StakerDelegationsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/export": {
+            "post": {
+                "description": "Creates a job exporting as CSV all the delegations of the staker matching the filters, with the same\ncolumns as the CSV format of the staker delegations. The export is generated in the background, which\nsuits the stakers too large to be exported synchronously. Its status is polled with the returned job id.\nThe job and its file expire an hour after the job creation.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key, required unless address is provided",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot format, as an alternative to staker_btc_pk",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "unbonding_requested",
+                            "unbonding",
+                            "unbonded",
+                            "withdrawn"
+                        ],
+                        "type": "string",
+                        "description": "Comma separated list of the states of the delegations to export",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "early_unbonding",
+                            "natural_expiry"
+                        ],
+                        "type": "string",
+                        "description": "Only export the ended delegations with the given unbonding type",
+                        "name": "unbonding_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export the delegations to the given finality provider",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether overflow delegations are included, defaults to the server configuration",
+                        "name": "include_overflow",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "staking_amount",
+                            "start_height"
+                        ],
+                        "type": "string",
+                        "description": "Sort the delegations by the given field, the staking tx hash breaking ties. Defaults to the staking start height in descending order",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Order of the sort_by field, defaults to desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Pending export job",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ExportJobPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Error: Too many pending exports",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/export/{job_id}": {
+            "get": {
+                "description": "Retrieves the status of the export job, along with the link its file is downloaded from once completed.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job id",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ExportJobPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found, the job does not exist or has expired",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/export/{job_id}/download": {
+            "get": {
+                "description": "Downloads the CSV file of the completed export job.",
+                "produces": [
+                    "text/csv"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job id",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV export of the delegations",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found, the job does not exist or has expired",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Error: Conflict, the job has not completed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/linked": {
+            "get": {
+                "description": "Tells whether the two stakers are linked to the same entity. No data linking several\nkeys to one entity is indexed for now, hence ` + "`" + `supported` + "`" + ` is false and ` + "`" + `linked` + "`" + ` is null,\nwhich must not be read as the stakers not being linked.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Staker BTC Public Keys, exactly two of them",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Linkage of the stakers",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerLinkagePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/provider-stats": {
+            "get": {
+                "description": "Retrieves the total and active stake and delegation count of a staker with a given finality provider.\nOverflow delegations are not accounted for.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Finality Provider BTC Public Key",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stats of the staker with the finality provider",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerFinalityProviderStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/total-stake": {
+            "get": {
+                "description": "Retrieves the sum of the staking value in satoshis of the delegations of the staker,\nexcluding the unbonded and withdrawn ones. The overflow delegations are included unless hidden by default in the service configuration.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Total stake of the staker",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerTotalStakePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/unbondings": {
+            "get": {
+                "description": "Retrieves the unbonding requests submitted for the delegations of the staker, the most recent first,\nalong with the current state of the delegation. Filtering by the ` + "`" + `unbonding_requested` + "`" + ` state returns the pending ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the unbonding requests of a staker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of delegation states to filter by",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of unbonding requests",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of unbonding requests and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerUnbondingRequestPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staking/params": {
+            "get": {
+                "description": "Retrieves the parameters a wallet needs to build a valid staking transaction,\nfrom the global params version active at the next BTC height.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the staking parameters",
+                "responses": {
+                    "200": {
+                        "description": "Staking parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakingParamsPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Error: Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats": {
+            "get": {
+                "description": "Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.\nThe response carries an ETag that only changes with the stats data, polling with ` + "`" + `If-None-Match` + "`" + ` returns 304 if unchanged.\nWith ` + "`" + `respect_allowlist=true` + "`" + `, all the stats only account for the delegations to the allowlisted finality providers.\nThe unconfirmed tvl cannot be scoped to them, so it's reported as 0 and the stats as partial.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Overall Stats",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Scope the stats to the allowlisted finality providers, defaults to network-wide",
+                        "name": "respect_allowlist",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the previously fetched stats",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overall stats for babylon staking",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_OverallStatsPublic"
+                        }
+                    },
+                    "304": {
+                        "description": "Stats unchanged since the given ETag"
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/active-set": {
+            "get": {
+                "description": "Fetches the total active stake and delegation count split by whether the finality provider is in the active set.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Active Set Stake Breakdown",
+                "responses": {
+                    "200": {
+                        "description": "Active stake split by active set membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ActiveSetStakeBreakdownPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/finality-providers/count": {
+            "get": {
+                "description": "Fetches the number of distinct finality providers with at least one active delegation,\nalong with the number of registered finality providers. The result is cached for a short while.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Finality Provider Count",
+                "responses": {
+                    "200": {
+                        "description": "Finality provider count",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_FinalityProviderCountPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/history": {
+            "get": {
+                "description": "Fetches the staking activity bucketed per UTC day, hour or week starting on Monday, in ascending order of time.\nEach point carries the tvl and the number of delegations which started within the bucket, along with the cumulative total tvl and total delegations.\nBuckets without any new delegation are omitted, a range without any activity returns an empty series.\nThe range is inclusive and cannot exceed 365 days for the day granularity, 7 days for the hour granularity\nand 1825 days for the week granularity. For the latter, the range starts from the week of ` + "`" + `from` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Stats History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the range in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the range in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "hour",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Size of the buckets",
+                        "name": "granularity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stats history",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakingHistoryPointPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/inactive-provider-stake": {
+            "get": {
+                "description": "Fetches the total active stake delegated to finality providers that are not part of the active set.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Inactive Provider Stake",
+                "responses": {
+                    "200": {
+                        "description": "Stake delegated to inactive finality providers",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_InactiveProviderStakePublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/staker": {
+            "get": {
+                "description": "Fetches details of top stakers by their active total value locked (ActiveTvl) in descending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Top Staker Stats by Active TVL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of top stakers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of top stakers in the page, defaults to the configured page size and cannot exceed the configured max page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of top stakers by active tvl",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/staker/rank": {
+            "get": {
+                "description": "Fetches the rank of the staker, starting from 1, in the top stakers by active tvl along with its stats.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Staker Rank",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rank of the staker",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerRankPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/tvl": {
+            "get": {
+                "description": "Fetches the active TVL and the total amount of BTC locked, along with the definition of each figure.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get TVL",
+                "responses": {
+                    "200": {
+                        "description": "Active TVL and total locked BTC",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_TvlPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/unbonding/daily": {
+            "get": {
+                "description": "Fetches per UTC day the number and the total staking value of the delegations which started unbonding, in ascending order of day.\nThe range is inclusive and cannot exceed 90 days. It defaults to the last 30 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Daily Unbonding Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the range in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the range in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily unbonding stats",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DailyUnbondingStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding": {
+            "post": {
+                "description": "Unbonds a delegation by processing the provided transaction details. This is an async operation.\nRetries carrying the same ` + "`" + `Idempotency-Key` + "`" + ` header and payload get the outcome of the original request.\nWith ` + "`" + `dry_run=true` + "`" + ` the request goes through the same validations but nothing is saved, nor is the\nidempotency key recorded, and the outcome the request would have is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unbond delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key identifying the request across retries, up to 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key, required if API keys are configured on the server",
+                        "name": "X-Api-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the request without submitting it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Unbonding Request Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UnbondDelegationRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The request is valid, only returned for dry runs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingDryRunPublic"
+                        }
+                    },
+                    "202": {
+                        "description": "Request accepted and will be processed asynchronously"
+                    },
+                    "400": {
+                        "description": "Invalid request payload, or the unbonding tx or signature does not match the delegation (INVALID_SIGNATURE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/eligibility": {
+            "get": {
+                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding.\nIf not, the response carries a machine readable reason along with a human readable message.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check unbonding eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The delegation is eligible for unbonding",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingEligibilityPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "The delegation is not eligible for unbonding",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingEligibilityPublic"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_babylonchain_staking-api-service_internal_types.Error": {
+            "type": "object",
+            "properties": {
+                "err": {},
+                "errorCode": {
+                    "$ref": "#/definitions/types.ErrorCode"
+                },
+                "statusCode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.DelegationsBatchRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staking_tx_hash_hexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.FinalityProvidersBatchRequestPayload": {
+            "type": "object",
+            "properties": {
+                "finality_provider_pk_hexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DailyUnbondingStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DailyUnbondingStatsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DelegationMinimalPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationMinimalPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DelegationStateChangePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationStateChangePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FpDetailsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_StakerDailyActivityPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakerDailyActivityPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakerStatsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_StakerUnbondingRequestPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakerUnbondingRequestPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_StakingHistoryPointPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakingHistoryPointPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_UnbondingDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.UnbondingDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-map_string_int64": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_int64"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-map_string_services_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_services.DelegationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-map_string_services_FpDelegationCountPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_services.FpDelegationCountPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-map_string_services_FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_services.FpDetailsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-map_string_services_StakerDelegationsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_services.StakerDelegationsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_ActiveSetStakeBreakdownPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ActiveSetStakeBreakdownPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.DelegationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_ExportJobPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ExportJobPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_FinalityProviderCountPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.FinalityProviderCountPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.FpDetailsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_FpStakeStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.FpStakeStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.GlobalParamsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_InactiveProviderStakePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.InactiveProviderStakePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_OverallStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.OverallStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_ReadinessPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReadinessPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerDashboardPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerDashboardPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerFinalityProviderStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerFinalityProviderStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerLinkagePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerLinkagePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerRankPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerRankPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerTotalStakePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerTotalStakePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakingParamsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakingParamsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_TvlPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.TvlPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnbondingDryRunPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnbondingDryRunPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnbondingEligibilityPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnbondingEligibilityPublic"
+                },
+                "pagination": {
                     "$ref": "#/definitions/handlers.paginationResponse"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.Result": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.StakerDelegationsBatchRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staker_btc_pks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.UnbondDelegationRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staker_signed_signature_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.paginationResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "next_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "map_string_int64": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "integer"
+            }
+        },
+        "map_string_services.DelegationPublic": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/services.DelegationPublic"
+            }
+        },
+        "map_string_services.FpDelegationCountPublic": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/services.FpDelegationCountPublic"
+            }
+        },
+        "map_string_services.FpDetailsPublic": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/services.FpDetailsPublic"
+            }
+        },
+        "map_string_services.StakerDelegationsPublic": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/services.StakerDelegationsPublic"
+            }
+        },
+        "services.ActiveSetStakeBreakdownPublic": {
+            "type": "object",
+            "properties": {
+                "computed_at": {
+                    "type": "string"
+                },
+                "data_completeness": {
+                    "$ref": "#/definitions/services.DataCompleteness"
+                },
+                "in_active_set": {
+                    "$ref": "#/definitions/services.ActiveSetStakePublic"
+                },
+                "out_of_active_set": {
+                    "$ref": "#/definitions/services.ActiveSetStakePublic"
+                }
+            }
+        },
+        "services.ActiveSetStakePublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "finality_providers": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-array_services_StakerStatsPublic": {
+        "services.DailyUnbondingStatsPublic": {
             "type": "object",
             "properties": {
-                "data": {
+                "date": {
+                    "type": "string"
+                },
+                "unbonding_requests": {
+                    "type": "integer"
+                },
+                "unbonding_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.DataCompleteness": {
+            "type": "string",
+            "enum": [
+                "complete",
+                "stale",
+                "partial"
+            ],
+            "x-enum-varnames": [
+                "DataComplete",
+                "DataStale",
+                "DataPartial"
+            ]
+        },
+        "services.DelegationMinimalPublic": {
+            "type": "object",
+            "properties": {
+                "delegation_id": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "cap_position": {
+                    "description": "Only available when fetching a single delegation with its cap position\nincluded. The cap position is the active stake in sats filling the staking\ncap of the params version ahead of the delegation, to be compared against\nthe staking cap",
+                    "type": "integer"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "is_overflow": {
+                    "type": "boolean"
+                },
+                "required_covenant_quorum": {
+                    "description": "From the params version at the staking height",
+                    "type": "integer"
+                },
+                "staked_duration_seconds": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_cap": {
+                    "type": "integer"
+                },
+                "staking_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                },
+                "staking_tx_explorer_url": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "unbonding_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                },
+                "unbonding_type": {
+                    "description": "Only set once the delegation has ended",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DelegationStateChangePublic": {
+            "type": "object",
+            "properties": {
+                "btc_height": {
+                    "description": "Only available if the transition is triggered by a btc transaction",
+                    "type": "integer"
+                },
+                "from_state": {
+                    "description": "Empty when the delegation is created",
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "synthesized": {
+                    "description": "Set on the creation of the delegations made before the state changes\nare recorded, which is derived from their staking tx instead",
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "to_state": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ExportJobPublic": {
+            "type": "object",
+            "properties": {
+                "download_url": {
+                    "description": "Only set once completed",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "The job and its file are no longer available after this time",
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/services.ExportJobStatus"
+                }
+            }
+        },
+        "services.ExportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ExportJobPending",
+                "ExportJobCompleted",
+                "ExportJobFailed"
+            ]
+        },
+        "services.FinalityProviderCountPublic": {
+            "type": "object",
+            "properties": {
+                "active_finality_providers": {
+                    "description": "Finality providers having at least one active delegation",
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "type": "string"
+                },
+                "registered_finality_providers": {
+                    "description": "Finality providers registered in the finality providers config",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.FpDelegationCountPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_staker_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.FpDescriptionPublic": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "moniker": {
+                    "type": "string"
+                },
+                "security_contact": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_staker_count": {
+                    "description": "Only available when sorting by the number of active stakers",
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/services.FpDescriptionPublic"
+                },
+                "self_stake": {
+                    "type": "integer"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.FpStakeStatsPublic": {
+            "type": "object",
+            "properties": {
+                "active_staker_count": {
+                    "type": "integer"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "total_stake": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "versions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.StakerStatsPublic"
+                        "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
                     }
+                }
+            }
+        },
+        "services.HealthStatus": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "HealthStatusUp",
+                "HealthStatusDown"
+            ]
+        },
+        "services.InactiveProviderStakePublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "type": "string"
+                },
+                "data_completeness": {
+                    "$ref": "#/definitions/services.DataCompleteness"
+                },
+                "finality_providers": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-services_DelegationPublic": {
+        "services.OverallStatsPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.DelegationPublic"
+                "active_delegations": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "average_staking_value": {
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "type": "string"
+                },
+                "data_completeness": {
+                    "$ref": "#/definitions/services.DataCompleteness"
+                },
+                "median_staking_value": {
+                    "description": "Distribution of the staking value over the active delegations, refreshed\nperiodically. Omitted if it could not be computed.",
+                    "type": "integer"
+                },
+                "p90_staking_value": {
+                    "type": "integer"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_stakers": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                },
+                "unconfirmed_tvl": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-services_GlobalParamsPublic": {
+        "services.RangePublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.GlobalParamsPublic"
+                "max": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "min": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-services_OverallStatsPublic": {
+        "services.ReadinessPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.OverallStatsPublic"
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/services.HealthStatus"
+                    }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "status": {
+                    "description": "Down if any of the components is down",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.HealthStatus"
+                        }
+                    ]
                 }
             }
         },
-        "handlers.Result": {
+        "services.StakerDailyActivityPublic": {
             "type": "object",
             "properties": {
-                "data": {},
-                "status": {
+                "date": {
+                    "type": "string"
+                },
+                "delegations": {
+                    "type": "integer"
+                },
+                "staking_value": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.UnbondDelegationRequestPayload": {
+        "services.StakerDashboardPublic": {
             "type": "object",
             "properties": {
-                "staker_signed_signature_hex": {
-                    "type": "string"
+                "eligible_stake": {
+                    "description": "Staking value of the delegations that can be unbonded, i.e the active ones",
+                    "type": "integer"
                 },
-                "staking_tx_hash_hex": {
-                    "type": "string"
+                "finality_provider_count": {
+                    "description": "Finality providers the delegations whose BTC is still locked are delegated to",
+                    "type": "integer"
                 },
-                "unbonding_tx_hash_hex": {
-                    "type": "string"
+                "rank": {
+                    "description": "Rank in the top stakers by active tvl, null if the staker has no active stake",
+                    "type": "integer"
                 },
-                "unbonding_tx_hex": {
+                "recent_activity": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakerDailyActivityPublic"
+                    }
+                },
+                "staker_pk_hex": {
                     "type": "string"
+                },
+                "total_stake": {
+                    "description": "Staking value of the delegations whose BTC is still locked",
+                    "type": "integer"
                 }
             }
         },
-        "handlers.paginationResponse": {
+        "services.StakerDelegationsPublic": {
             "type": "object",
             "properties": {
+                "delegations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationPublic"
+                    }
+                },
                 "next_key": {
+                    "description": "Pagination key to fetch the next page of delegations of the staker\nfrom the staker delegations endpoint, empty if there are no more",
                     "type": "string"
                 }
             }
         },
-        "services.DelegationPublic": {
+        "services.StakerFinalityProviderStatsPublic": {
             "type": "object",
             "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
                 "finality_provider_pk_hex": {
                     "type": "string"
                 },
-                "is_overflow": {
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StakerLinkagePublic": {
+            "type": "object",
+            "properties": {
+                "linked": {
+                    "description": "Whether the stakers belong to the same entity, only set if supported",
                     "type": "boolean"
                 },
+                "staker_pk_hexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "supported": {
+                    "description": "Whether the linkage of the stakers is known at all",
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.StakerRankPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
                 "staker_pk_hex": {
                     "type": "string"
                 },
-                "staking_tx": {
-                    "$ref": "#/definitions/services.TransactionPublic"
+                "total_delegations": {
+                    "type": "integer"
                 },
-                "staking_tx_hash_hex": {
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
                     "type": "string"
                 },
-                "staking_value": {
+                "total_delegations": {
                     "type": "integer"
                 },
-                "state": {
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StakerTotalStakePublic": {
+            "type": "object",
+            "properties": {
+                "staker_pk_hex": {
                     "type": "string"
                 },
-                "unbonding_tx": {
-                    "$ref": "#/definitions/services.TransactionPublic"
+                "total_stake": {
+                    "description": "Sum of the staking value in satoshis",
+                    "type": "integer"
                 }
             }
         },
-        "services.FpDescriptionPublic": {
+        "services.StakerUnbondingRequestPublic": {
             "type": "object",
             "properties": {
-                "details": {
+                "requested_timestamp": {
                     "type": "string"
                 },
-                "identity": {
+                "staking_tx_hash_hex": {
                     "type": "string"
                 },
-                "moniker": {
+                "staking_value": {
+                    "type": "integer"
+                },
+                "state": {
                     "type": "string"
                 },
-                "security_contact": {
+                "unbonding_start_timestamp": {
                     "type": "string"
                 },
-                "website": {
+                "unbonding_tx_hash_hex": {
                     "type": "string"
                 }
             }
         },
-        "services.FpDetailsPublic": {
+        "services.StakingHistoryPointPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
+                "new_delegations": {
                     "type": "integer"
                 },
-                "active_tvl": {
+                "new_tvl": {
                     "type": "integer"
                 },
-                "btc_pk": {
-                    "type": "string"
-                },
-                "commission": {
+                "timestamp": {
                     "type": "string"
                 },
-                "description": {
-                    "$ref": "#/definitions/services.FpDescriptionPublic"
-                },
                 "total_delegations": {
                     "type": "integer"
                 },
@@ -491,80 +2977,185 @@ const docTemplate = `{
                 }
             }
         },
-        "services.GlobalParamsPublic": {
+        "services.StakingParamsPublic": {
             "type": "object",
             "properties": {
-                "versions": {
+                "confirmation_depth": {
+                    "type": "integer"
+                },
+                "covenant_pks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
+                        "type": "string"
                     }
+                },
+                "covenant_quorum": {
+                    "type": "integer"
+                },
+                "staking_amount": {
+                    "$ref": "#/definitions/services.RangePublic"
+                },
+                "staking_time": {
+                    "$ref": "#/definitions/services.RangePublic"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "unbonding_fee": {
+                    "type": "integer"
+                },
+                "unbonding_time": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "services.OverallStatsPublic": {
+        "services.TransactionPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
+                "output_index": {
                     "type": "integer"
                 },
-                "active_tvl": {
+                "start_height": {
                     "type": "integer"
                 },
-                "total_delegations": {
-                    "type": "integer"
+                "start_timestamp": {
+                    "type": "string"
                 },
-                "total_stakers": {
+                "timelock": {
                     "type": "integer"
                 },
-                "total_tvl": {
+                "tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.TvlPublic": {
+            "type": "object",
+            "properties": {
+                "active_tvl": {
                     "type": "integer"
                 },
-                "unconfirmed_tvl": {
+                "computed_at": {
+                    "type": "string"
+                },
+                "definitions": {
+                    "description": "What each of the figures above accounts for, keyed by the field name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "total_locked": {
                     "type": "integer"
                 }
             }
         },
-        "services.StakerStatsPublic": {
+        "services.UnbondingDelegationPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
+                "cap_position": {
+                    "description": "Only available when fetching a single delegation with its cap position\nincluded. The cap position is the active stake in sats filling the staking\ncap of the params version ahead of the delegation, to be compared against\nthe staking cap",
                     "type": "integer"
                 },
-                "active_tvl": {
+                "estimated_completion_timestamp": {
+                    "type": "string"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "is_overflow": {
+                    "type": "boolean"
+                },
+                "remaining_blocks": {
+                    "type": "integer"
+                },
+                "required_covenant_quorum": {
+                    "description": "From the params version at the staking height",
+                    "type": "integer"
+                },
+                "staked_duration_seconds": {
                     "type": "integer"
                 },
                 "staker_pk_hex": {
                     "type": "string"
                 },
-                "total_delegations": {
+                "staking_cap": {
                     "type": "integer"
                 },
-                "total_tvl": {
+                "staking_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                },
+                "staking_tx_explorer_url": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
                     "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "unbonding_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                },
+                "unbonding_type": {
+                    "description": "Only set once the delegation has ended",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "services.TransactionPublic": {
+        "services.UnbondingDryRunPublic": {
             "type": "object",
             "properties": {
-                "output_index": {
-                    "type": "integer"
-                },
-                "start_height": {
-                    "type": "integer"
+                "staking_tx_hash_hex": {
+                    "type": "string"
                 },
-                "start_timestamp": {
+                "state": {
+                    "description": "State the delegation would transition to",
                     "type": "string"
                 },
-                "timelock": {
-                    "type": "integer"
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.UnbondingEligibilityPublic": {
+            "type": "object",
+            "properties": {
+                "eligible": {
+                    "type": "boolean"
                 },
-                "tx_hex": {
+                "message": {
                     "type": "string"
+                },
+                "reason": {
+                    "$ref": "#/definitions/services.UnbondingIneligibilityReason"
                 }
             }
         },
+        "services.UnbondingIneligibilityReason": {
+            "type": "string",
+            "enum": [
+                "DELEGATION_NOT_FOUND",
+                "UNBONDING_ALREADY_REQUESTED",
+                "DELEGATION_UNBONDING",
+                "DELEGATION_ENDED"
+            ],
+            "x-enum-varnames": [
+                "DelegationNotFound",
+                "UnbondingAlreadyRequested",
+                "DelegationUnbonding",
+                "DelegationEnded"
+            ]
+        },
         "services.VersionedGlobalParamsPublic": {
             "type": "object",
             "properties": {
@@ -619,14 +3210,26 @@ const docTemplate = `{
                 "VALIDATION_ERROR",
                 "NOT_FOUND",
                 "BAD_REQUEST",
-                "FORBIDDEN"
+                "FORBIDDEN",
+                "SERVICE_UNAVAILABLE",
+                "TOO_MANY_REQUESTS",
+                "CONFLICT",
+                "INVALID_SIGNATURE",
+                "REQUEST_TIMEOUT",
+                "UNAUTHORIZED"
             ],
             "x-enum-varnames": [
                 "InternalServiceError",
                 "ValidationError",
                 "NotFound",
                 "BadRequest",
-                "Forbidden"
+                "Forbidden",
+                "ServiceUnavailable",
+                "TooManyRequests",
+                "Conflict",
+                "InvalidSignature",
+                "RequestTimeout",
+                "Unauthorized"
             ]
         }
     }
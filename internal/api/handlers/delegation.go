@@ -1,15 +1,45 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+// localizeDelegationTimestamps formats the timestamps of the delegation in the given location
+func localizeDelegationTimestamps(delegation *services.DelegationPublic, loc *time.Location) *types.Error {
+	for _, tx := range []*services.TransactionPublic{delegation.StakingTx, delegation.UnbondingTx} {
+		if tx == nil || tx.StartTimestamp == "" {
+			continue
+		}
+		localized, err := utils.ConvertIsoTimestampToLocation(tx.StartTimestamp, loc)
+		if err != nil {
+			return types.NewInternalServiceError(err)
+		}
+		tx.StartTimestamp = localized
+	}
+	if delegation.UpdatedAt != "" {
+		localized, err := utils.ConvertIsoTimestampToLocation(delegation.UpdatedAt, loc)
+		if err != nil {
+			return types.NewInternalServiceError(err)
+		}
+		delegation.UpdatedAt = localized
+	}
+	return nil
+}
+
 // GetDelegationByTxHash @Summary Get a delegation
-// @Description Retrieves a delegation by a given transaction hash
+// @Description Retrieves a delegation by a given transaction hash, optionally including its position in the staking cap
+// @Description fill order, i.e the active stake in sats of the non-overflow delegations filling the staking cap ahead of it
 // @Produce json
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param include_cap_position query bool false "Include the cap position and the staking cap, defaults to false"
+// @Param tz query string false "IANA timezone name the timestamps are formatted in, defaults to UTC"
 // @Success 200 {object} PublicResponse[services.DelegationPublic] "Delegation"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/delegation [get]
@@ -18,10 +48,136 @@ func (h *Handler) GetDelegationByTxHash(request *http.Request) (*Result, *types.
 	if err != nil {
 		return nil, err
 	}
-	delegation, err := h.services.GetDelegation(request.Context(), stakingTxHash)
+	includeCapPosition, err := parseOptionalBoolQuery(request, "include_cap_position")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := parseTimezoneQuery(request, "tz")
+	if err != nil {
+		return nil, err
+	}
+	delegation, err := h.services.GetDelegationPublic(
+		request.Context(), stakingTxHash, includeCapPosition != nil && *includeCapPosition,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := localizeDelegationTimestamps(delegation, loc); err != nil {
+		return nil, err
+	}
+
+	return NewResult(delegation), nil
+}
+
+// Maximum number of delegations that can be fetched in a single batch request
+const maxDelegationsBatchSize = 100
+
+type DelegationsBatchRequestPayload struct {
+	StakingTxHashHexes []string `json:"staking_tx_hash_hexes"`
+}
+
+func parseDelegationsBatchRequestPayload(request *http.Request) (*DelegationsBatchRequestPayload, *types.Error) {
+	payload := &DelegationsBatchRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if len(payload.StakingTxHashHexes) == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "staking_tx_hash_hexes is required",
+		)
+	}
+	if len(payload.StakingTxHashHexes) > maxDelegationsBatchSize {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("at most %d delegations can be fetched at once", maxDelegationsBatchSize),
+		)
+	}
+	for i, txHashHex := range payload.StakingTxHashHexes {
+		normalizedTxHashHex, err := utils.NormalizeTxHash(txHashHex)
+		if err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				"invalid staking_tx_hash_hex "+txHashHex+": "+err.Error(),
+			)
+		}
+		payload.StakingTxHashHexes[i] = normalizedTxHashHex
+	}
+	return payload, nil
+}
+
+// GetDelegationsByTxHashes @Summary Get multiple delegations
+// @Description Retrieves the delegations identified by the given staking transaction hashes, keyed by the
+// @Description normalized lowercase hash. Hashes without a matching delegation are included with a null value.
+// @Accept json
+// @Produce json
+// @Param payload body DelegationsBatchRequestPayload true "List of at most 100 staking transaction hashes"
+// @Param tz query string false "IANA timezone name the timestamps are formatted in, defaults to UTC"
+// @Success 200 {object} PublicResponse[map[string]services.DelegationPublic] "Delegations keyed by staking tx hash"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/delegations [post]
+func (h *Handler) GetDelegationsByTxHashes(request *http.Request) (*Result, *types.Error) {
+	loc, err := parseTimezoneQuery(request, "tz")
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parseDelegationsBatchRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := h.services.GetDelegationsPublicByTxHashes(request.Context(), payload.StakingTxHashHexes)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegation := range delegations {
+		if delegation == nil {
+			continue
+		}
+		if err := localizeDelegationTimestamps(delegation, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewResult(delegations), nil
+}
+
+// GetDelegationByStakingOutput @Summary Get a delegation by its staking output
+// @Description Retrieves a delegation of a staker by the staking output, identified by the staking tx hash and the output index
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param txid query string true "Staking transaction hash in hex format"
+// @Param vout query integer true "Index of the staking output in the staking transaction"
+// @Param tz query string false "IANA timezone name the timestamps are formatted in, defaults to UTC"
+// @Success 200 {object} PublicResponse[services.DelegationPublic] "Delegation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/delegation/by-output [get]
+func (h *Handler) GetDelegationByStakingOutput(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	stakingTxHash, err := parseTxHashQuery(request, "txid")
 	if err != nil {
 		return nil, err
 	}
+	outputIndex, err := parseUint64Query(request, "vout")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := parseTimezoneQuery(request, "tz")
+	if err != nil {
+		return nil, err
+	}
+	delegation, err := h.services.GetDelegationByStakingOutput(
+		request.Context(), stakerBtcPk, stakingTxHash, outputIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := localizeDelegationTimestamps(delegation, loc); err != nil {
+		return nil, err
+	}
 
 	return NewResult(delegation), nil
 }
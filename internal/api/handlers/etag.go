@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NewResultWithETag returns a successful result with the given ETag, or an empty
+// 304 result if the request's If-None-Match header matches the ETag.
+func NewResultWithETag[T any](request *http.Request, data T, etag string) *Result {
+	quotedETag := `"` + etag + `"`
+	headers := http.Header{}
+	headers.Set("ETag", quotedETag)
+	if matchesIfNoneMatch(request.Header.Get("If-None-Match"), quotedETag) {
+		return &Result{Status: http.StatusNotModified, Headers: headers}
+	}
+	result := NewResult(data)
+	result.Headers = headers
+	return result
+}
+
+// matchesIfNoneMatch checks whether the If-None-Match header value matches the
+// quoted ETag, using the weak comparison as required for If-None-Match
+func matchesIfNoneMatch(ifNoneMatch, quotedETag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == quotedETag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// GetDelegationStateChanges godoc
+// @Summary Get the feed of delegation state changes
+// @Description Retrieves the state changes across all delegations in the order they were recorded.
+// @Description The pagination key is always returned, consumers are expected to keep the last one
+// @Description and poll with it to fetch the state changes recorded since.
+// @Description The delegations created before the state changes were first recorded are not part of the feed.
+// @Produce json
+// @Param pagination_key query string false "Pagination key to fetch the next page of state changes"
+// @Success 200 {object} PublicResponse[[]services.DelegationStateChangePublic]{array} "List of delegation state changes and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/events/delegations [get]
+func (h *Handler) GetDelegationStateChanges(request *http.Request) (*Result, *types.Error) {
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	stateChanges, newPaginationKey, err := h.services.DelegationStateChanges(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResultWithPagination(stateChanges, newPaginationKey), nil
+}
+
+// GetDelegationStateHistory godoc
+// @Summary Get the state history of a delegation
+// @Description Retrieves the state transitions of a delegation identified by its staking transaction hash,
+// @Description from its creation onwards in the order they were recorded. The creation of the delegations made before
+// @Description the state changes are recorded is derived from their staking transaction and flagged as synthesized,
+// @Description their transitions made before the recording started are missing.
+// @Produce json
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} PublicResponse[[]services.DelegationStateChangePublic]{array} "List of the delegation state changes"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/delegation/history [get]
+func (h *Handler) GetDelegationStateHistory(request *http.Request) (*Result, *types.Error) {
+	stakingTxHash, err := parseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	stateChanges, err := h.services.DelegationStateHistory(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(stateChanges), nil
+}
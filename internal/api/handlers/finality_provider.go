@@ -1,26 +1,294 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+func parseFpSortByQuery(r *http.Request) (services.FpSortBy, *types.Error) {
+	sortBy := services.FpSortBy(r.URL.Query().Get("sort_by"))
+	switch sortBy {
+	case "":
+		return services.FpSortByActiveTvl, nil
+	case services.FpSortByActiveTvl, services.FpSortByActiveStakerCount, services.FpSortBySelfStake:
+		return sortBy, nil
+	default:
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid sort_by value",
+		)
+	}
+}
+
+func parseFinalityProvidersFilter(
+	r *http.Request, sortBy services.FpSortBy,
+) (*services.FinalityProvidersFilter, *types.Error) {
+	filter := &services.FinalityProvidersFilter{}
+	if minSelfStake := r.URL.Query().Get("min_self_stake"); minSelfStake != "" {
+		if sortBy != services.FpSortBySelfStake {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "min_self_stake is only supported with sort_by=self_stake",
+			)
+		}
+		parsed, err := strconv.ParseInt(minSelfStake, 10, 64)
+		if err != nil || parsed < 0 {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid min_self_stake",
+			)
+		}
+		filter.MinSelfStake = parsed
+	}
+	filter.Name = strings.TrimSpace(r.URL.Query().Get("name"))
+	return filter, nil
+}
+
 // GetFinalityProviders gets active finality providers sorted by ActiveTvl.
 // @Summary Get Active Finality Providers
 // @Description Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order.
+// @Description Use `sort_by=active_staker_count` to sort them by the number of distinct stakers with active delegations instead,
+// @Description or `sort_by=self_stake` to sort them by the active stake they delegated to themselves.
 // @Produce json
 // @Param pagination_key query string false "Pagination key to fetch the next page of finality providers"
+// @Param sort_by query string false "Sort order of the finality providers" Enums(active_tvl, active_staker_count, self_stake)
+// @Param min_self_stake query integer false "Only return the finality providers with at least this self stake, requires sort_by=self_stake"
+// @Param name query string false "Only return the finality providers whose moniker contains this value, case-insensitive"
 // @Success 200 {object} PublicResponse[[]services.FpDetailsPublic] "A list of finality providers sorted by ActiveTvl in descending order"
+// @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/finality-providers [get]
 func (h *Handler) GetFinalityProviders(request *http.Request) (*Result, *types.Error) {
-	paginationKey, err := parsePaginationQuery(request)
+	paginationKey, err := h.parsePaginationQuery(request)
 	if err != nil {
 		return nil, err
 	}
-	fps, paginationToken, err := h.services.GetFinalityProviders(request.Context(), paginationKey)
+	sortBy, err := parseFpSortByQuery(request)
 	if err != nil {
 		return nil, err
 	}
+	filter, err := parseFinalityProvidersFilter(request, sortBy)
+	if err != nil {
+		return nil, err
+	}
+	fps, paginationToken, truncated, err := h.services.GetFinalityProviders(request.Context(), paginationKey, sortBy, filter)
+	if err != nil {
+		return nil, err
+	}
+	// The registered finality providers without stats are appended to the last page
+	// regardless of the page size, hence the page may be cut short of them
+	if truncated {
+		return NewTruncatedResultWithPagination(
+			fps, paginationToken, h.config.Server.MaxUnpaginatedResults,
+		), nil
+	}
 	return NewResultWithPagination(fps, paginationToken), nil
 }
+
+// Maximum number of finality providers that can be fetched in a single batch request
+const maxFinalityProvidersBatchSize = 100
+
+type FinalityProvidersBatchRequestPayload struct {
+	FinalityProviderPkHexes []string `json:"finality_provider_pk_hexes"`
+}
+
+func parseFinalityProvidersBatchRequestPayload(request *http.Request) (*FinalityProvidersBatchRequestPayload, *types.Error) {
+	payload := &FinalityProvidersBatchRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if len(payload.FinalityProviderPkHexes) == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "finality_provider_pk_hexes is required",
+		)
+	}
+	if len(payload.FinalityProviderPkHexes) > maxFinalityProvidersBatchSize {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("at most %d finality providers can be fetched at once", maxFinalityProvidersBatchSize),
+		)
+	}
+	for _, pkHex := range payload.FinalityProviderPkHexes {
+		if _, err := utils.GetSchnorrPkFromHex(pkHex); err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid finality provider pk hex: "+pkHex,
+			)
+		}
+	}
+	return payload, nil
+}
+
+// GetFinalityProvidersBatch gets the finality providers matching a list of pks.
+// @Summary Get Finality Providers by pks
+// @Description Fetches the details of the finality providers matching the given pks, keyed by pk hex.
+// @Description Unknown pks are omitted from the result.
+// @Accept json
+// @Produce json
+// @Param payload body FinalityProvidersBatchRequestPayload true "List of finality provider pks"
+// @Success 200 {object} PublicResponse[map[string]services.FpDetailsPublic] "Finality providers keyed by pk hex"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/finality-providers/batch [post]
+func (h *Handler) GetFinalityProvidersBatch(request *http.Request) (*Result, *types.Error) {
+	payload, err := parseFinalityProvidersBatchRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	fps, err := h.services.GetFinalityProvidersByPkHex(request.Context(), payload.FinalityProviderPkHexes)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(fps), nil
+}
+
+// GetFinalityProvider gets the details of a single finality provider.
+// @Summary Get Finality Provider
+// @Description Fetches the details of the finality provider with the given pk.
+// @Produce json
+// @Param finality_provider_pk_hex query string true "Finality Provider BTC Public Key"
+// @Success 200 {object} PublicResponse[services.FpDetailsPublic] "Finality provider details"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/finality-provider [get]
+func (h *Handler) GetFinalityProvider(request *http.Request) (*Result, *types.Error) {
+	fpPkHex, err := parsePublicKeyQuery(request, "finality_provider_pk_hex")
+	if err != nil {
+		return nil, err
+	}
+	fp, err := h.services.GetFinalityProvider(request.Context(), fpPkHex)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(fp), nil
+}
+
+// GetFinalityProviderDelegationCounts gets the delegation counts of all the finality providers.
+// @Summary Get Finality Provider Delegation Counts
+// @Description Fetches the number of active delegations and distinct stakers of the finality providers, keyed by pk hex.
+// @Description The delegations whose unbonding is requested are active until the unbonding tx is confirmed.
+// @Description The finality providers without any active delegation are omitted.
+// @Produce json
+// @Param pagination_key query string false "Pagination key to fetch the next page of finality providers"
+// @Param include_overflow query boolean false "Whether overflow delegations are included, defaults to the server configuration"
+// @Success 200 {object} PublicResponse[map[string]services.FpDelegationCountPublic] "Delegation counts keyed by finality provider pk hex"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-providers/delegation-counts [get]
+func (h *Handler) GetFinalityProviderDelegationCounts(request *http.Request) (*Result, *types.Error) {
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	includeOverflow, err := parseOptionalBoolQuery(request, "include_overflow")
+	if err != nil {
+		return nil, err
+	}
+	delegationCounts, paginationToken, err := h.services.GetFinalityProviderDelegationCounts(
+		request.Context(), paginationKey, includeOverflow,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyedResultWithPagination(delegationCounts, paginationToken), nil
+}
+
+// GetFinalityProviderStakerCounts gets the active staker counts of all the finality providers.
+// @Summary Get Finality Provider Staker Counts
+// @Description Fetches the number of distinct stakers with an active delegation to the finality providers, keyed by pk hex.
+// @Description The delegations whose unbonding is requested are active until the unbonding tx is confirmed.
+// @Description The finality providers without any active delegation are omitted.
+// @Produce json
+// @Param pagination_key query string false "Pagination key to fetch the next page of finality providers"
+// @Param include_overflow query boolean false "Whether overflow delegations are included, defaults to the server configuration"
+// @Success 200 {object} PublicResponse[map[string]int64] "Active staker counts keyed by finality provider pk hex"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-providers/staker-counts [get]
+func (h *Handler) GetFinalityProviderStakerCounts(request *http.Request) (*Result, *types.Error) {
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	includeOverflow, err := parseOptionalBoolQuery(request, "include_overflow")
+	if err != nil {
+		return nil, err
+	}
+	stakerCounts, paginationToken, err := h.services.GetFinalityProviderStakerCounts(
+		request.Context(), paginationKey, includeOverflow,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyedResultWithPagination(stakerCounts, paginationToken), nil
+}
+
+// GetFinalityProviderDelegations gets the delegations to a finality provider.
+// @Summary Get Finality Provider Delegations
+// @Description Retrieves the delegations to a given finality provider, sorted by the staking start height in descending order.
+// @Produce json
+// @Param finality_provider_pk_hex query string true "Finality Provider BTC Public Key"
+// @Param state query string false "Comma separated list of the states of the delegations to return" Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param tz query string false "IANA timezone name the timestamps are formatted in, defaults to UTC"
+// @Param limit query integer false "Maximum number of delegations in the page, defaults to the configured page size and cannot exceed the configured max page size"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} PublicResponse[[]services.DelegationPublic]{array} "List of delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-provider/delegations [get]
+func (h *Handler) GetFinalityProviderDelegations(request *http.Request) (*Result, *types.Error) {
+	fpPkHex, err := parsePublicKeyQuery(request, "finality_provider_pk_hex")
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := h.parsePageSizeQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	states, err := parseDelegationStatesQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := parseTimezoneQuery(request, "tz")
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.services.DelegationsByFinalityProviderPk(
+		request.Context(), fpPkHex, states, paginationKey, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range delegations {
+		if err := localizeDelegationTimestamps(&delegations[i], loc); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// GetFinalityProviderStakeStats gets the stake stats of a finality provider.
+// @Summary Get Finality Provider Stake Stats
+// @Description Retrieves the total staking value and the number of distinct stakers of the delegations to a finality provider.
+// @Description Only the delegations whose BTC is still locked are accounted for, i.e. the unbonded and withdrawn delegations are excluded.
+// @Produce json
+// @Param finality_provider_pk_hex query string true "Finality Provider BTC Public Key"
+// @Success 200 {object} PublicResponse[services.FpStakeStatsPublic] "Stake stats of the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-provider/stats [get]
+func (h *Handler) GetFinalityProviderStakeStats(request *http.Request) (*Result, *types.Error) {
+	fpPkHex, err := parsePublicKeyQuery(request, "finality_provider_pk_hex")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.services.GetFinalityProviderStakeStats(request.Context(), fpPkHex)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(stats), nil
+}
@@ -2,7 +2,15 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/services"
@@ -11,31 +19,87 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 )
 
+const csvMediaType = "text/csv"
+
 type Handler struct {
 	config   *config.Config
 	services *services.Services
+	// Secret the pagination tokens are authenticated with
+	paginationTokenSecret []byte
 }
 
 type paginationResponse struct {
 	NextKey string `json:"next_key"`
+	HasNext bool   `json:"has_next"`
+	Count   int    `json:"count"`
 }
 
 type PublicResponse[T any] struct {
 	Data       T                   `json:"data"`
 	Pagination *paginationResponse `json:"pagination,omitempty"`
+	Warning    string              `json:"warning,omitempty"`
 }
 
 type Result struct {
 	Data   interface{}
 	Status int
+	// Extra headers set on the response
+	Headers http.Header `swaggerignore:"true"`
+	// Writes the response body in place of Data, for the responses streamed
+	// rather than buffered in memory
+	Stream func(w io.Writer) error `swaggerignore:"true"`
+}
+
+// NewResultWithPagination returns a successful result with the pagination metadata
+// of the returned page, with default status code 200
+func NewResultWithPagination[T any](data []T, pageToken string) *Result {
+	res := &PublicResponse[[]T]{
+		Data: data,
+		Pagination: &paginationResponse{
+			NextKey: pageToken,
+			HasNext: pageToken != "",
+			Count:   len(data),
+		},
+	}
+	return &Result{Data: res, Status: http.StatusOK}
 }
 
-// NewResult returns a successful result, with default status code 200
-func NewResultWithPagination[T any](data T, pageToken string) *Result {
-	res := &PublicResponse[T]{Data: data, Pagination: &paginationResponse{NextKey: pageToken}}
+// NewKeyedResultWithPagination is the same as NewResultWithPagination, except
+// that the items of the returned page are keyed in a map
+func NewKeyedResultWithPagination[K comparable, V any](data map[K]V, pageToken string) *Result {
+	res := &PublicResponse[map[K]V]{
+		Data: data,
+		Pagination: &paginationResponse{
+			NextKey: pageToken,
+			HasNext: pageToken != "",
+			Count:   len(data),
+		},
+	}
 	return &Result{Data: res, Status: http.StatusOK}
 }
 
+// NewTruncatedResultWithPagination is the same as NewResultWithPagination, except
+// that a warning is attached to tell that the page has been cut short at
+// maxResults items. The page token must resume right after the last item of
+// data, so that no item is lost by the truncation.
+func NewTruncatedResultWithPagination[T any](data []T, pageToken string, maxResults int) *Result {
+	result := NewResultWithPagination(data, pageToken)
+	result.Data.(*PublicResponse[[]T]).Warning = fmt.Sprintf(
+		"result truncated to %d items, use the pagination key to fetch the rest", maxResults,
+	)
+	return result
+}
+
+// NewStreamResult returns a successful result whose body of the given content
+// type is written by the stream function, with default status code 200
+func NewStreamResult(contentType string, stream func(w io.Writer) error) *Result {
+	return &Result{
+		Status:  http.StatusOK,
+		Headers: http.Header{"Content-Type": []string{contentType}},
+		Stream:  stream,
+	}
+}
+
 func NewResult[T any](data T) *Result {
 	res := &PublicResponse[T]{Data: data}
 	return &Result{Data: res, Status: http.StatusOK}
@@ -45,17 +109,19 @@ func New(
 	ctx context.Context, cfg *config.Config, services *services.Services,
 ) (*Handler, error) {
 	return &Handler{
-		config:   cfg,
-		services: services,
+		config:                cfg,
+		services:              services,
+		paginationTokenSecret: []byte(cfg.Db.PaginationTokenSecret),
 	}, nil
 }
 
-func parsePaginationQuery(r *http.Request) (string, *types.Error) {
+func (h *Handler) parsePaginationQuery(r *http.Request) (string, *types.Error) {
 	pageKey := r.URL.Query().Get("pagination_key")
 	if pageKey == "" {
 		return "", nil
 	}
-	if !utils.IsBase64Encoded(pageKey) {
+	// Forged or corrupted keys are rejected before reaching the db query
+	if _, err := utils.DecodePaginationToken(h.paginationTokenSecret, pageKey); err != nil {
 		return "", types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid pagination key format",
 		)
@@ -70,8 +136,7 @@ func parsePublicKeyQuery(r *http.Request, queryName string) (string, *types.Erro
 			http.StatusBadRequest, types.BadRequest, queryName+" is required",
 		)
 	}
-	_, err := utils.GetSchnorrPkFromHex(pkHex)
-	if err != nil {
+	if !isValidPublicKey(pkHex) {
 		return "", types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
 		)
@@ -79,6 +144,12 @@ func parsePublicKeyQuery(r *http.Request, queryName string) (string, *types.Erro
 	return pkHex, nil
 }
 
+// isValidPublicKey checks whether the pk hex is a valid BTC schnorr public key
+func isValidPublicKey(pkHex string) bool {
+	_, err := utils.GetSchnorrPkFromHex(pkHex)
+	return err == nil
+}
+
 func parseTxHashQuery(r *http.Request, queryName string) (string, *types.Error) {
 	txHashHex := r.URL.Query().Get(queryName)
 	if txHashHex == "" {
@@ -86,12 +157,265 @@ func parseTxHashQuery(r *http.Request, queryName string) (string, *types.Error)
 			http.StatusBadRequest, types.BadRequest, queryName+" is required",
 		)
 	}
-	if !utils.IsValidTxHash(txHashHex) {
+	normalizedTxHashHex, err := utils.NormalizeTxHash(txHashHex)
+	if err != nil {
 		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName+": "+err.Error(),
+		)
+	}
+	return normalizedTxHashHex, nil
+}
+
+func parseUint64Query(r *http.Request, queryName string) (uint64, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, queryName+" is required",
+		)
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return parsed, nil
+}
+
+// parseOptionalBoolQuery parses the optional boolean query parameter.
+// It returns nil if not provided.
+func parseOptionalBoolQuery(r *http.Request, queryName string) (*bool, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return &parsed, nil
+}
+
+// parseOptionalUint64Query parses the optional uint64 query parameter.
+// It returns nil if not provided.
+func parseOptionalUint64Query(r *http.Request, queryName string) (*uint64, *types.Error) {
+	if r.URL.Query().Get(queryName) == "" {
+		return nil, nil
+	}
+	parsed, err := parseUint64Query(r, queryName)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// parseLimitQuery parses the optional `limit` query parameter, which must be
+// within [1, maxLimit]. It returns 0 if not provided, leaving the default
+// page size to the caller.
+func parseLimitQuery(r *http.Request, maxLimit uint64) (int64, *types.Error) {
+	limit, err := parseOptionalUint64Query(r, "limit")
+	if err != nil || limit == nil {
+		return 0, err
+	}
+	if *limit == 0 || *limit > maxLimit {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("limit must be between 1 and %d", maxLimit),
+		)
+	}
+	return int64(*limit), nil
+}
+
+// jsonFieldNames returns the JSON names of the exported fields of the struct
+func jsonFieldNames(v interface{}) map[string]struct{} {
+	names := make(map[string]struct{})
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+	return names
+}
+
+// parseFieldsQuery parses the optional `fields` query parameter, a comma
+// separated list of the JSON fields to return out of the known ones. It
+// returns nil if not provided, i.e all the fields are returned.
+func parseFieldsQuery(r *http.Request, knownFields map[string]struct{}) ([]string, *types.Error) {
+	value := r.URL.Query().Get("fields")
+	if value == "" {
+		return nil, nil
+	}
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := knownFields[field]; !ok {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "unknown field: "+field,
+			)
+		}
+		if !utils.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// selectFields trims each item down to the given JSON fields. The fields
+// omitted from the JSON encoding of an item are omitted as well.
+func selectFields[T any](items []T, fields []string) ([]map[string]json.RawMessage, *types.Error) {
+	selected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, types.NewInternalServiceError(err)
+		}
+		var allFields map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &allFields); err != nil {
+			return nil, types.NewInternalServiceError(err)
+		}
+		itemFields := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := allFields[field]; ok {
+				itemFields[field] = value
+			}
+		}
+		selected = append(selected, itemFields)
+	}
+	return selected, nil
+}
+
+// parseCsvFormat returns whether the response is requested as CSV, either with
+// the `format=csv` query param or a `text/csv` Accept header. The query param
+// prevails over the header, JSON being the default format.
+func parseCsvFormat(r *http.Request) (bool, *types.Error) {
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		return true, nil
+	case "json":
+		return false, nil
+	case "":
+	default:
+		return false, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "format must be either json or csv",
+		)
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == csvMediaType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parsePageSizeQuery parses the optional `limit` query parameter of the
+// paginated listings against the configured max page size. It returns 0 if
+// not provided, in which case the configured default page size applies.
+func (h *Handler) parsePageSizeQuery(r *http.Request) (int64, *types.Error) {
+	_, maxPageSize := h.config.PageSizeLimits()
+	return parseLimitQuery(r, uint64(maxPageSize))
+}
+
+// parseMinConfirmationsQuery parses the optional `min_confirmations` query
+// parameter. It defaults to 0, i.e no minimum is requested.
+func parseMinConfirmationsQuery(r *http.Request) (uint64, *types.Error) {
+	minConfirmations, err := parseOptionalUint64Query(r, "min_confirmations")
+	if err != nil || minConfirmations == nil {
+		return 0, err
+	}
+	return *minConfirmations, nil
+}
+
+// parseDelegationStatesQuery parses the optional comma separated list of
+// delegation states. It returns nil if not provided.
+func parseDelegationStatesQuery(r *http.Request, queryName string) ([]types.DelegationState, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return nil, nil
+	}
+	var states []types.DelegationState
+	for _, str := range strings.Split(value, ",") {
+		state, err := types.FromStringToDelegationState(strings.TrimSpace(str))
+		if err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid "+queryName+": "+err.Error(),
+			)
+		}
+		if !utils.Contains(states, state) {
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+// parseTimezoneQuery parses the optional IANA timezone name query parameter.
+// It defaults to UTC if not provided.
+func parseTimezoneQuery(r *http.Request, queryName string) (*time.Location, *types.Error) {
+	tz := r.URL.Query().Get(queryName)
+	if tz == "" {
+		return time.UTC, nil
+	}
+	// time.LoadLocation treats "Local" as the server timezone, which is not a valid IANA name
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return nil, types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
 		)
 	}
-	return txHashHex, nil
+	return loc, nil
+}
+
+// parseDateRangeQuery parses the inclusive `from` and `to` day query parameters
+// in YYYY-MM-DD format as UTC days. `to` defaults to today and `from` defaults
+// to 30 days before `to`, capped by the max range.
+func parseDateRangeQuery(r *http.Request, maxRangeInDays int) (time.Time, time.Time, *types.Error) {
+	parseDate := func(queryName string, defaultDate time.Time) (time.Time, *types.Error) {
+		str := r.URL.Query().Get(queryName)
+		if str == "" {
+			return defaultDate, nil
+		}
+		date, err := time.Parse(time.DateOnly, str)
+		if err != nil {
+			return time.Time{}, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid "+queryName+", expected YYYY-MM-DD",
+			)
+		}
+		return date, nil
+	}
+
+	now := time.Now().UTC()
+	toDate, err := parseDate("to", time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	fromDate, err := parseDate("from", toDate.AddDate(0, 0, -min(30, maxRangeInDays)+1))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "from must not be after to",
+		)
+	}
+	if fromDate.AddDate(0, 0, maxRangeInDays).Before(toDate.AddDate(0, 0, 1)) {
+		return time.Time{}, time.Time{}, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("date range cannot exceed %d days", maxRangeInDays),
+		)
+	}
+	return fromDate, toDate, nil
 }
 
 func parseBtcAddressQuery(
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
@@ -20,3 +21,29 @@ func (h *Handler) HealthCheck(request *http.Request) (*Result, *types.Error) {
 
 	return NewResult("Server is up and running"), nil
 }
+
+// HealthCheckLive godoc
+// @Summary Liveness check endpoint
+// @Description Checks that the server is up, without checking its dependencies
+// @Produce json
+// @Success 200 {string} PublicResponse[string] "Server is up and running"
+// @Router /healthcheck/live [get]
+func (h *Handler) HealthCheckLive(request *http.Request) (*Result, *types.Error) {
+	return NewResult("Server is up and running"), nil
+}
+
+// HealthCheckReady godoc
+// @Summary Readiness check endpoint
+// @Description Checks that the dependencies of the server, i.e the database, the cache and the queues, are reachable within the readiness check timeout, with the status of each of them
+// @Produce json
+// @Success 200 {object} PublicResponse[services.ReadinessPublic] "All the dependencies are up"
+// @Failure 503 {object} PublicResponse[services.ReadinessPublic] "Some of the dependencies are down"
+// @Router /healthcheck/ready [get]
+func (h *Handler) HealthCheckReady(request *http.Request) (*Result, *types.Error) {
+	readiness := h.services.CheckReadiness(request.Context())
+	result := NewResult(readiness)
+	if readiness.Status != services.HealthStatusUp {
+		result.Status = http.StatusServiceUnavailable
+	}
+	return result, nil
+}
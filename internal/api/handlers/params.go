@@ -9,10 +9,45 @@ import (
 // GetBabylonGlobalParams godoc
 // @Summary Get Babylon global parameters
 // @Description Retrieves the global parameters for Babylon, including finality provider details.
+// @Description All the versions are returned unless a single one is requested.
+// @Description The response carries an ETag, a 304 is returned if it matches the If-None-Match header.
 // @Produce json
+// @Param version query integer false "Only return the given params version"
+// @Param If-None-Match header string false "ETag of the params held by the client"
 // @Success 200 {object} PublicResponse[services.GlobalParamsPublic] "Global parameters"
+// @Success 304 "Global parameters not modified"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
 // @Router /v1/global-params [get]
 func (h *Handler) GetBabylonGlobalParams(request *http.Request) (*Result, *types.Error) {
+	version, err := parseOptionalUint64Query(request, "version")
+	if err != nil {
+		return nil, err
+	}
+	if version != nil {
+		params, err := h.services.GetGlobalParamsVersionPublic(*version)
+		if err != nil {
+			return nil, err
+		}
+		return NewResultWithETag(request, params, params.ContentVersion()), nil
+	}
 	params := h.services.GetGlobalParamsPublic()
+	return NewResultWithETag(request, params, params.ContentVersion()), nil
+}
+
+// GetStakingParams godoc
+// @Summary Get the staking parameters
+// @Description Retrieves the parameters a wallet needs to build a valid staking transaction,
+// @Description from the global params version active at the next BTC height.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.StakingParamsPublic] "Staking parameters"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Failure 503 {object} types.Error "Error: Service Unavailable"
+// @Router /v1/staking/params [get]
+func (h *Handler) GetStakingParams(request *http.Request) (*Result, *types.Error) {
+	params, err := h.services.GetStakingParamsPublic(request.Context())
+	if err != nil {
+		return nil, err
+	}
 	return NewResult(params), nil
 }
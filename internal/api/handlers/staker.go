@@ -1,45 +1,600 @@
 package handlers
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"time"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/go-chi/chi"
 )
 
+func parseStakerDelegationsFilter(request *http.Request) (*services.StakerDelegationsFilter, *types.Error) {
+	filter := &services.StakerDelegationsFilter{}
+	states, err := parseDelegationStatesQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	filter.States = states
+	if unbondingType := request.URL.Query().Get("unbonding_type"); unbondingType != "" {
+		parsed, err := types.FromStringToUnbondingType(unbondingType)
+		if err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid unbonding_type",
+			)
+		}
+		filter.UnbondingType = parsed
+	}
+	if request.URL.Query().Get("finality_provider_pk_hex") != "" {
+		fpPkHex, err := parsePublicKeyQuery(request, "finality_provider_pk_hex")
+		if err != nil {
+			return nil, err
+		}
+		filter.FinalityProviderPkHex = fpPkHex
+	}
+	includeOverflow, err := parseOptionalBoolQuery(request, "include_overflow")
+	if err != nil {
+		return nil, err
+	}
+	filter.IncludeOverflow = includeOverflow
+	belowMin, err := parseOptionalBoolQuery(request, "below_min")
+	if err != nil {
+		return nil, err
+	}
+	filter.BelowMinStakingAmount = belowMin
+	timelockRange, err := parseStakingTimelockRange(request)
+	if err != nil {
+		return nil, err
+	}
+	filter.StakingTimelock = timelockRange
+	minConfirmations, err := parseMinConfirmationsQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	filter.MinConfirmations = minConfirmations
+	return filter, nil
+}
+
+// parseStakerQuery parses the staker either from its `staker_btc_pk` or from its
+// Taproot `address`, exactly one of which must be provided. The address is mapped
+// to the staker pk from the stored delegations. An empty pk is returned if no
+// delegation has been made from the address.
+func (h *Handler) parseStakerQuery(request *http.Request) (string, *types.Error) {
+	hasPk := request.URL.Query().Get("staker_btc_pk") != ""
+	hasAddress := request.URL.Query().Get("address") != ""
+	if hasPk == hasAddress {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "exactly one of staker_btc_pk or address is required",
+		)
+	}
+	if hasPk {
+		return parsePublicKeyQuery(request, "staker_btc_pk")
+	}
+	address, err := parseBtcAddressQuery(request, "address", h.config.Server.BTCNetParam)
+	if err != nil {
+		return "", err
+	}
+	if !utils.IsTaprootAddress(address, h.config.Server.BTCNetParam) {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "only Taproot addresses are supported",
+		)
+	}
+	return h.services.StakerPkByTaprootAddress(request.Context(), address)
+}
+
+// parseStakerDelegationsSort parses the `sort_by` and `sort_order` params.
+// It returns nil if no sort is requested, in which case the default order applies.
+func parseStakerDelegationsSort(request *http.Request) (*services.StakerDelegationsSort, *types.Error) {
+	sortBy := services.StakerDelegationsSortBy(request.URL.Query().Get("sort_by"))
+	sortOrder := request.URL.Query().Get("sort_order")
+	switch sortBy {
+	case "":
+		if sortOrder != "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "sort_order requires sort_by",
+			)
+		}
+		return nil, nil
+	case services.StakerDelegationsSortByStakingAmount, services.StakerDelegationsSortByStartHeight:
+	default:
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid sort_by value",
+		)
+	}
+	switch sortOrder {
+	case "", "desc":
+		return &services.StakerDelegationsSort{By: sortBy}, nil
+	case "asc":
+		return &services.StakerDelegationsSort{By: sortBy, Ascending: true}, nil
+	default:
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid sort_order value",
+		)
+	}
+}
+
+// parseStakingTimelockRange parses either the exact `staking_timelock` or the
+// `min_staking_timelock` and `max_staking_timelock` bounds, both inclusive.
+// It returns nil if none of them is provided.
+func parseStakingTimelockRange(request *http.Request) (*services.StakingTimelockRange, *types.Error) {
+	exact, err := parseOptionalUint64Query(request, "staking_timelock")
+	if err != nil {
+		return nil, err
+	}
+	minTimelock, err := parseOptionalUint64Query(request, "min_staking_timelock")
+	if err != nil {
+		return nil, err
+	}
+	maxTimelock, err := parseOptionalUint64Query(request, "max_staking_timelock")
+	if err != nil {
+		return nil, err
+	}
+	if exact != nil {
+		if minTimelock != nil || maxTimelock != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				"staking_timelock cannot be combined with min_staking_timelock or max_staking_timelock",
+			)
+		}
+		return &services.StakingTimelockRange{Min: exact, Max: exact}, nil
+	}
+	if minTimelock == nil && maxTimelock == nil {
+		return nil, nil
+	}
+	if minTimelock != nil && maxTimelock != nil && *minTimelock > *maxTimelock {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			"min_staking_timelock cannot be greater than max_staking_timelock",
+		)
+	}
+	return &services.StakingTimelockRange{Min: minTimelock, Max: maxTimelock}, nil
+}
+
 // GetStakerDelegations @Summary Get staker delegations
 // @Description Retrieves delegations for a given staker
+// @Description With `sort_by`, the pagination key is tied to the requested sort, resuming it with a different `sort_by` or `sort_order` returns 400.
+// @Description Paging through a fixed sort never skips nor repeats delegations, as ties are broken by the staking tx hash.
 // @Produce json
-// @Param staker_btc_pk query string true "Staker BTC Public Key"
-// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Param staker_btc_pk query string false "Staker BTC Public Key, required unless address is provided"
+// @Param address query string false "Staker BTC address in Taproot format, as an alternative to staker_btc_pk"
+// @Param state query string false "Comma separated list of the states of the delegations to return" Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param unbonding_type query string false "Only return the ended delegations with the given unbonding type" Enums(early_unbonding, natural_expiry)
+// @Param finality_provider_pk_hex query string false "Only return the delegations to the given finality provider"
+// @Param include_overflow query boolean false "Whether overflow delegations are included, defaults to the server configuration"
+// @Param below_min query boolean false "Only return the delegations at or below (if true) or above (if false) the min staking amount of their params version"
+// @Param staking_timelock query integer false "Only return the delegations with exactly the given staking timelock, cannot be combined with the range bounds"
+// @Param min_staking_timelock query integer false "Only return the delegations with a staking timelock at or above the given value"
+// @Param max_staking_timelock query integer false "Only return the delegations with a staking timelock at or below the given value"
+// @Param min_confirmations query integer false "Only return the delegations whose staking tx has at least the given confirmations, the server minimum applies if stricter"
+// @Param sort_by query string false "Sort the delegations by the given field, the staking tx hash breaking ties. Defaults to the staking start height in descending order" Enums(staking_amount, start_height)
+// @Param sort_order query string false "Order of the sort_by field, defaults to desc" Enums(asc, desc)
+// @Param minimal query boolean false "Only return the id, state and last update time of each delegation"
+// @Param fields query string false "Comma separated list of the delegation fields to return, e.g. staking_tx_hash_hex,state,staking_value. Cannot be combined with minimal"
+// @Param tz query string false "IANA timezone name the timestamps are formatted in, defaults to UTC"
+// @Param limit query integer false "Maximum number of delegations in the page, defaults to the configured page size and cannot exceed the configured max page size"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations, only valid with the sort it was returned for"
+// @Param format query string false "Response format, CSV can also be requested with a text/csv Accept header. The CSV export streams all the delegations from the pagination key onwards, ignoring limit, minimal and fields" Enums(json, csv)
 // @Success 200 {object} PublicResponse[[]services.DelegationPublic]{array} "List of delegations and pagination token"
+// @Success 200 {object} PublicResponse[[]services.DelegationMinimalPublic]{array} "List of minimal delegations and pagination token, if minimal is set"
+// @Success 200 {string} string "CSV export of the delegations, if requested"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegations [get]
 func (h *Handler) GetStakerDelegations(request *http.Request) (*Result, *types.Error) {
-	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	stakerBtcPk, err := h.parseStakerQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := h.parsePageSizeQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseStakerDelegationsFilter(request)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseStakerDelegationsSort(request)
+	if err != nil {
+		return nil, err
+	}
+	minimal, err := parseOptionalBoolQuery(request, "minimal")
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFieldsQuery(request, delegationPublicFields)
+	if err != nil {
+		return nil, err
+	}
+	if fields != nil && minimal != nil && *minimal {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "fields cannot be combined with minimal",
+		)
+	}
+	loc, err := parseTimezoneQuery(request, "tz")
 	if err != nil {
 		return nil, err
 	}
-	paginationKey, err := parsePaginationQuery(request)
+	csvFormat, err := parseCsvFormat(request)
 	if err != nil {
 		return nil, err
 	}
+	if csvFormat {
+		return h.stakerDelegationsCsvResult(request.Context(), stakerBtcPk, filter, sort, paginationKey)
+	}
+
+	if stakerBtcPk == "" {
+		// No delegation has been made from the address
+		return NewResultWithPagination([]services.DelegationPublic{}, ""), nil
+	}
+
+	if minimal != nil && *minimal {
+		minimalDelegations, newPaginationKey, err := h.services.MinimalDelegationsByStakerPk(
+			request.Context(), stakerBtcPk, filter, sort, paginationKey, limit,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for i := range minimalDelegations {
+			localized, localizeErr := utils.ConvertIsoTimestampToLocation(minimalDelegations[i].UpdatedAt, loc)
+			if localizeErr != nil {
+				return nil, types.NewInternalServiceError(localizeErr)
+			}
+			minimalDelegations[i].UpdatedAt = localized
+		}
+		return NewResultWithPagination(minimalDelegations, newPaginationKey), nil
+	}
 
-	delegations, newPaginationKey, err := h.services.DelegationsByStakerPk(request.Context(), stakerBtcPk, paginationKey)
+	delegations, newPaginationKey, err := h.services.DelegationsByStakerPk(
+		request.Context(), stakerBtcPk, filter, sort, paginationKey, limit,
+	)
 	if err != nil {
 		return nil, err
 	}
+	for i := range delegations {
+		if err := localizeDelegationTimestamps(&delegations[i], loc); err != nil {
+			return nil, err
+		}
+	}
+	if fields != nil {
+		selectedDelegations, err := selectFields(delegations, fields)
+		if err != nil {
+			return nil, err
+		}
+		return NewResultWithPagination(selectedDelegations, newPaginationKey), nil
+	}
 
 	return NewResultWithPagination(delegations, newPaginationKey), nil
 }
 
+// JSON names of the delegation fields which can be selected with `fields`
+var delegationPublicFields = jsonFieldNames(services.DelegationPublic{})
+
+// stakerDelegationsCsvResult streams the delegations of the staker as CSV, one
+// page at a time so that large stakers are not buffered in memory. The first
+// page is fetched upfront for its errors to be returned with the status code.
+func (h *Handler) stakerDelegationsCsvResult(
+	ctx context.Context, stakerBtcPk string, filter *services.StakerDelegationsFilter,
+	sort *services.StakerDelegationsSort, paginationKey string,
+) (*Result, *types.Error) {
+	_, pageSize := h.config.PageSizeLimits()
+	var delegations []services.DelegationPublic
+	var nextKey string
+	if stakerBtcPk != "" {
+		var err *types.Error
+		delegations, nextKey, err = h.services.DelegationsByStakerPk(
+			ctx, stakerBtcPk, filter, sort, paginationKey, pageSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := NewStreamResult(csvMediaType+"; charset=utf-8", func(w io.Writer) error {
+		return h.services.WriteStakerDelegationsCsv(ctx, w, stakerBtcPk, filter, sort, delegations, nextKey)
+	})
+	result.Headers.Set("Content-Disposition", `attachment; filename="delegations.csv"`)
+	return result, nil
+}
+
+// stakerDelegationsExportPath is the path of the export jobs of the staker delegations
+const stakerDelegationsExportPath = "/v1/staker/delegations/export"
+
+// parseExportJobIdParam parses the `job_id` path parameter, a 16 bytes hex id
+func parseExportJobIdParam(request *http.Request) (string, *types.Error) {
+	jobId := chi.URLParam(request, "job_id")
+	if decoded, err := hex.DecodeString(jobId); err != nil || len(decoded) != 16 {
+		return "", types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid job_id")
+	}
+	return jobId, nil
+}
+
+// CreateStakerDelegationsExport @Summary Create a staker delegations export job
+// @Description Creates a job exporting as CSV all the delegations of the staker matching the filters, with the same
+// @Description columns as the CSV format of the staker delegations. The export is generated in the background, which
+// @Description suits the stakers too large to be exported synchronously. Its status is polled with the returned job id.
+// @Description The job and its file expire an hour after the job creation.
+// @Produce json
+// @Param staker_btc_pk query string false "Staker BTC Public Key, required unless address is provided"
+// @Param address query string false "Staker BTC address in Taproot format, as an alternative to staker_btc_pk"
+// @Param state query string false "Comma separated list of the states of the delegations to export" Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param unbonding_type query string false "Only export the ended delegations with the given unbonding type" Enums(early_unbonding, natural_expiry)
+// @Param finality_provider_pk_hex query string false "Only export the delegations to the given finality provider"
+// @Param include_overflow query boolean false "Whether overflow delegations are included, defaults to the server configuration"
+// @Param sort_by query string false "Sort the delegations by the given field, the staking tx hash breaking ties. Defaults to the staking start height in descending order" Enums(staking_amount, start_height)
+// @Param sort_order query string false "Order of the sort_by field, defaults to desc" Enums(asc, desc)
+// @Success 202 {object} PublicResponse[services.ExportJobPublic] "Pending export job"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "Error: Too many pending exports"
+// @Router /v1/staker/delegations/export [post]
+func (h *Handler) CreateStakerDelegationsExport(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := h.parseStakerQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseStakerDelegationsFilter(request)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseStakerDelegationsSort(request)
+	if err != nil {
+		return nil, err
+	}
+	job, err := h.services.CreateStakerDelegationsExport(request.Context(), stakerBtcPk, filter, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewResult(job)
+	result.Status = http.StatusAccepted
+	return result, nil
+}
+
+// GetStakerDelegationsExport @Summary Get a staker delegations export job
+// @Description Retrieves the status of the export job, along with the link its file is downloaded from once completed.
+// @Produce json
+// @Param job_id path string true "Export job id"
+// @Success 200 {object} PublicResponse[services.ExportJobPublic] "Export job"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found, the job does not exist or has expired"
+// @Router /v1/staker/delegations/export/{job_id} [get]
+func (h *Handler) GetStakerDelegationsExport(request *http.Request) (*Result, *types.Error) {
+	jobId, err := parseExportJobIdParam(request)
+	if err != nil {
+		return nil, err
+	}
+	job, err := h.services.GetExportJob(request.Context(), jobId)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == services.ExportJobCompleted {
+		job.DownloadUrl = stakerDelegationsExportPath + "/" + jobId + "/download"
+	}
+
+	return NewResult(job), nil
+}
+
+// DownloadStakerDelegationsExport @Summary Download a staker delegations export
+// @Description Downloads the CSV file of the completed export job.
+// @Produce text/csv
+// @Param job_id path string true "Export job id"
+// @Success 200 {string} string "CSV export of the delegations"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found, the job does not exist or has expired"
+// @Failure 409 {object} types.Error "Error: Conflict, the job has not completed"
+// @Router /v1/staker/delegations/export/{job_id}/download [get]
+func (h *Handler) DownloadStakerDelegationsExport(request *http.Request) (*Result, *types.Error) {
+	jobId, err := parseExportJobIdParam(request)
+	if err != nil {
+		return nil, err
+	}
+	file, err := h.services.GetExportFile(request.Context(), jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewStreamResult(csvMediaType+"; charset=utf-8", func(w io.Writer) error {
+		_, err := w.Write(file)
+		return err
+	})
+	result.Headers.Set("Content-Disposition", `attachment; filename="delegations.csv"`)
+	return result, nil
+}
+
+// Maximum number of stakers whose delegations can be fetched in a single batch request
+const maxStakerDelegationsBatchSize = 50
+
+type StakerDelegationsBatchRequestPayload struct {
+	StakerBtcPks []string `json:"staker_btc_pks"`
+}
+
+func parseStakerDelegationsBatchRequestPayload(request *http.Request) (*StakerDelegationsBatchRequestPayload, *types.Error) {
+	payload := &StakerDelegationsBatchRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if len(payload.StakerBtcPks) == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "staker_btc_pks is required",
+		)
+	}
+	if len(payload.StakerBtcPks) > maxStakerDelegationsBatchSize {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("at most %d stakers can be fetched at once", maxStakerDelegationsBatchSize),
+		)
+	}
+	for _, pkHex := range payload.StakerBtcPks {
+		if !isValidPublicKey(pkHex) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid staker_btc_pk: "+pkHex,
+			)
+		}
+	}
+	return payload, nil
+}
+
+// GetStakerDelegationsBatch @Summary Get the delegations of multiple stakers
+// @Description Retrieves the first page of delegations of each of the given stakers, keyed by staker pk hex.
+// @Description Stakers without any delegation are included with an empty list. The following pages can be
+// @Description fetched from the staker delegations endpoint with the returned `next_key` as pagination key.
+// @Accept json
+// @Produce json
+// @Param payload body StakerDelegationsBatchRequestPayload true "List of at most 50 staker BTC public keys"
+// @Success 200 {object} PublicResponse[map[string]services.StakerDelegationsPublic] "Delegations keyed by staker pk hex"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/staker/delegations/batch [post]
+func (h *Handler) GetStakerDelegationsBatch(request *http.Request) (*Result, *types.Error) {
+	payload, err := parseStakerDelegationsBatchRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	stakersDelegations, err := h.services.DelegationsByStakerPks(request.Context(), payload.StakerBtcPks)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(stakersDelegations), nil
+}
+
+// GetStakerTotalStake @Summary Get the total stake of a staker
+// @Description Retrieves the sum of the staking value in satoshis of the delegations of the staker,
+// @Description excluding the unbonded and withdrawn ones. The overflow delegations are included unless hidden by default in the service configuration.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} PublicResponse[services.StakerTotalStakePublic] "Total stake of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/total-stake [get]
+func (h *Handler) GetStakerTotalStake(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	totalStake, err := h.services.GetStakerTotalStake(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(totalStake), nil
+}
+
+// Number of stakers whose linkage is checked at once
+const stakerLinkageSize = 2
+
+// GetStakerLinkage @Summary Check whether two stakers belong to the same entity
+// @Description Tells whether the two stakers are linked to the same entity. No data linking several
+// @Description keys to one entity is indexed for now, hence `supported` is false and `linked` is null,
+// @Description which must not be read as the stakers not being linked.
+// @Produce json
+// @Param staker_btc_pk query []string true "Staker BTC Public Keys, exactly two of them" collectionFormat(multi)
+// @Success 200 {object} PublicResponse[services.StakerLinkagePublic] "Linkage of the stakers"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/linked [get]
+func (h *Handler) GetStakerLinkage(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPks := request.URL.Query()["staker_btc_pk"]
+	if len(stakerBtcPks) != stakerLinkageSize {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "exactly two staker_btc_pk are required",
+		)
+	}
+	for _, stakerBtcPk := range stakerBtcPks {
+		if !isValidPublicKey(stakerBtcPk) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid staker_btc_pk",
+			)
+		}
+	}
+	return NewResult(h.services.GetStakerLinkage(stakerBtcPks)), nil
+}
+
+// GetStakerDelegationSummary @Summary Get the delegation counts of a staker per state
+// @Description Retrieves the number of delegations of the staker in each state, keyed by state.
+// @Description Every state is included, with a zero count if the staker has no delegation in it.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} PublicResponse[map[string]int64] "Delegation counts keyed by state"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/delegation-summary [get]
+func (h *Handler) GetStakerDelegationSummary(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	summary, err := h.services.GetStakerDelegationSummary(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(summary), nil
+}
+
+// GetStakerDashboard @Summary Get the dashboard of a staker
+// @Description Retrieves the headline figures of the staker at once: the total stake, the stake eligible for unbonding,
+// @Description the number of finality providers delegated to, the rank by active tvl and the daily activity of the last 30 days.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} PublicResponse[services.StakerDashboardPublic] "Dashboard of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/dashboard [get]
+func (h *Handler) GetStakerDashboard(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	dashboard, err := h.services.GetStakerDashboard(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(dashboard), nil
+}
+
+// A year long range is allowed so that the activity heatmap can be rendered at once
+const maxStakerActivityRangeInDays = 366
+
+// GetStakerDailyActivity @Summary Get the daily activity of a staker
+// @Description Retrieves per UTC day the number and the total staking value of the delegations created by the staker,
+// @Description in ascending order of day. The range is inclusive and cannot exceed 366 days. It defaults to the last 30 days.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param from query string false "First day of the range in YYYY-MM-DD format"
+// @Param to query string false "Last day of the range in YYYY-MM-DD format, defaults to today"
+// @Success 200 {object} PublicResponse[[]services.StakerDailyActivityPublic]{array} "Daily activity of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/activity/daily [get]
+func (h *Handler) GetStakerDailyActivity(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	fromDate, toDate, err := parseDateRangeQuery(request, maxStakerActivityRangeInDays)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := h.services.GetStakerDailyActivity(request.Context(), stakerBtcPk, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(activity), nil
+}
+
 // CheckStakerDelegationExist @Summary Check if a staker has an active delegation
 // @Description Check if a staker has an active delegation by the staker BTC address (Taproot only)
 // @Description Optionally, you can provide a timeframe to check if the delegation is active within the provided timeframe
-// @Description The available timeframe is "today" which checks after UTC 12AM of the current day
+// @Description The available timeframes are "today" which checks after 12AM of the current day in the `tz` timezone (UTC by default),
+// @Description "week" and "month" which check within the last 7 and 30 days respectively
 // @Produce json
 // @Param address query string true "Staker BTC address in Taproot format"
-// @Param timeframe query string false "Check if the delegation is active within the provided timeframe" Enums(today)
+// @Param timeframe query string false "Check if the delegation is active within the provided timeframe" Enums(today, week, month)
+// @Param tz query string false "IANA timezone name the day boundary of the today timeframe is in, defaults to UTC"
+// @Param after query integer false "Check if the delegation is active after the given Unix timestamp in seconds, instead of a timeframe"
 // @Success 200 {object} Result "Result"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegation/check [get]
@@ -49,7 +604,7 @@ func (h *Handler) CheckStakerDelegationExist(request *http.Request) (*Result, *t
 		return nil, err
 	}
 
-	afterTimestamp, err := parseTimeframeToAfterTimestamp(request.URL.Query().Get("timeframe"))
+	afterTimestamp, err := parseDelegationCheckAfterTimestamp(request)
 	if err != nil {
 		return nil, err
 	}
@@ -64,15 +619,80 @@ func (h *Handler) CheckStakerDelegationExist(request *http.Request) (*Result, *t
 	return NewResult(exist), nil
 }
 
-func parseTimeframeToAfterTimestamp(timeframe string) (int64, *types.Error) {
+// parseDelegationCheckAfterTimestamp returns the timestamp the delegation check
+// starts at, from either the `after` timestamp or the `timeframe`.
+func parseDelegationCheckAfterTimestamp(request *http.Request) (int64, *types.Error) {
+	after, err := parseOptionalUint64Query(request, "after")
+	if err != nil {
+		return 0, err
+	}
+	timeframe := request.URL.Query().Get("timeframe")
+	if after != nil {
+		if timeframe != "" {
+			return 0, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "after and timeframe cannot be provided together",
+			)
+		}
+		if *after > math.MaxInt64 {
+			return 0, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid after")
+		}
+		return int64(*after), nil
+	}
+
+	loc, err := parseTimezoneQuery(request, "tz")
+	if err != nil {
+		return 0, err
+	}
+	return parseTimeframeToAfterTimestamp(timeframe, time.Now(), loc)
+}
+
+// Number of days covered by the rolling timeframes
+const (
+	weekTimeframeDays  = 7
+	monthTimeframeDays = 30
+)
+
+// parseTimeframeToAfterTimestamp returns the timestamp the timeframe starts at.
+// The day boundary of "today" is in the given location.
+func parseTimeframeToAfterTimestamp(timeframe string, now time.Time, loc *time.Location) (int64, *types.Error) {
 	switch timeframe {
 	case "": // We ignore and return 0 if no timeframe is provided
 		return 0, nil
 	case "today":
-		return utils.GetTodayStartTimestampInSeconds(), nil
+		return utils.GetDayStartTimestampInLocation(now, loc), nil
+	case "week":
+		return utils.GetDaysAgoTimestampInSeconds(now, weekTimeframeDays), nil
+	case "month":
+		return utils.GetDaysAgoTimestampInSeconds(now, monthTimeframeDays), nil
 	default:
 		return 0, types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid timeframe value",
 		)
 	}
 }
+
+// GetStakerFinalityProviderStats @Summary Get the stats of a staker with a finality provider
+// @Description Retrieves the total and active stake and delegation count of a staker with a given finality provider.
+// @Description Overflow delegations are not accounted for.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param finality_provider_pk_hex query string true "Finality Provider BTC Public Key"
+// @Success 200 {object} PublicResponse[services.StakerFinalityProviderStatsPublic] "Stats of the staker with the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/provider-stats [get]
+func (h *Handler) GetStakerFinalityProviderStats(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	fpPkHex, err := parsePublicKeyQuery(request, "finality_provider_pk_hex")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.services.GetStakerFinalityProviderStats(request.Context(), stakerBtcPk, fpPkHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(stats), nil
+}
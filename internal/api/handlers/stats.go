@@ -3,22 +3,44 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
+// Maximum number of days that can be requested from the daily stats and the stats history
+const (
+	maxDailyStatsRangeInDays    = 90
+	maxDailyHistoryRangeInDays  = 365
+	maxHourlyHistoryRangeInDays = 7
+	maxWeeklyHistoryRangeInDays = 5 * 365
+)
+
 // GetOverallStats gets overall stats for babylon staking
 // @Summary Get Overall Stats
 // @Description Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.
+// @Description The response carries an ETag that only changes with the stats data, polling with `If-None-Match` returns 304 if unchanged.
+// @Description With `respect_allowlist=true`, all the stats only account for the delegations to the allowlisted finality providers.
+// @Description The unconfirmed tvl cannot be scoped to them, so it's reported as 0 and the stats as partial.
 // @Produce json
+// @Param respect_allowlist query bool false "Scope the stats to the allowlisted finality providers, defaults to network-wide"
+// @Param If-None-Match header string false "ETag of the previously fetched stats"
 // @Success 200 {object} PublicResponse[services.OverallStatsPublic] "Overall stats for babylon staking"
+// @Success 304 "Stats unchanged since the given ETag"
+// @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/stats [get]
 func (h *Handler) GetOverallStats(request *http.Request) (*Result, *types.Error) {
-	stats, err := h.services.GetOverallStats(request.Context())
+	respectAllowlist, err := parseOptionalBoolQuery(request, "respect_allowlist")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.services.GetOverallStats(
+		request.Context(), respectAllowlist != nil && *respectAllowlist,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewResult(stats), nil
+	return NewResultWithETag(request, stats, stats.SnapshotVersion()), nil
 }
 
 // GetTopStakerStats gets top stakers by active tvl
@@ -26,18 +48,175 @@ func (h *Handler) GetOverallStats(request *http.Request) (*Result, *types.Error)
 // @Description Fetches details of top stakers by their active total value locked (ActiveTvl) in descending order.
 // @Produce json
 // @Param  pagination_key query string false "Pagination key to fetch the next page of top stakers"
+// @Param  limit query int false "Maximum number of top stakers in the page, defaults to the configured page size and cannot exceed the configured max page size"
 // @Success 200 {object} PublicResponse[[]services.StakerStatsPublic]{array} "List of top stakers by active tvl"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/stats/staker [get]
 func (h *Handler) GetTopStakerStats(request *http.Request) (*Result, *types.Error) {
-	paginationKey, err := parsePaginationQuery(request)
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := h.parsePageSizeQuery(request)
 	if err != nil {
 		return nil, err
 	}
-	topStakerStats, paginationToken, err := h.services.GetTopStakersByActiveTvl(request.Context(), paginationKey)
+	topStakerStats, paginationToken, err := h.services.GetTopStakersByActiveTvl(
+		request.Context(), paginationKey, limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	return NewResultWithPagination(topStakerStats, paginationToken), nil
 }
+
+// GetStakerRank gets the rank of a staker in the top stakers by active tvl
+// @Summary Get Staker Rank
+// @Description Fetches the rank of the staker, starting from 1, in the top stakers by active tvl along with its stats.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} PublicResponse[services.StakerRankPublic] "Rank of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/stats/staker/rank [get]
+func (h *Handler) GetStakerRank(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	rank, err := h.services.GetStakerRank(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(rank), nil
+}
+
+// GetInactiveProviderStake gets the stake delegated to finality providers outside the active set
+// @Summary Get Inactive Provider Stake
+// @Description Fetches the total active stake delegated to finality providers that are not part of the active set.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.InactiveProviderStakePublic] "Stake delegated to inactive finality providers"
+// @Router /v1/stats/inactive-provider-stake [get]
+func (h *Handler) GetInactiveProviderStake(request *http.Request) (*Result, *types.Error) {
+	stake, err := h.services.GetInactiveProviderStake(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(stake), nil
+}
+
+// GetDailyUnbondingStats gets the daily unbonding stats
+// @Summary Get Daily Unbonding Stats
+// @Description Fetches per UTC day the number and the total staking value of the delegations which started unbonding, in ascending order of day.
+// @Description The range is inclusive and cannot exceed 90 days. It defaults to the last 30 days.
+// @Produce json
+// @Param from query string false "First day of the range in YYYY-MM-DD format"
+// @Param to query string false "Last day of the range in YYYY-MM-DD format, defaults to today"
+// @Success 200 {object} PublicResponse[[]services.DailyUnbondingStatsPublic]{array} "Daily unbonding stats"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/stats/unbonding/daily [get]
+func (h *Handler) GetDailyUnbondingStats(request *http.Request) (*Result, *types.Error) {
+	fromDate, toDate, err := parseDateRangeQuery(request, maxDailyStatsRangeInDays)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.services.GetDailyUnbondingStats(request.Context(), fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(stats), nil
+}
+
+// GetStatsHistory gets the time series of the overall staking stats
+// @Summary Get Stats History
+// @Description Fetches the staking activity bucketed per UTC day, hour or week starting on Monday, in ascending order of time.
+// @Description Each point carries the tvl and the number of delegations which started within the bucket, along with the cumulative total tvl and total delegations.
+// @Description Buckets without any new delegation are omitted, a range without any activity returns an empty series.
+// @Description The range is inclusive and cannot exceed 365 days for the day granularity, 7 days for the hour granularity
+// @Description and 1825 days for the week granularity. For the latter, the range starts from the week of `from`.
+// @Produce json
+// @Param from query string false "First day of the range in YYYY-MM-DD format"
+// @Param to query string false "Last day of the range in YYYY-MM-DD format, defaults to today"
+// @Param granularity query string false "Size of the buckets" Enums(day, hour, week) default(day)
+// @Success 200 {object} PublicResponse[[]services.StakingHistoryPointPublic]{array} "Stats history"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/stats/history [get]
+func (h *Handler) GetStatsHistory(request *http.Request) (*Result, *types.Error) {
+	granularity := services.StatsGranularity(request.URL.Query().Get("granularity"))
+	var maxRangeInDays int
+	switch granularity {
+	case "", services.StatsGranularityDay:
+		granularity = services.StatsGranularityDay
+		maxRangeInDays = maxDailyHistoryRangeInDays
+	case services.StatsGranularityHour:
+		maxRangeInDays = maxHourlyHistoryRangeInDays
+	case services.StatsGranularityWeek:
+		maxRangeInDays = maxWeeklyHistoryRangeInDays
+	default:
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid granularity, must be day, hour or week",
+		)
+	}
+	fromDate, toDate, err := parseDateRangeQuery(request, maxRangeInDays)
+	if err != nil {
+		return nil, err
+	}
+	history, err := h.services.GetStakingHistory(
+		request.Context(), fromDate, toDate.AddDate(0, 0, 1), granularity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(history), nil
+}
+
+// GetActiveSetStakeBreakdown gets the active stake split by active set membership
+// @Summary Get Active Set Stake Breakdown
+// @Description Fetches the total active stake and delegation count split by whether the finality provider is in the active set.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.ActiveSetStakeBreakdownPublic] "Active stake split by active set membership"
+// @Router /v1/stats/active-set [get]
+func (h *Handler) GetActiveSetStakeBreakdown(request *http.Request) (*Result, *types.Error) {
+	breakdown, err := h.services.GetActiveSetStakeBreakdown(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(breakdown), nil
+}
+
+// GetFinalityProviderCount gets the number of finality providers network-wide
+// @Summary Get Finality Provider Count
+// @Description Fetches the number of distinct finality providers with at least one active delegation,
+// @Description along with the number of registered finality providers. The result is cached for a short while.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.FinalityProviderCountPublic] "Finality provider count"
+// @Router /v1/stats/finality-providers/count [get]
+func (h *Handler) GetFinalityProviderCount(request *http.Request) (*Result, *types.Error) {
+	count, err := h.services.GetFinalityProviderCount(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(count), nil
+}
+
+// GetTvl gets the network TVL
+// @Summary Get TVL
+// @Description Fetches the active TVL and the total amount of BTC locked, along with the definition of each figure.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.TvlPublic] "Active TVL and total locked BTC"
+// @Router /v1/stats/tvl [get]
+func (h *Handler) GetTvl(request *http.Request) (*Result, *types.Error) {
+	tvl, err := h.services.GetTvl(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(tvl), nil
+}
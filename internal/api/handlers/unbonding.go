@@ -2,12 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 type UnbondDelegationRequestPayload struct {
 	StakingTxHashHex         string `json:"staking_tx_hash_hex"`
 	UnbondingTxHashHex       string `json:"unbonding_tx_hash_hex"`
@@ -49,22 +57,62 @@ func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegati
 // UnbondDelegation godoc
 // @Summary Unbond delegation
 // @Description Unbonds a delegation by processing the provided transaction details. This is an async operation.
+// @Description Retries carrying the same `Idempotency-Key` header and payload get the outcome of the original request.
+// @Description With `dry_run=true` the request goes through the same validations but nothing is saved, nor is the
+// @Description idempotency key recorded, and the outcome the request would have is returned.
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key identifying the request across retries, up to 255 characters"
+// @Param X-Api-Key header string false "API key, required if API keys are configured on the server"
+// @Param dry_run query boolean false "Only validate the request without submitting it"
 // @Param payload body UnbondDelegationRequestPayload true "Unbonding Request Payload"
+// @Success 200 {object} PublicResponse[services.UnbondingDryRunPublic] "The request is valid, only returned for dry runs"
 // @Success 202 "Request accepted and will be processed asynchronously"
-// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 400 {object} types.Error "Invalid request payload, or the unbonding tx or signature does not match the delegation (INVALID_SIGNATURE)"
+// @Failure 401 {object} types.Error "Missing or invalid API key"
+// @Failure 409 {object} types.Error "Idempotency key already used for a different request"
 // @Router /v1/unbonding [post]
 func (h *Handler) UnbondDelegation(request *http.Request) (*Result, *types.Error) {
 	payload, err := parseUnbondDelegationRequestPayload(request)
 	if err != nil {
 		return nil, err
 	}
-	unbondErr := h.services.UnbondDelegation(
-		request.Context(), payload.StakingTxHashHex,
-		payload.UnbondingTxHashHex, payload.UnbondingTxHex,
-		payload.StakerSignedSignatureHex,
-	)
+	dryRun, err := parseOptionalBoolQuery(request, "dry_run")
+	if err != nil {
+		return nil, err
+	}
+	if dryRun != nil && *dryRun {
+		outcome, err := h.services.ValidateUnbondDelegation(
+			request.Context(), payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return NewResult(outcome), nil
+	}
+	idempotencyKey := strings.TrimSpace(request.Header.Get(idempotencyKeyHeader))
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("%s cannot exceed %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength),
+		)
+	}
+	var unbondErr *types.Error
+	if idempotencyKey == "" {
+		unbondErr = h.services.UnbondDelegation(
+			request.Context(), payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+	} else {
+		unbondErr = h.services.UnbondDelegationWithIdempotencyKey(
+			request.Context(), idempotencyKey, payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+	}
 	if unbondErr != nil {
 		return nil, unbondErr
 	}
@@ -75,20 +123,114 @@ func (h *Handler) UnbondDelegation(request *http.Request) (*Result, *types.Error
 // GetUnbondingEligibility godoc
 // @Summary Check unbonding eligibility
 // @Description Checks if a delegation identified by its staking transaction hash is eligible for unbonding.
+// @Description If not, the response carries a machine readable reason along with a human readable message.
 // @Produce json
 // @Param staking_tx_hash_hex query string true "Staking Transaction Hash Hex"
-// @Success 200 "The delegation is eligible for unbonding"
+// @Success 200 {object} PublicResponse[services.UnbondingEligibilityPublic] "The delegation is eligible for unbonding"
 // @Failure 400 {object} types.Error "Missing or invalid 'staking_tx_hash_hex' query parameter"
+// @Failure 403 {object} PublicResponse[services.UnbondingEligibilityPublic] "The delegation is not eligible for unbonding"
 // @Router /v1/unbonding/eligibility [get]
 func (h *Handler) GetUnbondingEligibility(request *http.Request) (*Result, *types.Error) {
 	stakingTxHashHex, err := parseTxHashQuery(request, "staking_tx_hash_hex")
 	if err != nil {
 		return nil, err
 	}
-	err = h.services.IsEligibleForUnbondingRequest(request.Context(), stakingTxHashHex)
+	eligibility, err := h.services.GetUnbondingEligibility(request.Context(), stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewResult(eligibility)
+	// Clients only checking the status keep telling the ineligible delegations apart
+	if !eligibility.Eligible {
+		result.Status = http.StatusForbidden
+	}
+	return result, nil
+}
+
+func parseUnbondingSortByQuery(r *http.Request) (services.UnbondingSortBy, *types.Error) {
+	sortBy := services.UnbondingSortBy(r.URL.Query().Get("sort_by"))
+	switch sortBy {
+	case "":
+		return services.UnbondingSortByStartHeight, nil
+	case services.UnbondingSortByStartHeight, services.UnbondingSortByRemainingBlocks:
+		return sortBy, nil
+	default:
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid sort_by value",
+		)
+	}
+}
+
+// GetUnbondingDelegations godoc
+// @Summary Get delegations in the unbonding window
+// @Description Retrieves delegations that have started unbonding but whose unbonding timelock has not yet elapsed,
+// @Description ordered by the unbonding start height, along with the remaining blocks and estimated completion time.
+// @Produce json
+// @Param include_overflow query boolean false "Whether overflow delegations are included, defaults to the server configuration"
+// @Param min_confirmations query integer false "Only return the delegations whose staking tx has at least the given confirmations, the server minimum applies if stricter"
+// @Param sort_by query string false "Sort order of the delegations, both in ascending order" Enums(start_height, remaining_blocks)
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} PublicResponse[[]services.UnbondingDelegationPublic]{array} "List of unbonding delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/unbonding [get]
+func (h *Handler) GetUnbondingDelegations(request *http.Request) (*Result, *types.Error) {
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	includeOverflow, err := parseOptionalBoolQuery(request, "include_overflow")
+	if err != nil {
+		return nil, err
+	}
+	minConfirmations, err := parseMinConfirmationsQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	sortBy, err := parseUnbondingSortByQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	delegations, newPaginationKey, err := h.services.UnbondingDelegations(
+		request.Context(), includeOverflow, minConfirmations, sortBy, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// GetStakerUnbondings godoc
+// @Summary Get the unbonding requests of a staker
+// @Description Retrieves the unbonding requests submitted for the delegations of the staker, the most recent first,
+// @Description along with the current state of the delegation. Filtering by the `unbonding_requested` state returns the pending ones.
+// @Produce json
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param state query string false "Comma separated list of delegation states to filter by"
+// @Param pagination_key query string false "Pagination key to fetch the next page of unbonding requests"
+// @Success 200 {object} PublicResponse[[]services.StakerUnbondingRequestPublic]{array} "List of unbonding requests and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/unbondings [get]
+func (h *Handler) GetStakerUnbondings(request *http.Request) (*Result, *types.Error) {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return nil, err
+	}
+	states, err := parseDelegationStatesQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := h.parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	requests, newPaginationKey, err := h.services.GetStakerUnbondingRequests(
+		request.Context(), stakerBtcPk, states, paginationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Result{Status: http.StatusOK}, nil
+	return NewResultWithPagination(requests, newPaginationKey), nil
 }
@@ -1,88 +1,102 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
 
+	"github.com/go-chi/chi"
 	logger "github.com/rs/zerolog"
 
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
 	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
-type ErrorResponse struct {
-	ErrorCode string `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
-func newInternalServiceError() *ErrorResponse {
-	return &ErrorResponse{
-		ErrorCode: types.InternalServiceError.String(),
-		Message:   "Internal service error",
-	}
-}
-
-func (e *ErrorResponse) Error() string {
-	return e.Message
-}
-
 func registerHandler(handlerFunc func(*http.Request) (*handlers.Result, *types.Error)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set up metrics recording for the endpoint
-		timer := metrics.StartHttpRequestDurationTimer(r.URL.Path)
+		endpoint := endpointLabel(r)
+		timer := metrics.StartHttpRequestDurationTimer(endpoint)
 
 		// Handle the actual business logic
 		result, err := handlerFunc(r)
 
+		if err != nil && errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+			// The downstream calls failed as the request timeout was exceeded
+			err = types.NewErrorWithMsg(http.StatusGatewayTimeout, types.RequestTimeout, "request timed out")
+		}
 		if err != nil {
 			if http.StatusText(err.StatusCode) == "" {
 				logger.Ctx(r.Context()).Error().Err(err).Int("status_code", err.StatusCode).Msg("invalid status code")
 				err.StatusCode = http.StatusInternalServerError
 			}
 
-			errorResponse := &ErrorResponse{
+			errorResponse := &respond.ErrorResponse{
 				ErrorCode: string(err.ErrorCode),
 				Message:   err.Err.Error(),
 			}
+			if err.ErrorCode == types.InternalServiceError {
+				metrics.RecordInternalServiceError(endpoint)
+			}
 			// Log the error
 			if err.StatusCode >= http.StatusInternalServerError {
 				logger.Ctx(r.Context()).Error().Err(errorResponse).Msg("request failed with 5xx error")
-				errorResponse.Message = "Internal service error" // Hide the internal message error from client
+				if err.ErrorCode != types.RequestTimeout {
+					errorResponse.Message = "Internal service error" // Hide the internal message error from client
+				}
 			}
 			timer(err.StatusCode)
 			// terminate the request here
-			writeResponse(w, r, err.StatusCode, errorResponse)
+			respond.WriteJSON(w, r, err.StatusCode, errorResponse)
 			return
 		}
 
 		if result == nil || http.StatusText(result.Status) == "" {
 			logger.Ctx(r.Context()).Error().Msg("invalid success response, error returned")
+			metrics.RecordInternalServiceError(endpoint)
 			timer(http.StatusInternalServerError)
 			// terminate the request here
-			writeResponse(w, r, http.StatusInternalServerError, newInternalServiceError())
+			respond.WriteJSON(w, r, http.StatusInternalServerError, respond.NewInternalServiceError())
 			return
 		}
 
 		defer timer(result.Status)
-		writeResponse(w, r, result.Status, result.Data)
+		for key, values := range result.Headers {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		if result.Status == http.StatusNotModified {
+			// A 304 response must not contain a body
+			w.WriteHeader(result.Status)
+			return
+		}
+		if result.Stream != nil {
+			w.WriteHeader(result.Status)
+			if err := result.Stream(w); err != nil {
+				logger.Ctx(r.Context()).Err(err).Msg("failed to stream response")
+				metrics.RecordHttpResponseWriteFailure(result.Status)
+				// The status is already sent, the connection is closed without
+				// terminating the body instead, e.g once the request timed out,
+				// so that the client can't mistake the partial body for a
+				// complete one. Note that chi's Recoverer swallows this panic,
+				// hence must not be set up in front of the streamed responses.
+				panic(http.ErrAbortHandler)
+			}
+			return
+		}
+		respond.WriteJSON(w, r, result.Status, result.Data)
 	}
 }
 
-// Write and return response
-func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, res interface{}) {
-	respBytes, err := json.Marshal(res)
-
-	if err != nil {
-		logger.Ctx(r.Context()).Err(err).Msg("failed to marshal error response")
-		http.Error(w, "Failed to process the request. Please try again later.", http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if _, err := w.Write(respBytes); err != nil {
-		logger.Ctx(r.Context()).Err(err).Msg("failed to write response")
-		metrics.RecordHttpResponseWriteFailure(statusCode)
+// endpointLabel returns the route pattern of the request as the endpoint label
+// of the metrics, so that the path parameters, e.g. the export job ids, don't
+// make its cardinality unbounded.
+func endpointLabel(r *http.Request) string {
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+		return routeCtx.RoutePattern()
 	}
+	return r.URL.Path
 }
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const ApiKeyHeader = "X-Api-Key"
+
+// ApiKeyAuthMiddleware rejects with a 401 status code the requests without a
+// valid API key in the X-Api-Key header. Only the SHA-256 hashes of the keys
+// are known, the hash of the given key is compared in constant time against
+// all of them so that the response time leaks neither the keys nor which one
+// matched. The health checks don't require an API key.
+func ApiKeyAuthMiddleware(apiKeyHashes [][]byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHealthCheckPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			apiKey := r.Header.Get(ApiKeyHeader)
+			if apiKey == "" {
+				writeUnauthorized(w, r, "missing API key")
+				return
+			}
+			apiKeyHash := sha256.Sum256([]byte(apiKey))
+			matched := 0
+			for _, expectedHash := range apiKeyHashes {
+				matched |= subtle.ConstantTimeCompare(apiKeyHash[:], expectedHash)
+			}
+			if matched != 1 {
+				writeUnauthorized(w, r, "invalid API key")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	respond.WriteError(w, r, types.NewErrorWithMsg(http.StatusUnauthorized, types.Unauthorized, message))
+}
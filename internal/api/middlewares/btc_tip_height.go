@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+const BtcTipHeightHeader = "X-Btc-Tip-Height"
+
+// BtcTipHeightMiddleware sets the latest indexed BTC height in the response
+// header of the read requests. The header is omitted if the height is not known.
+func BtcTipHeightMiddleware(
+	getBtcTipHeight func(ctx context.Context) (uint64, bool),
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				if height, ok := getBtcTipHeight(r.Context()); ok {
+					w.Header().Set(BtcTipHeightHeader, strconv.FormatUint(height, 10))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
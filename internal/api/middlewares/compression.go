@@ -0,0 +1,144 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionMiddleware gzip compresses the responses of at least minSize bytes
+// for the clients accepting it. The responses which already carry a content
+// encoding are left untouched, so that they are not compressed twice. The
+// streamed responses are compressed as soon as they are flushed.
+func CompressionMiddleware(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressResponseWriter{ResponseWriter: w, minSize: minSize, status: http.StatusOK}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip returns whether gzip is among the encodings accepted by the client
+func acceptsGzip(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil || (encoding != "gzip" && encoding != "*") {
+			continue
+		}
+		// Explicitly refused with a zero quality value
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of the response until it's known
+// whether it reaches the min size, then either compresses or passes it through.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	minSize     int
+	status      int
+	buf         []byte
+	decided     bool
+	gzipWriter  *gzip.Writer
+	wroteHeader bool
+}
+
+func (cw *compressResponseWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+}
+
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	cw.wroteHeader = true
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.minSize {
+			return len(p), nil
+		}
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if cw.gzipWriter != nil {
+		return cw.gzipWriter.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// decide sends the status and the buffered start of the response, compressing
+// it if requested and the response is not already encoded.
+func (cw *compressResponseWriter) decide(compress bool) error {
+	cw.decided = true
+	header := cw.ResponseWriter.Header()
+	if compress && header.Get("Content-Encoding") == "" &&
+		cw.status != http.StatusNoContent && cw.status != http.StatusNotModified {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		// The compressed bytes differ from the uncompressed ones, hence a strong
+		// ETag no longer applies. Only the representation is equivalent, which
+		// is what a weak ETag stands for, and If-None-Match compares them weakly.
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		cw.gzipWriter = gzip.NewWriter(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if cw.gzipWriter != nil {
+		_, err := cw.gzipWriter.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+func (cw *compressResponseWriter) Flush() {
+	if !cw.decided {
+		if err := cw.decide(len(cw.buf) > 0); err != nil {
+			return
+		}
+	}
+	if cw.gzipWriter != nil {
+		if err := cw.gzipWriter.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// close sends the response if it's still buffered, i.e below the min size
+func (cw *compressResponseWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// Nothing was written by the handler
+			return
+		}
+		_ = cw.decide(false)
+		return
+	}
+	if cw.gzipWriter != nil {
+		_ = cw.gzipWriter.Close()
+	}
+}
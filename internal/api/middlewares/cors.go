@@ -33,7 +33,15 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 			// Default CORS options for other routes
 			return cors.Options{
 				AllowedOrigins: cfg.Server.AllowedOrigins,
-				MaxAge:         maxAge,
+				// The defaults of the cors package along with the idempotency key of the unbonding requests
+				// and the API key
+				AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Idempotency-Key", ApiKeyHeader},
+				// The custom response headers are only readable by the browser clients if exposed
+				ExposedHeaders: []string{
+					RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader, "Retry-After",
+					BtcTipHeightHeader,
+				},
+				MaxAge: maxAge,
 			}
 		}
 
@@ -6,13 +6,20 @@ import (
 	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/observability/tracing"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
 	"github.com/rs/zerolog/log"
 )
 
+// LoggingMiddleware logs one access log line per request, with the request
+// method, path, route, status code and duration, along with the client IP and
+// the trace id of the request. Only the path is logged, never the query
+// string, as it may contain sensitive data. The access logs are written at
+// info level, hence they are not written if the configured log level is above.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Check if the request path starts with /swagger/ or is /healthcheck
-		if strings.HasPrefix(r.URL.Path, "/swagger/") || r.URL.Path == "/healthcheck" {
+		// Check if the request path starts with /swagger/ or is a health check
+		if strings.HasPrefix(r.URL.Path, "/swagger/") || isHealthCheckPath(r.URL.Path) {
 			// If it does, skip logging and serve the swagger request
 			next.ServeHTTP(w, r)
 			return
@@ -30,10 +37,25 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		logger.Debug().Msg("request received")
 		r = r.WithContext(logger.WithContext(r.Context()))
 
-		next.ServeHTTP(w, r)
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
 
 		requestDuration := time.Since(startTime).Milliseconds()
-		logEvent := logger.Info()
+		status := ww.Status()
+		// Nothing was written by the handler, which defaults to 200
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logEvent := logger.Info().
+			Str("method", r.Method).
+			Int("status", status).
+			Str("remoteIp", clientIP(r))
+		// The route is only known once the request has been routed
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+			if route := routeCtx.RoutePattern(); route != "" {
+				logEvent = logEvent.Str("route", route)
+			}
+		}
 
 		tracingInfo := r.Context().Value(tracing.TracingInfoKey)
 		if tracingInfo != nil {
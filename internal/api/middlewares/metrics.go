@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// Route label of the requests not matching any route, so that the cardinality
+// of the route label stays bounded
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the number of requests in flight, and the number
+// of requests per route, method and response status.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done := metrics.StartHttpRequest()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		// Nothing was written by the handler, which defaults to 200
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := unmatchedRoute
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			route = routeCtx.RoutePattern()
+		}
+		done(route, r.Method, status)
+	})
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/cache"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	healthCheckPath      = "/healthcheck"
+	rateLimitKeyPrefix   = "ratelimit:"
+	RateLimitLimitHeader = "X-RateLimit-Limit"
+	// Number of requests left in the current window
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	// Unix time in seconds at which the current window ends
+	RateLimitResetHeader = "X-RateLimit-Reset"
+)
+
+// isHealthCheckPath returns whether the path is one of the health checks
+func isHealthCheckPath(path string) bool {
+	return path == healthCheckPath || strings.HasPrefix(path, healthCheckPath+"/")
+}
+
+// clientIP returns the IP of the client the request comes from. Behind a
+// trusted proxy, the remote address is the one forwarded by the proxy, see
+// TrustedRealIPMiddleware.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimitMiddleware limits the number of requests per client IP with fixed
+// windows of burst requests, each window lasting burst/requestsPerSecond seconds
+// so that requestsPerSecond are allowed on average. The counters are kept in
+// the cache, hence shared by all the instances when the cache is remote.
+// The requests beyond the limit are rejected with a 429 status code, while the
+// requests are let through if the cache fails. The health checks are not rate limited.
+func RateLimitMiddleware(c cache.Cache, requestsPerSecond float64, burst int) func(http.Handler) http.Handler {
+	window := time.Duration(float64(burst) / requestsPerSecond * float64(time.Second))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHealthCheckPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			now := time.Now()
+			windowIndex := now.UnixNano() / window.Nanoseconds()
+			key := fmt.Sprintf("%s%s:%d", rateLimitKeyPrefix, clientIP(r), windowIndex)
+			count, err := c.Incr(r.Context(), key, window)
+			if err != nil {
+				log.Ctx(r.Context()).Warn().Err(err).Msg("error while counting the request for rate limiting")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			windowEnd := time.Unix(0, (windowIndex+1)*window.Nanoseconds())
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(burst))
+			w.Header().Set(RateLimitRemainingHeader, strconv.FormatInt(max(int64(burst)-count, 0), 10))
+			// Rounded up so that the limit is reset by the advertised time
+			w.Header().Set(RateLimitResetHeader, strconv.FormatInt(windowEnd.Add(time.Second-1).Unix(), 10))
+			if count > int64(burst) {
+				writeTooManyRequests(w, r, windowEnd.Sub(now))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeTooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	respond.WriteError(w, r, types.NewErrorWithMsg(
+		http.StatusTooManyRequests, types.TooManyRequests, "too many requests, please retry later",
+	))
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// TrustedRealIPMiddleware sets the remote address of the requests coming from
+// one of the trusted proxies to the client IP forwarded by the proxy, so that
+// the clients behind a load balancer are told apart by the rate limiter and
+// the access logs. The forwarding headers of the requests coming from any
+// other address are ignored, as they could be spoofed.
+func TrustedRealIPMiddleware(trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		realIP := middleware.RealIP(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isTrustedProxy(clientIP(r), trustedProxies) {
+				realIP.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, trustedProxy := range trustedProxies {
+		if trustedProxy.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestTimeoutMiddleware bounds the processing time of the requests. The
+// request context is cancelled once the timeout is exceeded, so that the
+// downstream service and db calls observing it are aborted.
+func RequestTimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package respond
+
+import (
+	"encoding/json"
+	"net/http"
+
+	logger "github.com/rs/zerolog"
+
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// ErrorResponse is the body of all the error responses, whether they are
+// returned by the handlers or by the middlewares.
+type ErrorResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+func NewInternalServiceError() *ErrorResponse {
+	return &ErrorResponse{
+		ErrorCode: types.InternalServiceError.String(),
+		Message:   "Internal service error",
+	}
+}
+
+func (e *ErrorResponse) Error() string {
+	return e.Message
+}
+
+// WriteError writes the error as an ErrorResponse with its status code
+func WriteError(w http.ResponseWriter, r *http.Request, err *types.Error) {
+	WriteJSON(w, r, err.StatusCode, &ErrorResponse{
+		ErrorCode: string(err.ErrorCode),
+		Message:   err.Err.Error(),
+	})
+}
+
+// WriteJSON writes the JSON encoded res with the given status code
+func WriteJSON(w http.ResponseWriter, r *http.Request, statusCode int, res interface{}) {
+	respBytes, err := json.Marshal(res)
+
+	if err != nil {
+		logger.Ctx(r.Context()).Err(err).Msg("failed to marshal error response")
+		http.Error(w, "Failed to process the request. Please try again later.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(respBytes); err != nil {
+		logger.Ctx(r.Context()).Err(err).Msg("failed to write response")
+		metrics.RecordHttpResponseWriteFailure(statusCode)
+	}
+}
@@ -2,23 +2,86 @@ package api
 
 import (
 	_ "github.com/babylonchain/staking-api-service/docs"
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
 	"github.com/go-chi/chi"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 func (a *Server) SetupRoutes(r *chi.Mux) {
 	handlers := a.handlers
+	// Applied ahead of the handlers, the middlewares must be set up before the routes.
+	// The metrics are recorded first so that the rate limited requests are accounted for.
+	// The client IP is resolved ahead of the rate limit, the access logs read it
+	// from the same request once it is served.
+	if a.realIP != nil {
+		r.Use(a.realIP)
+	}
+	r.Use(middlewares.MetricsMiddleware)
+	if a.rateLimiter != nil {
+		r.Use(a.rateLimiter)
+	}
+	if a.apiKeyAuth != nil && a.apiKeyAuthAllRoutes {
+		r.Use(a.apiKeyAuth)
+	}
+	if a.compressor != nil {
+		r.Use(a.compressor)
+	}
+	if a.requestTimeout != nil {
+		r.Use(a.requestTimeout)
+	}
 	r.Get("/healthcheck", registerHandler(handlers.HealthCheck))
+	r.Get("/healthcheck/live", registerHandler(handlers.HealthCheckLive))
+	r.Get("/healthcheck/ready", registerHandler(handlers.HealthCheckReady))
 
 	r.Get("/v1/staker/delegations", registerHandler(handlers.GetStakerDelegations))
-	r.Post("/v1/unbonding", registerHandler(handlers.UnbondDelegation))
+	r.Post("/v1/staker/delegations/batch", registerHandler(handlers.GetStakerDelegationsBatch))
+	r.Post("/v1/staker/delegations/export", registerHandler(handlers.CreateStakerDelegationsExport))
+	r.Get("/v1/staker/delegations/export/{job_id}", registerHandler(handlers.GetStakerDelegationsExport))
+	r.Get("/v1/staker/delegations/export/{job_id}/download", registerHandler(handlers.DownloadStakerDelegationsExport))
+	a.writeRoutes(r).Post("/v1/unbonding", registerHandler(handlers.UnbondDelegation))
 	r.Get("/v1/unbonding/eligibility", registerHandler(handlers.GetUnbondingEligibility))
+	r.Get("/v1/delegations/unbonding", registerHandler(handlers.GetUnbondingDelegations))
 	r.Get("/v1/global-params", registerHandler(handlers.GetBabylonGlobalParams))
+	r.Get("/v1/staking/params", registerHandler(handlers.GetStakingParams))
 	r.Get("/v1/finality-providers", registerHandler(handlers.GetFinalityProviders))
+	r.Post("/v1/finality-providers/batch", registerHandler(handlers.GetFinalityProvidersBatch))
+	r.Get("/v1/finality-providers/delegation-counts", registerHandler(handlers.GetFinalityProviderDelegationCounts))
+	r.Get("/v1/finality-providers/staker-counts", registerHandler(handlers.GetFinalityProviderStakerCounts))
+	r.Get("/v1/finality-provider", registerHandler(handlers.GetFinalityProvider))
+	r.Get("/v1/finality-provider/delegations", registerHandler(handlers.GetFinalityProviderDelegations))
+	r.Get("/v1/finality-provider/stats", registerHandler(handlers.GetFinalityProviderStakeStats))
 	r.Get("/v1/stats", registerHandler(handlers.GetOverallStats))
+	r.Get("/v1/stats/tvl", registerHandler(handlers.GetTvl))
 	r.Get("/v1/stats/staker", registerHandler(handlers.GetTopStakerStats))
+	r.Get("/v1/stats/staker/rank", registerHandler(handlers.GetStakerRank))
+	r.Get("/v1/stats/inactive-provider-stake", registerHandler(handlers.GetInactiveProviderStake))
+	r.Get("/v1/stats/active-set", registerHandler(handlers.GetActiveSetStakeBreakdown))
+	r.Get("/v1/stats/unbonding/daily", registerHandler(handlers.GetDailyUnbondingStats))
+	r.Get("/v1/stats/history", registerHandler(handlers.GetStatsHistory))
+	r.Get("/v1/stats/finality-providers/count", registerHandler(handlers.GetFinalityProviderCount))
 	r.Get("/v1/staker/delegation/check", registerHandler(handlers.CheckStakerDelegationExist))
+	r.Get("/v1/staker/provider-stats", registerHandler(handlers.GetStakerFinalityProviderStats))
+	r.Get("/v1/staker/activity/daily", registerHandler(handlers.GetStakerDailyActivity))
+	r.Get("/v1/staker/total-stake", registerHandler(handlers.GetStakerTotalStake))
+	r.Get("/v1/staker/dashboard", registerHandler(handlers.GetStakerDashboard))
+	r.Get("/v1/staker/delegation-summary", registerHandler(handlers.GetStakerDelegationSummary))
+	r.Get("/v1/staker/unbondings", registerHandler(handlers.GetStakerUnbondings))
+	r.Get("/v1/staker/linked", registerHandler(handlers.GetStakerLinkage))
 	r.Get("/v1/delegation", registerHandler(handlers.GetDelegationByTxHash))
+	r.Get("/v1/delegation/by-output", registerHandler(handlers.GetDelegationByStakingOutput))
+	r.Get("/v1/delegation/history", registerHandler(handlers.GetDelegationStateHistory))
+	r.Post("/v1/delegations", registerHandler(handlers.GetDelegationsByTxHashes))
+	r.Get("/v1/events/delegations", registerHandler(handlers.GetDelegationStateChanges))
 
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 }
+
+// writeRoutes returns the router the state changing routes are registered on,
+// which requires an API key if any is configured.
+func (a *Server) writeRoutes(r chi.Router) chi.Router {
+	if a.apiKeyAuth == nil || a.apiKeyAuthAllRoutes {
+		// Either not required or already required on all the routes
+		return r
+	}
+	return r.With(a.apiKeyAuth)
+}
@@ -17,6 +17,17 @@ import (
 type Server struct {
 	httpServer *http.Server
 	handlers   *handlers.Handler
+	// Not set if no trusted proxy is configured
+	realIP func(http.Handler) http.Handler
+	// Not set if the rate limit is disabled
+	rateLimiter func(http.Handler) http.Handler
+	// Not set if the compression is disabled
+	compressor func(http.Handler) http.Handler
+	// Not set if no request timeout is configured
+	requestTimeout func(http.Handler) http.Handler
+	// Not set if no API key is configured
+	apiKeyAuth          func(http.Handler) http.Handler
+	apiKeyAuthAllRoutes bool
 }
 
 func New(
@@ -34,6 +45,9 @@ func New(
 	r.Use(middlewares.SecurityHeadersMiddleware())
 	r.Use(middlewares.TracingMiddleware)
 	r.Use(middlewares.LoggingMiddleware)
+	if cfg.Server.BtcTipHeightHeader {
+		r.Use(middlewares.BtcTipHeightMiddleware(services.GetBtcTipHeight))
+	}
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -52,6 +66,24 @@ func New(
 		httpServer: srv,
 		handlers:   handlers,
 	}
+	if len(cfg.Server.TrustedProxyNets) > 0 {
+		server.realIP = middlewares.TrustedRealIPMiddleware(cfg.Server.TrustedProxyNets)
+	}
+	if cfg.Server.RateLimitRps > 0 {
+		server.rateLimiter = middlewares.RateLimitMiddleware(
+			services.Cache, cfg.Server.RateLimitRps, cfg.Server.RateLimitBurst,
+		)
+	}
+	if !cfg.Server.DisableCompression {
+		server.compressor = middlewares.CompressionMiddleware(cfg.Server.CompressionMinSize)
+	}
+	if cfg.Server.RequestTimeout > 0 {
+		server.requestTimeout = middlewares.RequestTimeoutMiddleware(cfg.Server.RequestTimeout)
+	}
+	if len(cfg.Server.ApiKeyHashesBytes) > 0 {
+		server.apiKeyAuth = middlewares.ApiKeyAuthMiddleware(cfg.Server.ApiKeyHashesBytes)
+		server.apiKeyAuthAllRoutes = cfg.Server.ApiKeyAuthAllRoutes
+	}
 	server.SetupRoutes(r)
 	return server, nil
 }
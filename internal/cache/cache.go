@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+// Cache is the storage shared by the cached data and the rate-limit counters.
+// Values are opaque bytes, callers are responsible for the serialization.
+type Cache interface {
+	// Get returns the value stored under the key. The second return value is
+	// false if the key does not exist or has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value under the key for the given ttl. A zero ttl means
+	// the value never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Incr increments the counter stored under the key and returns its new value.
+	// The ttl is applied when the counter is created, it's not extended afterwards.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Delete removes the value or the counter stored under the key, if any.
+	Delete(ctx context.Context, key string) error
+	Ping(ctx context.Context) error
+}
+
+// New creates the cache configured by the given config. The connection to the
+// remote backend (if any) is validated before returning.
+func New(ctx context.Context, cfg config.CacheConfig) (Cache, error) {
+	switch cfg.Type {
+	case config.MemoryCacheType, "":
+		return NewMemoryCache(), nil
+	case config.RedisCacheType:
+		redisCache := NewRedisCache(cfg.Redis)
+		if err := redisCache.Ping(ctx); err != nil {
+			return nil, fmt.Errorf("failed to connect to redis: %w", err)
+		}
+		return redisCache, nil
+	default:
+		return nil, fmt.Errorf("unsupported cache type: %s", cfg.Type)
+	}
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value     []byte
+	counter   int64
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) isExpired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryCache is the default cache, local to the running instance.
+type MemoryCache struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// Interval between two sweeps of the expired entries
+const memorySweepInterval = time.Minute
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if entry.isExpired(time.Now()) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictExpired()
+	entry := &memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *MemoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := c.entries[key]
+	if !ok || entry.isExpired(now) {
+		c.evictExpired()
+		entry = &memoryEntry{}
+		if ttl > 0 {
+			entry.expiresAt = now.Add(ttl)
+		}
+		c.entries[key] = entry
+	}
+	entry.counter++
+	return entry.counter, nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+func (c *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+// evictExpired periodically removes all the expired entries so that keys which
+// are never read again do not pile up. The caller must hold the lock.
+func (c *MemoryCache) evictExpired() {
+	now := time.Now()
+	if now.Sub(c.lastSweep) < memorySweepInterval {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if entry.isExpired(now) {
+			delete(c.entries, key)
+		}
+	}
+}
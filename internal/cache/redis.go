@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+// incrScript increments the counter and sets its expiry when it's created, in a
+// single atomic step, so that a counter can never be left without an expiry.
+// Only the first increment sets the expiry, so that the counter window is not
+// extended by the subsequent increments.
+var incrScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// RedisCache is a cache backed by a Redis server, shared by all the instances
+// pointing to it.
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(cfg config.RedisConfig) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:        cfg.Address,
+			Password:    cfg.Password,
+			DB:          cfg.Db,
+			PoolSize:    cfg.PoolSize,
+			DialTimeout: cfg.DialTimeout,
+		}),
+	}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
+
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	MemoryCacheType = "memory"
+	RedisCacheType  = "redis"
+
+	defaultRedisPoolSize    = 10
+	defaultRedisDialTimeout = 5 * time.Second
+)
+
+// CacheConfig defines the storage used for the cached data and rate-limit counters.
+// The in-memory cache is used if no type is configured.
+type CacheConfig struct {
+	Type  string      `mapstructure:"type"`
+	Redis RedisConfig `mapstructure:"redis"`
+}
+
+type RedisConfig struct {
+	Address  string `mapstructure:"address"`
+	Password string `mapstructure:"password"`
+	// Logical redis database index
+	Db          int           `mapstructure:"db"`
+	PoolSize    int           `mapstructure:"pool-size"`
+	DialTimeout time.Duration `mapstructure:"dial-timeout"`
+}
+
+func (cfg *CacheConfig) Validate() error {
+	switch cfg.Type {
+	case "":
+		cfg.Type = MemoryCacheType
+	case MemoryCacheType:
+	case RedisCacheType:
+		return cfg.Redis.Validate()
+	default:
+		return fmt.Errorf("unsupported cache type: %s", cfg.Type)
+	}
+	return nil
+}
+
+func (cfg *RedisConfig) Validate() error {
+	if cfg.Address == "" {
+		return fmt.Errorf("missing redis address")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		return fmt.Errorf("invalid redis address: %w", err)
+	}
+	if cfg.Db < 0 {
+		return fmt.Errorf("redis db index cannot be negative")
+	}
+	if cfg.PoolSize < 0 {
+		return fmt.Errorf("redis pool size cannot be negative")
+	}
+	if cfg.PoolSize == 0 {
+		cfg.PoolSize = defaultRedisPoolSize
+	}
+	if cfg.DialTimeout < 0 {
+		return fmt.Errorf("redis dial timeout cannot be negative")
+	}
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = defaultRedisDialTimeout
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ type Config struct {
 	Db      DbConfig          `mapstructure:"db"`
 	Queue   queue.QueueConfig `mapstructure:"queue"`
 	Metrics MetricsConfig     `mapstructure:"metrics"`
+	Cache   CacheConfig       `mapstructure:"cache"`
 }
 
 func (cfg *Config) Validate() error {
@@ -33,9 +34,31 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := cfg.Cache.Validate(); err != nil {
+		return err
+	}
+
+	if defaultPageSize, maxPageSize := cfg.PageSizeLimits(); defaultPageSize > maxPageSize {
+		return fmt.Errorf("default page size %d cannot exceed the max page size %d", defaultPageSize, maxPageSize)
+	}
+
 	return nil
 }
 
+// PageSizeLimits returns the default and the maximum page size of the
+// delegation listings, see ServerConfig.DefaultPageSize.
+func (cfg *Config) PageSizeLimits() (defaultPageSize, maxPageSize int64) {
+	defaultPageSize = cfg.Server.DefaultPageSize
+	if defaultPageSize == 0 {
+		defaultPageSize = cfg.Db.MaxPaginationLimit
+	}
+	maxPageSize = cfg.Server.MaxPageSize
+	if maxPageSize == 0 {
+		maxPageSize = defaultPageSize
+	}
+	return defaultPageSize, maxPageSize
+}
+
 // New returns a fully parsed Config object from a given file directory
 func New(cfgFile string) (*Config, error) {
 	_, err := os.Stat(cfgFile)
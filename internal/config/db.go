@@ -8,6 +8,8 @@ import (
 
 const (
 	maxLogicalShardCount = 100
+	// Minimum length of the pagination token secret
+	minPaginationTokenSecretLength = 16
 )
 
 type DbConfig struct {
@@ -16,6 +18,9 @@ type DbConfig struct {
 	MaxPaginationLimit int64  `mapstructure:"max-pagination-limit"`
 	DbBatchSizeLimit   int64  `mapstructure:"db-batch-size-limit"`
 	LogicalShardCount  int64  `mapstructure:"logical-shard-count"`
+	// Secret the pagination tokens are authenticated with, so that forged or
+	// corrupted tokens are rejected. Changing it invalidates the issued tokens.
+	PaginationTokenSecret string `mapstructure:"pagination-token-secret"`
 }
 
 func (cfg *DbConfig) Validate() error {
@@ -58,6 +63,10 @@ func (cfg *DbConfig) Validate() error {
 		return fmt.Errorf("max pagination limit must be greater than 0")
 	}
 
+	if len(cfg.PaginationTokenSecret) < minPaginationTokenSecretLength {
+		return fmt.Errorf("pagination token secret must be at least %d characters", minPaginationTokenSecretLength)
+	}
+
 	if cfg.DbBatchSizeLimit <= 0 {
 		return fmt.Errorf("db batch size limit must be greater than 0")
 	}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -12,6 +16,11 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+const (
+	BtcExplorerTxIdPlaceholder    = "{txid}"
+	BtcExplorerNetworkPlaceholder = "{network}"
+)
+
 type ServerConfig struct {
 	Host           string        `mapstructure:"host"`
 	Port           int           `mapstructure:"port"`
@@ -21,8 +30,76 @@ type ServerConfig struct {
 	AllowedOrigins []string      `mapstructure:"allowed-origins"`
 	BTCNet         string        `mapstructure:"btc-net"`
 	LogLevel       string        `mapstructure:"log-level"`
+	// Template of the explorer URL of a BTC transaction. The `{txid}` placeholder
+	// is replaced by the tx hash and `{network}` by the mempool.space path of the
+	// configured btc-net, which is empty on mainnet.
+	// Explorer URLs are not returned if empty.
+	BtcExplorerTxUrlTemplate string `mapstructure:"btc-explorer-tx-url-template"`
+	// Maximum number of items returned by the listings that are not fully paginated.
+	// The result is cut short with a warning beyond it, at a pagination key that
+	// resumes right after the last returned item. No limit is applied if 0.
+	MaxUnpaginatedResults int `mapstructure:"max-unpaginated-results"`
+	// Deadline of the stats computation, the last computed stats are served
+	// beyond it. No deadline is applied if 0.
+	StatsComputationTimeout time.Duration `mapstructure:"stats-computation-timeout"`
+	// Minimum number of confirmations of the staking tx before a delegation is
+	// shown in the delegation listings. Lookups by tx hash are not affected so
+	// that a staker can still follow a freshly submitted delegation. Stats are
+	// not affected either, hence the listings may briefly lag behind them.
+	// No filtering is applied if 0.
+	MinDisplayConfirmations uint64 `mapstructure:"min-display-confirmations"`
+	// Whether the overflow delegations are excluded from the delegation listings
+	// unless explicitly requested with `include_overflow=true`. The stats never
+	// account for the overflow delegations regardless of this setting.
+	HideOverflowByDefault bool `mapstructure:"hide-overflow-by-default"`
+	// Whether the latest indexed BTC height is returned in the `X-Btc-Tip-Height`
+	// header of the read requests, so that clients know how fresh the data is.
+	BtcTipHeightHeader bool `mapstructure:"btc-tip-height-header"`
+	// Whether the stake delegated to the finality providers outside of the
+	// active set, i.e not in the finality providers config, is excluded from
+	// the active TVL served by the TVL endpoint.
+	TvlExcludeInactiveProviders bool `mapstructure:"tvl-exclude-inactive-providers"`
+	// Average number of requests per second allowed per client IP. Up to
+	// RateLimitBurst requests are allowed per window of RateLimitBurst/RateLimitRps
+	// seconds. No rate limit is applied if 0.
+	RateLimitRps   float64 `mapstructure:"rate-limit-rps"`
+	RateLimitBurst int     `mapstructure:"rate-limit-burst"`
+	// CIDRs of the proxies, e.g. the load balancer, whose X-Forwarded-For and
+	// X-Real-IP headers are trusted to tell the client IP. The headers are
+	// ignored on the requests coming from any other address.
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
+	// Page size of the delegation listings when the `limit` query param is not
+	// provided, and the maximum page size a client can request with it. Limits
+	// above the maximum are rejected rather than clamped. The db max pagination
+	// limit is the default page size if 0, and the default page size is the
+	// maximum if 0, i.e clients can only request smaller pages.
+	DefaultPageSize int64 `mapstructure:"default-page-size"`
+	MaxPageSize     int64 `mapstructure:"max-page-size"`
+	// Whether the gzip compression of the responses is disabled, e.g. when a
+	// fronting proxy already compresses them. Otherwise the responses of at
+	// least CompressionMinSize bytes are compressed for the clients accepting it.
+	DisableCompression bool `mapstructure:"disable-compression"`
+	CompressionMinSize int  `mapstructure:"compression-min-size"`
+	// Maximum processing time of a request, including the streamed exports.
+	// The service and db calls are cancelled beyond it and a 504 is returned,
+	// unless the response is already being streamed, in which case the
+	// connection is closed without terminating the body. No timeout is applied if 0.
+	RequestTimeout time.Duration `mapstructure:"request-timeout"`
+	// Maximum time each of the dependencies is given to answer the readiness
+	// check before it is reported down. No timeout is applied if 0.
+	ReadinessCheckTimeout time.Duration `mapstructure:"readiness-check-timeout"`
+	// Hex encoded SHA-256 hashes of the API keys accepted in the X-Api-Key
+	// header, e.g. from `echo -n $API_KEY | sha256sum`. If set, the state
+	// changing routes require one of the keys, and all the routes but the
+	// health checks do if ApiKeyAuthAllRoutes is set.
+	ApiKeyHashes        []string `mapstructure:"api-key-hashes"`
+	ApiKeyAuthAllRoutes bool     `mapstructure:"api-key-auth-all-routes"`
 
 	BTCNetParam *chaincfg.Params
+	// Decoded ApiKeyHashes, set on validation
+	ApiKeyHashesBytes [][]byte
+	// Parsed TrustedProxies, set on validation
+	TrustedProxyNets []*net.IPNet
 }
 
 func (cfg *ServerConfig) Validate() error {
@@ -47,6 +124,70 @@ func (cfg *ServerConfig) Validate() error {
 		return errors.New("idle timeout cannot be negative")
 	}
 
+	for _, origin := range cfg.AllowedOrigins {
+		if err := validateAllowedOrigin(origin); err != nil {
+			return err
+		}
+	}
+
+	if cfg.MaxUnpaginatedResults < 0 {
+		return errors.New("max unpaginated results cannot be negative")
+	}
+
+	if cfg.StatsComputationTimeout < 0 {
+		return errors.New("stats computation timeout cannot be negative")
+	}
+
+	if cfg.RateLimitRps < 0 {
+		return errors.New("rate limit rps cannot be negative")
+	}
+
+	if cfg.RateLimitRps > 0 && cfg.RateLimitBurst < 1 {
+		return errors.New("rate limit burst must be at least 1 when rate limiting")
+	}
+
+	if cfg.DefaultPageSize < 0 {
+		return errors.New("default page size cannot be negative")
+	}
+
+	if cfg.MaxPageSize < 0 {
+		return errors.New("max page size cannot be negative")
+	}
+
+	if cfg.CompressionMinSize < 0 {
+		return errors.New("compression min size cannot be negative")
+	}
+
+	if cfg.RequestTimeout < 0 {
+		return errors.New("request timeout cannot be negative")
+	}
+
+	if cfg.ReadinessCheckTimeout < 0 {
+		return errors.New("readiness check timeout cannot be negative")
+	}
+
+	cfg.TrustedProxyNets = make([]*net.IPNet, 0, len(cfg.TrustedProxies))
+	for _, trustedProxy := range cfg.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: must be a CIDR", trustedProxy)
+		}
+		cfg.TrustedProxyNets = append(cfg.TrustedProxyNets, ipNet)
+	}
+
+	cfg.ApiKeyHashesBytes = make([][]byte, 0, len(cfg.ApiKeyHashes))
+	for _, apiKeyHash := range cfg.ApiKeyHashes {
+		hashBytes, err := hex.DecodeString(apiKeyHash)
+		if err != nil || len(hashBytes) != sha256.Size {
+			return fmt.Errorf("invalid api key hash %q: must be a hex encoded SHA-256 hash", apiKeyHash)
+		}
+		cfg.ApiKeyHashesBytes = append(cfg.ApiKeyHashesBytes, hashBytes)
+	}
+
+	if cfg.ApiKeyAuthAllRoutes && len(cfg.ApiKeyHashes) == 0 {
+		return errors.New("api key hashes are required to authenticate all the routes")
+	}
+
 	btcNet, err := utils.GetBtcNetParamesFromString(cfg.BTCNet)
 	if err != nil {
 		return errors.New("invalid btc-net")
@@ -54,6 +195,34 @@ func (cfg *ServerConfig) Validate() error {
 
 	cfg.BTCNetParam = btcNet
 
+	if cfg.BtcExplorerTxUrlTemplate != "" &&
+		!strings.Contains(cfg.BtcExplorerTxUrlTemplate, BtcExplorerTxIdPlaceholder) {
+		return fmt.Errorf("btc explorer tx url template must contain the %s placeholder", BtcExplorerTxIdPlaceholder)
+	}
+
+	return nil
+}
+
+// validateAllowedOrigin checks that the origin is either "*", allowing any
+// origin, or a http(s) origin without path, query or fragment. The host may
+// contain a single "*" wildcard, e.g. https://*.example.com.
+func validateAllowedOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	if strings.Count(origin, "*") > 1 {
+		return fmt.Errorf("invalid allowed origin %q: at most one wildcard is supported", origin)
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("invalid allowed origin %q: %w", origin, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid allowed origin %q: scheme must be http or https", origin)
+	}
+	if u.Host == "" || u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid allowed origin %q: must be of the form scheme://host[:port]", origin)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"go.mongodb.org/mongo-driver/bson"
@@ -33,6 +34,7 @@ func (db *Database) UpsertLatestBtcInfo(
 			BtcHeight:      height,
 			ConfirmedTvl:   confirmedTvl,
 			UnconfirmedTvl: unconfirmedTvl,
+			UpdatedAt:      time.Now().Unix(),
 		}
 		if findErr == mongo.ErrNoDocuments {
 			// If no document exists, insert a new one
@@ -12,6 +12,8 @@ type Database struct {
 	DbName string
 	Client *mongo.Client
 	cfg    config.DbConfig
+	// Secret the pagination tokens are authenticated with
+	paginationTokenSecret []byte
 }
 
 type DbResultMap[T any] struct {
@@ -27,9 +29,10 @@ func New(ctx context.Context, cfg config.DbConfig) (*Database, error) {
 	}
 
 	return &Database{
-		DbName: cfg.DbName,
-		Client: client,
-		cfg:    cfg,
+		DbName:                cfg.DbName,
+		Client:                client,
+		cfg:                   cfg,
+		paginationTokenSecret: []byte(cfg.PaginationTokenSecret),
 	}, nil
 }
 
@@ -44,9 +47,20 @@ func (db *Database) Ping(ctx context.Context) error {
 // This function is used to build the result map with pagination token
 // It will return the result map with pagination token if the result length is equal to the fetch limit
 // Otherwise it will return the result map without pagination token. i.e pagination token will be empty string
-func toResultMapWithPaginationToken[T any](cfg config.DbConfig, result []T, paginationKeyBuilder func(T) (string, error)) (*DbResultMap[T], error) {
-	if len(result) > 0 && len(result) == int(cfg.MaxPaginationLimit) {
-		paginationToken, err := paginationKeyBuilder(result[len(result)-1])
+func toResultMapWithPaginationToken[T any](cfg config.DbConfig, result []T, paginationKeyBuilder func([]byte, T) (string, error)) (*DbResultMap[T], error) {
+	return toResultMapWithLimitedPaginationToken(
+		[]byte(cfg.PaginationTokenSecret), cfg.MaxPaginationLimit, result, paginationKeyBuilder,
+	)
+}
+
+// toResultMapWithLimitedPaginationToken builds the pagination token of a page
+// fetched with the given limit, a full page means there may be a next one.
+// The token is authenticated with the given secret.
+func toResultMapWithLimitedPaginationToken[T any](
+	secret []byte, limit int64, result []T, paginationKeyBuilder func([]byte, T) (string, error),
+) (*DbResultMap[T], error) {
+	if len(result) > 0 && len(result) == int(limit) {
+		paginationToken, err := paginationKeyBuilder(secret, result[len(result)-1])
 		if err != nil {
 			return nil, err
 		}
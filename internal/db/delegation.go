@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -36,21 +37,36 @@ func (db *Database) SaveActiveStakingDelegation(
 		StakerBtcAddress: &model.StakerBtcAddress{
 			TaprootAddress: stakerTaprootAddress,
 		},
+		UpdatedAt: time.Now().Unix(),
 	}
-	_, err := client.InsertOne(ctx, document)
+	session, err := db.Client.StartSession()
 	if err != nil {
-		var writeErr mongo.WriteException
-		if errors.As(err, &writeErr) {
-			for _, e := range writeErr.WriteErrors {
-				if mongo.IsDuplicateKeyError(e) {
-					// Return the custom error type so that we can return 4xx errors to client
-					return &DuplicateKeyError{
-						Key:     stakingTxHashHex,
-						Message: "Delegation already exists",
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		_, err := client.InsertOne(sessCtx, document)
+		if err != nil {
+			var writeErr mongo.WriteException
+			if errors.As(err, &writeErr) {
+				for _, e := range writeErr.WriteErrors {
+					if mongo.IsDuplicateKeyError(e) {
+						// Return the custom error type so that we can return 4xx errors to client
+						return nil, &DuplicateKeyError{
+							Key:     stakingTxHashHex,
+							Message: "Delegation already exists",
+						}
 					}
 				}
 			}
+			return nil, err
 		}
+		return nil, db.saveStateChange(sessCtx, stakingTxHashHex, "", types.Active, startHeight)
+	}
+
+	_, err = session.WithTransaction(ctx, transactionWork)
+	if err != nil {
 		return err
 	}
 	return nil
@@ -76,16 +92,53 @@ func (db *Database) CheckDelegationExistByStakerTaprootAddress(
 	return true, nil
 }
 
-func (db *Database) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, paginationToken string) (*DbResultMap[model.DelegationDocument], error) {
+// FindStakerPkByTaprootAddress finds the pk of the staker whose taproot address
+// is the given one, from any of its delegations.
+// It returns a NotFoundError if no delegation has been made from the address.
+func (db *Database) FindStakerPkByTaprootAddress(ctx context.Context, address string) (string, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := bson.M{"staker_btc_address.taproot_address": address}
+	var delegation model.DelegationDocument
+	err := client.FindOne(ctx, filter).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return "", &NotFoundError{
+				Key:     address,
+				Message: "No delegation found for the staker address",
+			}
+		}
+		return "", err
+	}
+	return delegation.StakerPkHex, nil
+}
+
+// FindDelegationsByStakerPk finds the delegations of the staker, sorted by the
+// staking start height in descending order unless another sort is given.
+// Only the given fields are fetched if any, along with the ones the sort and
+// the pagination token rely on.
+func (db *Database) FindDelegationsByStakerPk(
+	ctx context.Context, stakerPk string,
+	extraFilter *DelegationFilter, sort *DelegationSort, paginationToken string, limit int64,
+	fields []string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	if limit <= 0 {
+		limit = db.cfg.MaxPaginationLimit
+	}
+	if sort != nil {
+		return db.findSortedDelegationsByStakerPk(ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields)
+	}
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
 
 	filter := bson.M{"staker_pk_hex": stakerPk}
 	options := options.Find().SetSort(bson.M{"staking_tx.start_height": -1}) // Sorting in descending order
 
-	options.SetLimit(db.cfg.MaxPaginationLimit)
+	options.SetLimit(limit)
+	if fields != nil {
+		options.SetProjection(buildDelegationProjection(fields, nil))
+	}
 	// Decode the pagination token first if it exist
 	if paginationToken != "" {
-		decodedToken, err := model.DecodePaginationToken[model.DelegationByStakerPagination](paginationToken)
+		decodedToken, err := model.DecodePaginationToken[model.DelegationByStakerPagination](db.paginationTokenSecret, paginationToken)
 		if err != nil {
 			return nil, &InvalidPaginationTokenError{
 				Message: "Invalid pagination token",
@@ -98,6 +151,7 @@ func (db *Database) FindDelegationsByStakerPk(ctx context.Context, stakerPk stri
 			},
 		}
 	}
+	filter = buildAdditionalDelegationFilter(filter, extraFilter)
 
 	cursor, err := client.Find(ctx, filter, options)
 	if err != nil {
@@ -110,7 +164,301 @@ func (db *Database) FindDelegationsByStakerPk(ctx context.Context, stakerPk stri
 		return nil, err
 	}
 
-	return toResultMapWithPaginationToken(db.cfg, delegations, model.BuildDelegationByStakerPaginationToken)
+	return toResultMapWithLimitedPaginationToken(db.paginationTokenSecret, limit, delegations, model.BuildDelegationByStakerPaginationToken)
+}
+
+// FindDelegationsByStakerPks finds the first page of at most limit delegations
+// of each of the stakers in a single query, keyed by staker pk hex. Each page
+// is sorted as the default FindDelegationsByStakerPk one, along with the
+// pagination token resuming it from FindDelegationsByStakerPk. The stakers
+// without any delegation are left out of the result. Requires MongoDB 5.2+
+// for $firstN, which keeps only the page of each staker in the group stage.
+func (db *Database) FindDelegationsByStakerPks(
+	ctx context.Context, stakerPks []string, extraFilter *DelegationFilter, limit int64,
+) (map[string]*DbResultMap[model.DelegationDocument], error) {
+	if limit <= 0 {
+		limit = db.cfg.MaxPaginationLimit
+	}
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+
+	filter := buildAdditionalDelegationFilter(bson.M{"staker_pk_hex": bson.M{"$in": stakerPks}}, extraFilter)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "staking_tx.start_height", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$staker_pk_hex",
+			"delegations": bson.M{"$firstN": bson.M{"input": "$$ROOT", "n": limit}},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StakerPkHex string                     `bson:"_id"`
+		Delegations []model.DelegationDocument `bson:"delegations"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stakersDelegations := make(map[string]*DbResultMap[model.DelegationDocument], len(results))
+	for _, result := range results {
+		resultMap, err := toResultMapWithLimitedPaginationToken(
+			db.paginationTokenSecret, limit, result.Delegations, model.BuildDelegationByStakerPaginationToken,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stakersDelegations[result.StakerPkHex] = resultMap
+	}
+	return stakersDelegations, nil
+}
+
+// findSortedDelegationsByStakerPk finds the delegations of the staker in the
+// given sort order. The pagination token records the sort it was issued for,
+// so that resuming it with any other sort is rejected as an invalid token.
+func (db *Database) findSortedDelegationsByStakerPk(
+	ctx context.Context, stakerPk string,
+	extraFilter *DelegationFilter, sort *DelegationSort, paginationToken string, limit int64,
+	fields []string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+
+	direction, afterOperator := -1, "$lt"
+	if sort.Ascending {
+		direction, afterOperator = 1, "$gt"
+	}
+	field := string(sort.Field)
+	options := options.Find().SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: 1}})
+	options.SetLimit(limit)
+	if fields != nil {
+		options.SetProjection(buildDelegationProjection(fields, sort))
+	}
+
+	filter := bson.M{"staker_pk_hex": stakerPk}
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.DelegationByStakerSortedPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil || decodedToken.SortField != field || decodedToken.Ascending != sort.Ascending {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter = bson.M{
+			"$or": []bson.M{
+				{"staker_pk_hex": stakerPk, field: bson.M{afterOperator: decodedToken.SortValue}},
+				{"staker_pk_hex": stakerPk, field: decodedToken.SortValue, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+			},
+		}
+	}
+	filter = buildAdditionalDelegationFilter(filter, extraFilter)
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []model.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithLimitedPaginationToken(db.paginationTokenSecret, limit, delegations, func(secret []byte, d model.DelegationDocument) (string, error) {
+		sortValue := d.StakingTx.StartHeight
+		if sort.Field == SortByStakingValue {
+			sortValue = d.StakingValue
+		}
+		return model.BuildDelegationByStakerSortedPaginationToken(secret, d, field, sortValue, sort.Ascending)
+	})
+}
+
+// FindDelegationsByFinalityProviderPk finds the delegations to the finality
+// provider, sorted by the staking start height in descending order.
+func (db *Database) FindDelegationsByFinalityProviderPk(
+	ctx context.Context, fpPkHex string,
+	extraFilter *DelegationFilter, paginationToken string, limit int64,
+) (*DbResultMap[model.DelegationDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+
+	if limit <= 0 {
+		limit = db.cfg.MaxPaginationLimit
+	}
+	filter := bson.M{"finality_provider_pk_hex": fpPkHex}
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: -1},
+		{Key: "_id", Value: 1},
+	})
+	options.SetLimit(limit)
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.DelegationByFinalityProviderPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter = bson.M{
+			"$or": []bson.M{
+				{"finality_provider_pk_hex": fpPkHex, "staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
+				{"finality_provider_pk_hex": fpPkHex, "staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+			},
+		}
+	}
+	filter = buildAdditionalDelegationFilter(filter, extraFilter)
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []model.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithLimitedPaginationToken(db.paginationTokenSecret, limit, delegations, model.BuildDelegationByFinalityProviderPaginationToken)
+}
+
+// SumDelegationsStakingValue sums up the staking value of the delegations
+// matching the given filter.
+func (db *Database) SumDelegationsStakingValue(
+	ctx context.Context, extraFilter *DelegationFilter,
+) (uint64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildAdditionalDelegationFilter(bson.M{}, extraFilter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           nil,
+			"staking_value": bson.M{"$sum": "$staking_value"},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StakingValue int64 `bson:"staking_value"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+	// No delegation matched the filter
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return uint64(results[0].StakingValue), nil
+}
+
+// SumStakingValueBeforeInCapOrder sums up the staking value of the delegations
+// with a staking start height within [fromHeight, toHeight) that precede the
+// given delegation in the staking cap fill order, i.e by staking start height
+// and then by staking tx hash. Only the delegations filling the cap are
+// accounted for, i.e the ones not overflowing it whose stake is still active.
+// A toHeight of 0 means the range has no upper bound.
+func (db *Database) SumStakingValueBeforeInCapOrder(
+	ctx context.Context, stakingTxHashHex string, startHeight, fromHeight, toHeight uint64,
+) (uint64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	heightRange := bson.M{"$gte": fromHeight}
+	if toHeight != 0 {
+		heightRange["$lt"] = toHeight
+	}
+	filter := bson.M{
+		"$and": []bson.M{
+			{"staking_tx.start_height": heightRange},
+			{"$or": []bson.M{
+				{"staking_tx.start_height": bson.M{"$lt": startHeight}},
+				{"staking_tx.start_height": startHeight, "_id": bson.M{"$lt": stakingTxHashHex}},
+			}},
+		},
+		"is_overflow": false,
+		"state":       bson.M{"$in": activeStakeDelegationStates},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           nil,
+			"staking_value": bson.M{"$sum": "$staking_value"},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StakingValue int64 `bson:"staking_value"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return uint64(results[0].StakingValue), nil
+}
+
+// CountDelegationsByState counts the delegations matching the given filter
+// per state. The states without any delegation are omitted.
+func (db *Database) CountDelegationsByState(
+	ctx context.Context, extraFilter *DelegationFilter,
+) ([]model.DelegationStateCount, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildAdditionalDelegationFilter(bson.M{}, extraFilter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$state",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.DelegationStateCount
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountDistinctFinalityProviders counts the distinct finality providers the
+// delegations matching the given filter are delegated to.
+func (db *Database) CountDistinctFinalityProviders(
+	ctx context.Context, extraFilter *DelegationFilter,
+) (int64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildAdditionalDelegationFilter(bson.M{}, extraFilter)}},
+		{{Key: "$group", Value: bson.M{"_id": "$finality_provider_pk_hex"}}},
+		{{Key: "$count", Value: "finality_providers"}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		FinalityProviders int64 `bson:"finality_providers"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+	// No delegation matched the filter
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return results[0].FinalityProviders, nil
 }
 
 // SaveUnbondingTx saves the unbonding transaction details for a staking transaction
@@ -132,41 +480,207 @@ func (db *Database) FindDelegationByTxHashHex(ctx context.Context, stakingTxHash
 	return &delegation, nil
 }
 
+// FindLatestDelegationStakingHeight returns the highest staking tx height of
+// the stored delegations, or 0 if there is none.
+func (db *Database) FindLatestDelegationStakingHeight(ctx context.Context) (uint64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	options := options.FindOne().
+		SetSort(bson.D{{Key: "staking_tx.start_height", Value: -1}}).
+		SetProjection(bson.M{"staking_tx.start_height": 1})
+	var delegation model.DelegationDocument
+	err := client.FindOne(ctx, bson.M{}, options).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if delegation.StakingTx == nil {
+		return 0, nil
+	}
+	return delegation.StakingTx.StartHeight, nil
+}
+
+// FindDelegationsByTxHashHexes fetches the delegations identified by the staking
+// tx hashes in a single query. The hashes without a matching delegation are left
+// out of the result, which is not ordered.
+func (db *Database) FindDelegationsByTxHashHexes(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]model.DelegationDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := bson.M{"_id": bson.M{"$in": stakingTxHashHexes}}
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []model.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// FindDelegationByStakingOutput fetches the delegation of the staker identified by
+// its staking output, i.e. the staking tx hash and the output index.
+// It returns a NotFoundError if no delegation matches.
+func (db *Database) FindDelegationByStakingOutput(
+	ctx context.Context, stakerPkHex, stakingTxHashHex string, outputIndex uint64,
+) (*model.DelegationDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	// The staking tx hash is the primary key, hence the lookup is always indexed
+	filter := bson.M{
+		"_id":                     stakingTxHashHex,
+		"staker_pk_hex":           stakerPkHex,
+		"staking_tx.output_index": outputIndex,
+	}
+	var delegation model.DelegationDocument
+	err := client.FindOne(ctx, filter).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     stakingTxHashHex,
+				Message: "Delegation not found",
+			}
+		}
+		return nil, err
+	}
+	return &delegation, nil
+}
+
 // TransitionState updates the state of a staking transaction to a new state
 // It returns an NotFoundError if the staking transaction is not found or not in the eligible state to transition
 func (db *Database) transitionState(
 	ctx context.Context, stakingTxHashHex, newState string,
 	eligiblePreviousState []types.DelegationState, additionalUpdates map[string]interface{},
+	btcHeight uint64,
 ) error {
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
 	filter := bson.M{"_id": stakingTxHashHex, "state": bson.M{"$in": eligiblePreviousState}}
-	update := bson.M{"$set": bson.M{"state": newState}}
+	update := bson.M{"$set": bson.M{"state": newState, "updated_at": time.Now().Unix()}}
 	for field, value := range additionalUpdates {
 		// Add additional fields to the $set operation
 		update["$set"].(bson.M)[field] = value
 	}
-	_, err := client.UpdateOne(ctx, filter, update)
+
+	session, err := db.Client.StartSession()
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return &NotFoundError{
-				Key:     stakingTxHashHex,
-				Message: "Delegation not found or not in eligible state to transition",
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// The document before the update is returned, which carries the previous state
+		var previous model.DelegationDocument
+		err := client.FindOneAndUpdate(sessCtx, filter, update).Decode(&previous)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				// Nothing has been transitioned, hence no state change to record
+				return nil, nil
 			}
+			return nil, err
 		}
+		return nil, db.saveStateChange(
+			sessCtx, stakingTxHashHex, previous.State, types.DelegationState(newState), btcHeight,
+		)
+	}
+
+	_, err = session.WithTransaction(ctx, transactionWork)
+	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// buildDelegationProjection builds the projection of the given delegation
+// fields, along with the staking start height the default sort and its
+// pagination token rely on, and the field of the given sort if any. The id is
+// always fetched.
+func buildDelegationProjection(fields []string, sort *DelegationSort) bson.M {
+	projection := bson.M{"staking_tx.start_height": 1}
+	if sort != nil {
+		projection[string(sort.Field)] = 1
+	}
+	for _, field := range fields {
+		projection[field] = 1
+	}
+	return projection
+}
+
 func buildAdditionalDelegationFilter(
 	baseFilter primitive.M,
 	filters *DelegationFilter,
 ) primitive.M {
+	if filters == nil {
+		return baseFilter
+	}
+	if filters.StakerPkHex != "" {
+		baseFilter["staker_pk_hex"] = filters.StakerPkHex
+	}
+	if filters.FinalityProviderPkHex != "" {
+		baseFilter["finality_provider_pk_hex"] = filters.FinalityProviderPkHex
+	}
 	if filters.States != nil {
 		baseFilter["state"] = bson.M{"$in": filters.States}
 	}
+	if filters.ExcludeOverflow {
+		baseFilter["is_overflow"] = false
+	}
 	if filters.AfterTimestamp != 0 {
 		baseFilter["staking_tx.start_timestamp"] = bson.M{"$gte": filters.AfterTimestamp}
 	}
+	if filters.UnbondingType != "" {
+		// Only the ended delegations have an unbonding type. Those which went
+		// through early unbonding are the ones having an unbonding tx.
+		endedStates := bson.M{"state": bson.M{"$in": []types.DelegationState{types.Unbonded, types.Withdrawn}}}
+		unbondingTxFilter := bson.M{"unbonding_tx": bson.M{"$exists": filters.UnbondingType == types.EarlyUnbonding}}
+		andFilters, _ := baseFilter["$and"].([]bson.M)
+		baseFilter["$and"] = append(andFilters, endedStates, unbondingTxFilter)
+	}
+	if filters.StakingAmountThresholds != nil {
+		valueOperator := "$lte"
+		if filters.AboveStakingAmountThresholds {
+			valueOperator = "$gt"
+		}
+		thresholdFilters := make([]bson.M, 0, len(filters.StakingAmountThresholds))
+		for _, threshold := range filters.StakingAmountThresholds {
+			heightRange := bson.M{"$gte": threshold.FromHeight}
+			if threshold.ToHeight != 0 {
+				heightRange["$lt"] = threshold.ToHeight
+			}
+			thresholdFilters = append(thresholdFilters, bson.M{
+				"staking_tx.start_height": heightRange,
+				"staking_value":           bson.M{valueOperator: threshold.Amount},
+			})
+		}
+		var thresholdFilter bson.M
+		if len(thresholdFilters) == 0 {
+			// An empty $or is rejected by mongo, match nothing instead
+			thresholdFilter = bson.M{"_id": bson.M{"$in": bson.A{}}}
+		} else {
+			thresholdFilter = bson.M{"$or": thresholdFilters}
+		}
+		andFilters, _ := baseFilter["$and"].([]bson.M)
+		baseFilter["$and"] = append(andFilters, thresholdFilter)
+	}
+	if filters.MinConfirmations != 0 {
+		// The staking tx has (tip - start height + 1) confirmations. A negative
+		// bound matches nothing as the tip is not deep enough yet.
+		maxStartHeight := int64(filters.BtcTipHeight) + 1 - int64(filters.MinConfirmations)
+		andFilters, _ := baseFilter["$and"].([]bson.M)
+		baseFilter["$and"] = append(andFilters, bson.M{"staking_tx.start_height": bson.M{"$lte": maxStartHeight}})
+	}
+	if filters.MinStakingTimelock != 0 || filters.MaxStakingTimelock != 0 {
+		timelockRange := bson.M{}
+		if filters.MinStakingTimelock != 0 {
+			timelockRange["$gte"] = filters.MinStakingTimelock
+		}
+		if filters.MaxStakingTimelock != 0 {
+			timelockRange["$lte"] = filters.MaxStakingTimelock
+		}
+		andFilters, _ := baseFilter["$and"].([]bson.M)
+		baseFilter["$and"] = append(andFilters, bson.M{"staking_tx.timelock": timelockRange})
+	}
 	return baseFilter
 }
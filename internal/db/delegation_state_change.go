@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// saveStateChange records the transition of a delegation from one state to
+// another. It's expected to be called within the same transaction as the
+// actual state update so that the feed never diverges from the delegations.
+// The concurrent transactions conflict on the sequence counter, hence the
+// sequence numbers become visible in the order they are drawn and a consumer
+// resuming from the last one it has seen never misses a state change.
+func (db *Database) saveStateChange(
+	ctx context.Context, stakingTxHashHex string,
+	fromState, toState types.DelegationState, btcHeight uint64,
+) error {
+	sequence, err := db.nextSequence(ctx, model.DelegationStateChangeSequenceId)
+	if err != nil {
+		return err
+	}
+	client := db.Client.Database(db.DbName).Collection(model.DelegationStateChangeCollection)
+	document := model.DelegationStateChangeDocument{
+		Sequence:         sequence,
+		StakingTxHashHex: stakingTxHashHex,
+		FromState:        fromState,
+		ToState:          toState,
+		Timestamp:        time.Now().Unix(),
+		BtcHeight:        btcHeight,
+	}
+	_, err = client.InsertOne(ctx, document)
+	return err
+}
+
+// nextSequence increments the counter identified by id and returns its new
+// value, the counter starts at 1.
+func (db *Database) nextSequence(ctx context.Context, id string) (int64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.CounterCollection)
+	options := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var counter model.CounterDocument
+	err := client.FindOneAndUpdate(
+		ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"sequence": 1}}, options,
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Sequence, nil
+}
+
+// FindDelegationStateChanges fetches the state changes across all delegations
+// in the order they were recorded.
+// Unlike the other paginated queries, a pagination token is always returned
+// so that the consumers can keep polling for the state changes recorded after
+// the last one they have seen. The given token is returned as is if there is
+// no state change after it yet.
+func (db *Database) FindDelegationStateChanges(
+	ctx context.Context, paginationToken string,
+) (*DbResultMap[model.DelegationStateChangeDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationStateChangeCollection)
+
+	filter := bson.M{}
+	options := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	options.SetLimit(db.cfg.MaxPaginationLimit)
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.DelegationStateChangePagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter = bson.M{"sequence": bson.M{"$gt": decodedToken.Sequence}}
+	}
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stateChanges []model.DelegationStateChangeDocument
+	if err = cursor.All(ctx, &stateChanges); err != nil {
+		return nil, err
+	}
+	if len(stateChanges) == 0 {
+		return &DbResultMap[model.DelegationStateChangeDocument]{
+			Data:            stateChanges,
+			PaginationToken: paginationToken,
+		}, nil
+	}
+
+	nextPaginationToken, err := model.BuildDelegationStateChangePaginationToken(
+		db.paginationTokenSecret, stateChanges[len(stateChanges)-1],
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DbResultMap[model.DelegationStateChangeDocument]{
+		Data:            stateChanges,
+		PaginationToken: nextPaginationToken,
+	}, nil
+}
+
+// FindDelegationStateChangesByTxHashHex fetches the state changes of a single
+// delegation in the order they were recorded. A delegation only goes through a
+// handful of transitions, hence the result is not paginated.
+func (db *Database) FindDelegationStateChangesByTxHashHex(
+	ctx context.Context, stakingTxHashHex string,
+) ([]model.DelegationStateChangeDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationStateChangeCollection)
+
+	filter := bson.M{"staking_tx_hash_hex": stakingTxHashHex}
+	options := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stateChanges := []model.DelegationStateChangeDocument{}
+	if err = cursor.All(ctx, &stateChanges); err != nil {
+		return nil, err
+	}
+	return stateChanges, nil
+}
@@ -14,13 +14,34 @@ type DBClient interface {
 		stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
 		startTimestamp int64, isOverflow bool, stakerTaprootAddress string,
 	) error
+	// FindDelegationsByStakerPk fetches a page of at most limit delegations of
+	// the staker. A limit of 0 falls back to the configured page size. Only
+	// the given fields are fetched if not nil.
 	FindDelegationsByStakerPk(
-		ctx context.Context, stakerPk string, paginationToken string,
+		ctx context.Context, stakerPk string,
+		extraFilter *DelegationFilter, sort *DelegationSort, paginationToken string, limit int64,
+		fields []string,
+	) (*DbResultMap[model.DelegationDocument], error)
+	// FindDelegationsByStakerPks fetches the first page of at most limit
+	// delegations of each of the stakers, keyed by staker pk hex. A limit of 0
+	// falls back to the configured page size.
+	FindDelegationsByStakerPks(
+		ctx context.Context, stakerPks []string, extraFilter *DelegationFilter, limit int64,
+	) (map[string]*DbResultMap[model.DelegationDocument], error)
+	// FindDelegationsByFinalityProviderPk fetches a page of at most limit delegations
+	// to the finality provider. A limit of 0 falls back to the configured page size.
+	FindDelegationsByFinalityProviderPk(
+		ctx context.Context, fpPkHex string,
+		extraFilter *DelegationFilter, paginationToken string, limit int64,
 	) (*DbResultMap[model.DelegationDocument], error)
 	SaveUnbondingTx(
 		ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex string,
 	) error
 	FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*model.DelegationDocument, error)
+	// FindDelegationsByTxHashHexes fetches the delegations identified by the
+	// staking tx hashes, leaving out the hashes without a matching delegation.
+	FindDelegationsByTxHashHexes(ctx context.Context, txHashHexes []string) ([]model.DelegationDocument, error)
+	FindStakerPkByTaprootAddress(ctx context.Context, address string) (string, error)
 	SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string) error
 	SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error
 	TransitionToUnbondedState(
@@ -46,7 +67,18 @@ type DBClient interface {
 	SubtractFinalityProviderStats(
 		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
 	) error
-	FindFinalityProviderStats(ctx context.Context, paginationToken string) (*DbResultMap[*model.FinalityProviderStatsDocument], error)
+	FindFinalityProviderStats(
+		ctx context.Context, paginationToken string, finalityProviderPkHex []string,
+	) (*DbResultMap[*model.FinalityProviderStatsDocument], error)
+	FindFinalityProvidersByActiveStakerCount(
+		ctx context.Context, paginationToken string, finalityProviderPkHex []string,
+	) (*DbResultMap[*model.FinalityProviderStakerCountDocument], error)
+	FindFinalityProvidersBySelfStake(
+		ctx context.Context, minSelfStake int64, paginationToken string, finalityProviderPkHex []string,
+	) (*DbResultMap[*model.FinalityProviderSelfStakeDocument], error)
+	FindFinalityProviderSelfStakeByFinalityProviderPkHex(
+		ctx context.Context, finalityProviderPkHex []string,
+	) ([]*model.FinalityProviderSelfStakeDocument, error)
 	FindFinalityProviderStatsByFinalityProviderPkHex(
 		ctx context.Context, finalityProviderPkHex []string,
 	) ([]*model.FinalityProviderStatsDocument, error)
@@ -56,17 +88,126 @@ type DBClient interface {
 	SubtractStakerStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
-	FindTopStakersByTvl(ctx context.Context, paginationToken string) (*DbResultMap[*model.StakerStatsDocument], error)
+	// FindTopStakersByTvl fetches a page of at most limit stakers in descending
+	// order of active tvl. A limit of 0 falls back to the configured page size.
+	FindTopStakersByTvl(
+		ctx context.Context, paginationToken string, limit int64,
+	) (*DbResultMap[*model.StakerStatsDocument], error)
+	FindStakerStatsByStakerPk(ctx context.Context, stakerPkHex string) (*model.StakerStatsDocument, error)
+	CountStakersRankedAboveByActiveTvl(ctx context.Context, stakerStats *model.StakerStatsDocument) (int64, error)
+	AggregateStakerFinalityProviderStats(
+		ctx context.Context, stakerPkHex, fpPkHex string,
+	) (*model.StakerFinalityProviderStats, error)
+	AggregateFinalityProviderStakeStats(
+		ctx context.Context, fpPkHex string, extraFilter *DelegationFilter,
+	) (*model.FinalityProviderStakeStats, error)
 	UpsertLatestBtcInfo(
 		ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 	) error
 	GetLatestBtcInfo(ctx context.Context) (*model.BtcInfo, error)
+	// FindLatestDelegationStakingHeight returns the highest staking tx height
+	// of the stored delegations, or 0 if there is none.
+	FindLatestDelegationStakingHeight(ctx context.Context) (uint64, error)
 	CheckDelegationExistByStakerTaprootAddress(
 		ctx context.Context, address string, extraFilter *DelegationFilter,
 	) (bool, error)
+	FindUnbondingDelegations(
+		ctx context.Context, extraFilter *DelegationFilter, paginationToken string,
+	) (*DbResultMap[model.DelegationDocument], error)
+	FindUnbondingDelegationsByExpireHeight(
+		ctx context.Context, extraFilter *DelegationFilter, paginationToken string,
+	) (*DbResultMap[model.DelegationDocument], error)
+	FindStakerUnbondingRequests(
+		ctx context.Context, stakerPkHex string, states []types.DelegationState, paginationToken string,
+	) (*DbResultMap[model.StakerUnbondingRequestDocument], error)
+	FindDelegationByStakingOutput(
+		ctx context.Context, stakerPkHex, stakingTxHashHex string, outputIndex uint64,
+	) (*model.DelegationDocument, error)
+	SumDelegationsStakingValue(
+		ctx context.Context, extraFilter *DelegationFilter,
+	) (uint64, error)
+	SumStakingValueBeforeInCapOrder(
+		ctx context.Context, stakingTxHashHex string, startHeight, fromHeight, toHeight uint64,
+	) (uint64, error)
+	CountDelegationsByState(
+		ctx context.Context, extraFilter *DelegationFilter,
+	) ([]model.DelegationStateCount, error)
+	CountDistinctFinalityProviders(
+		ctx context.Context, extraFilter *DelegationFilter,
+	) (int64, error)
+	AggregateDailyUnbondingStats(
+		ctx context.Context, fromTimestamp, toTimestamp int64,
+	) ([]model.DailyUnbondingStats, error)
+	AggregateStakingHistory(
+		ctx context.Context, fromTimestamp, toTimestamp, bucketSeconds int64,
+	) (*model.StakingHistory, error)
+	AggregateStakerDailyActivity(
+		ctx context.Context, stakerPkHex string, fromTimestamp, toTimestamp int64,
+	) ([]model.StakerDailyActivity, error)
+	AggregateOverallStatsByFinalityProviders(
+		ctx context.Context, finalityProviderPkHex []string,
+	) (*model.OverallStatsDocument, error)
+	AggregateStakingValueDistribution(
+		ctx context.Context, finalityProviderPkHex []string,
+	) (*model.StakingValueDistribution, error)
+	AggregateFinalityProviderStatsByMembership(
+		ctx context.Context, memberFinalityProviderPkHex []string,
+	) ([]model.FinalityProviderMembershipStakeAggregate, error)
+	FindFinalityProviderDelegationCounts(
+		ctx context.Context, paginationToken string, extraFilter *DelegationFilter,
+	) (*DbResultMap[*model.FinalityProviderDelegationCountDocument], error)
+	FindDelegationStateChanges(
+		ctx context.Context, paginationToken string,
+	) (*DbResultMap[model.DelegationStateChangeDocument], error)
+	FindDelegationStateChangesByTxHashHex(
+		ctx context.Context, stakingTxHashHex string,
+	) ([]model.DelegationStateChangeDocument, error)
 }
 
 type DelegationFilter struct {
-	AfterTimestamp int64
-	States         []types.DelegationState
+	// Only the delegations of the staker are matched. Not applied if empty.
+	StakerPkHex     string
+	AfterTimestamp  int64
+	States          []types.DelegationState
+	UnbondingType   types.UnbondingType
+	ExcludeOverflow bool
+	// Only the delegations with at least MinConfirmations confirmations at
+	// BtcTipHeight are matched. Not applied if MinConfirmations is 0.
+	MinConfirmations uint64
+	BtcTipHeight     uint64
+	// Only the delegations whose staking value is at most (or above if
+	// AboveStakingAmountThresholds is set) the threshold of the height range
+	// their staking tx belongs to are matched. Delegations outside of all the
+	// height ranges are not matched. Not applied if nil.
+	StakingAmountThresholds      []StakingAmountThreshold
+	AboveStakingAmountThresholds bool
+	// Inclusive bounds of the staking timelock. A bound is not applied if 0.
+	MinStakingTimelock uint64
+	MaxStakingTimelock uint64
+	// Only the delegations to the finality provider are matched. Not applied if empty.
+	FinalityProviderPkHex string
+}
+
+// DelegationSortField is a field the delegations can be explicitly sorted by
+type DelegationSortField string
+
+const (
+	SortByStakingValue       DelegationSortField = "staking_value"
+	SortByStakingStartHeight DelegationSortField = "staking_tx.start_height"
+)
+
+// DelegationSort sorts the delegations by the given field, and then by the
+// staking tx hash in ascending order to keep the order total.
+type DelegationSort struct {
+	Field     DelegationSortField
+	Ascending bool
+}
+
+// StakingAmountThreshold is the staking amount threshold applying to the
+// delegations staked within [FromHeight, ToHeight). A ToHeight of 0 means
+// the range has no upper bound.
+type StakingAmountThreshold struct {
+	FromHeight uint64
+	ToHeight   uint64
+	Amount     uint64
 }
@@ -7,4 +7,7 @@ type BtcInfo struct {
 	BtcHeight      uint64 `bson:"btc_height"`
 	ConfirmedTvl   uint64 `bson:"confirmed_tvl"`
 	UnconfirmedTvl uint64 `bson:"unconfirmed_tvl"`
+	// Unix timestamp of the last update to a greater height, 0 if it
+	// predates the field
+	UpdatedAt int64 `bson:"updated_at"`
 }
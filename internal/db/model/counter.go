@@ -0,0 +1,8 @@
+package model
+
+// CounterDocument holds the last value drawn from a sequence, the id names the
+// sequence
+type CounterDocument struct {
+	Id       string `bson:"_id"`
+	Sequence int64  `bson:"sequence"`
+}
@@ -27,6 +27,15 @@ type DelegationDocument struct {
 	UnbondingTx           *TimelockTransaction  `bson:"unbonding_tx,omitempty"`
 	IsOverflow            bool                  `bson:"is_overflow"`
 	StakerBtcAddress      *StakerBtcAddress     `bson:"staker_btc_address,omitempty"`
+	// Unix timestamp of the last write to the delegation, missing on the
+	// delegations not updated since it's been tracked
+	UpdatedAt int64 `bson:"updated_at,omitempty"`
+}
+
+// DelegationStateCount is the number of delegations in a given state
+type DelegationStateCount struct {
+	State types.DelegationState `bson:"_id"`
+	Count int64                 `bson:"count"`
 }
 
 type DelegationByStakerPagination struct {
@@ -34,12 +43,96 @@ type DelegationByStakerPagination struct {
 	StakingStartHeight uint64 `json:"staking_start_height"`
 }
 
-func BuildDelegationByStakerPaginationToken(d DelegationDocument) (string, error) {
+func BuildDelegationByStakerPaginationToken(secret []byte, d DelegationDocument) (string, error) {
 	page := &DelegationByStakerPagination{
 		StakingTxHashHex:   d.StakingTxHashHex,
 		StakingStartHeight: d.StakingTx.StartHeight,
 	}
-	token, err := GetPaginationToken(page)
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// DelegationByFinalityProviderPagination is used to paginate the delegations to a finality provider
+// The staking start height is used as the sorting key, whereas StakingTxHashHex is used as the secondary sorting key
+type DelegationByFinalityProviderPagination struct {
+	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	StakingStartHeight uint64 `json:"staking_start_height"`
+}
+
+func BuildDelegationByFinalityProviderPaginationToken(secret []byte, d DelegationDocument) (string, error) {
+	page := &DelegationByFinalityProviderPagination{
+		StakingTxHashHex:   d.StakingTxHashHex,
+		StakingStartHeight: d.StakingTx.StartHeight,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// DelegationByStakerSortedPagination is used to paginate the delegations of a staker
+// explicitly sorted by SortField, StakingTxHashHex being the secondary sorting key.
+// The sort is kept in the token so that a page cannot be resumed with another sort.
+type DelegationByStakerSortedPagination struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	SortField        string `json:"sort_field"`
+	SortValue        uint64 `json:"sort_value"`
+	Ascending        bool   `json:"ascending"`
+}
+
+func BuildDelegationByStakerSortedPaginationToken(
+	secret []byte, d DelegationDocument, sortField string, sortValue uint64, ascending bool,
+) (string, error) {
+	page := &DelegationByStakerSortedPagination{
+		StakingTxHashHex: d.StakingTxHashHex,
+		SortField:        sortField,
+		SortValue:        sortValue,
+		Ascending:        ascending,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// UnbondingDelegationPagination is used to paginate the delegations in the unbonding state
+// The unbonding start height is used as the sorting key, whereas StakingTxHashHex is used as the secondary sorting key
+type UnbondingDelegationPagination struct {
+	StakingTxHashHex     string `json:"staking_tx_hash_hex"`
+	UnbondingStartHeight uint64 `json:"unbonding_start_height"`
+}
+
+func BuildUnbondingDelegationPaginationToken(secret []byte, d DelegationDocument) (string, error) {
+	page := &UnbondingDelegationPagination{
+		StakingTxHashHex:     d.StakingTxHashHex,
+		UnbondingStartHeight: d.UnbondingTx.StartHeight,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// UnbondingDelegationByExpirePagination is used to paginate the delegations in the unbonding state
+// by the height their unbonding timelock expires at, i.e the unbonding start height plus the timelock.
+// StakingTxHashHex is used as the secondary sorting key
+type UnbondingDelegationByExpirePagination struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	UnbondingExpireHeight uint64 `json:"unbonding_expire_height"`
+}
+
+func BuildUnbondingDelegationByExpirePaginationToken(secret []byte, d DelegationDocument) (string, error) {
+	page := &UnbondingDelegationByExpirePagination{
+		StakingTxHashHex:      d.StakingTxHashHex,
+		UnbondingExpireHeight: d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock,
+	}
+	token, err := GetPaginationToken(secret, page)
 	if err != nil {
 		return "", err
 	}
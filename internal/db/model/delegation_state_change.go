@@ -0,0 +1,42 @@
+package model
+
+import (
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DelegationStateChangeSequenceId is the id of the counter that the sequence
+// numbers of the state changes are drawn from
+const DelegationStateChangeSequenceId = "delegation_state_changes"
+
+// DelegationStateChangeDocument records a single state transition of a delegation.
+// FromState is empty when the delegation is created.
+// Only the transitions made since the state changes are recorded are present,
+// the delegations created before have no state change at all.
+type DelegationStateChangeDocument struct {
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// Strictly increasing in the order the state changes are committed
+	Sequence         int64                 `bson:"sequence"`
+	StakingTxHashHex string                `bson:"staking_tx_hash_hex"`
+	FromState        types.DelegationState `bson:"from_state,omitempty"`
+	ToState          types.DelegationState `bson:"to_state"`
+	// Time the transition is recorded at
+	Timestamp int64  `bson:"timestamp"`
+	BtcHeight uint64 `bson:"btc_height,omitempty"`
+}
+
+// DelegationStateChangePagination is used to paginate the state changes feed
+type DelegationStateChangePagination struct {
+	Sequence int64 `json:"sequence"`
+}
+
+func BuildDelegationStateChangePaginationToken(secret []byte, d DelegationStateChangeDocument) (string, error) {
+	page := &DelegationStateChangePagination{
+		Sequence: d.Sequence,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
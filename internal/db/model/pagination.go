@@ -1,12 +1,13 @@
 package model
 
 import (
-	"encoding/base64"
 	"encoding/json"
+
+	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
-func DecodePaginationToken[T any](token string) (*T, error) {
-	tokenBytes, err := base64.URLEncoding.DecodeString(token)
+func DecodePaginationToken[T any](secret []byte, token string) (*T, error) {
+	tokenBytes, err := utils.DecodePaginationToken(secret, token)
 	if err != nil {
 		return nil, err
 	}
@@ -18,10 +19,10 @@ func DecodePaginationToken[T any](token string) (*T, error) {
 	return &d, nil
 }
 
-func GetPaginationToken[PaginationType any](d PaginationType) (string, error) {
+func GetPaginationToken[PaginationType any](secret []byte, d PaginationType) (string, error) {
 	tokenBytes, err := json.Marshal(d)
 	if err != nil {
 		return "", err
 	}
-	return base64.URLEncoding.EncodeToString(tokenBytes), nil
+	return utils.EncodePaginationToken(secret, tokenBytes), nil
 }
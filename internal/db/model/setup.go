@@ -23,26 +23,43 @@ const (
 	UnbondingCollection             = "unbonding_queue"
 	BtcInfoCollection               = "btc_info"
 	UnprocessableMsgCollection      = "unprocessable_messages"
+	DelegationStateChangeCollection = "delegation_state_changes"
+	CounterCollection               = "counters"
 )
 
+// index is a (compound) index, its keys are ordered as the order of the
+// fields of a compound index matters
 type index struct {
-	Indexes map[string]int
+	Indexes bson.D
 	Unique  bool
 }
 
 var collections = map[string][]index{
-	StatsLockCollection:             {{Indexes: map[string]int{}}},
-	OverallStatsCollection:          {{Indexes: map[string]int{}}},
-	FinalityProviderStatsCollection: {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
-	StakerStatsCollection:           {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
+	StatsLockCollection:             {{Indexes: bson.D{}}},
+	OverallStatsCollection:          {{Indexes: bson.D{}}},
+	FinalityProviderStatsCollection: {{Indexes: bson.D{{Key: "active_tvl", Value: -1}}, Unique: false}},
+	StakerStatsCollection:           {{Indexes: bson.D{{Key: "active_tvl", Value: -1}}, Unique: false}},
 	DelegationCollection: {
-		{Indexes: map[string]int{"staker_pk_hex": 1, "staking_tx.start_height": -1}, Unique: false},
-		{Indexes: map[string]int{"staker_btc_address.taproot_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
+		{Indexes: bson.D{{Key: "staker_pk_hex", Value: 1}, {Key: "staking_tx.start_height", Value: -1}}, Unique: false},
+		{Indexes: bson.D{{Key: "staker_pk_hex", Value: 1}, {Key: "staking_value", Value: -1}}, Unique: false},
+		{Indexes: bson.D{{Key: "finality_provider_pk_hex", Value: 1}, {Key: "staking_tx.start_height", Value: -1}}, Unique: false},
+		{Indexes: bson.D{{Key: "staker_btc_address.taproot_address", Value: 1}, {Key: "staking_tx.start_timestamp", Value: -1}}, Unique: false},
+		{Indexes: bson.D{{Key: "state", Value: 1}, {Key: "unbonding_tx.start_height", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "staking_tx.start_height", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "unbonding_tx.start_timestamp", Value: 1}}, Unique: false},
 	},
-	TimeLockCollection:         {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
-	UnbondingCollection:        {{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true}},
-	UnprocessableMsgCollection: {{Indexes: map[string]int{}}},
-	BtcInfoCollection:          {{Indexes: map[string]int{}}},
+	TimeLockCollection: {{Indexes: bson.D{{Key: "expire_height", Value: 1}}, Unique: false}},
+	UnbondingCollection: {
+		{Indexes: bson.D{{Key: "unbonding_tx_hash_hex", Value: 1}}, Unique: true},
+		{Indexes: bson.D{{Key: "staker_pk_hex", Value: 1}}, Unique: false},
+	},
+	UnprocessableMsgCollection: {{Indexes: bson.D{}}},
+	BtcInfoCollection:          {{Indexes: bson.D{}}},
+	DelegationStateChangeCollection: {
+		{Indexes: bson.D{{Key: "sequence", Value: 1}}, Unique: true},
+		{Indexes: bson.D{{Key: "staking_tx_hash_hex", Value: 1}, {Key: "sequence", Value: 1}}, Unique: false},
+	},
+	CounterCollection: {{Indexes: bson.D{}}},
 }
 
 func Setup(ctx context.Context, cfg *config.Config) error {
@@ -95,13 +112,8 @@ func createIndex(ctx context.Context, database *mongo.Database, collectionName s
 		return
 	}
 
-	indexKeys := bson.D{}
-	for k, v := range idx.Indexes {
-		indexKeys = append(indexKeys, bson.E{Key: k, Value: v})
-	}
-
 	index := mongo.IndexModel{
-		Keys:    indexKeys,
+		Keys:    idx.Indexes,
 		Options: options.Index().SetUnique(idx.Unique),
 	}
 
@@ -31,6 +31,14 @@ type OverallStatsDocument struct {
 	TotalStakers      uint64 `bson:"total_stakers"`
 }
 
+// StakingValueDistribution describes how the staking value is distributed
+// over a set of delegations
+type StakingValueDistribution struct {
+	Median  float64 `bson:"median"`
+	P90     float64 `bson:"p90"`
+	Average float64 `bson:"average"`
+}
+
 type FinalityProviderStatsDocument struct {
 	FinalityProviderPkHex string `bson:"_id"` // FinalityProviderPkHex
 	ActiveTvl             int64  `bson:"active_tvl"`
@@ -39,23 +47,163 @@ type FinalityProviderStatsDocument struct {
 	TotalDelegations      int64  `bson:"total_delegations"`
 }
 
+// FinalityProviderStakeAggregate is the sum of the stats of a group of finality providers
+type FinalityProviderStakeAggregate struct {
+	ActiveTvl         int64 `bson:"active_tvl"`
+	ActiveDelegations int64 `bson:"active_delegations"`
+	FinalityProviders int64 `bson:"finality_providers"`
+}
+
+// FinalityProviderMembershipStakeAggregate is the sum of the stats of the finality
+// providers that are either all members or all non-members of a given set
+type FinalityProviderMembershipStakeAggregate struct {
+	IsMember                       bool `bson:"_id"`
+	FinalityProviderStakeAggregate `bson:",inline"`
+}
+
 type FinalityProviderStatsPagination struct {
 	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
 	ActiveTvl             int64  `json:"active_tvl"`
 }
 
-func BuildFinalityProviderStatsPaginationToken(d *FinalityProviderStatsDocument) (string, error) {
+func BuildFinalityProviderStatsPaginationToken(secret []byte, d *FinalityProviderStatsDocument) (string, error) {
 	page := FinalityProviderStatsPagination{
 		ActiveTvl:             d.ActiveTvl,
 		FinalityProviderPkHex: d.FinalityProviderPkHex,
 	}
-	token, err := GetPaginationToken(page)
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// FinalityProviderStakerCountDocument is the number of distinct stakers having
+// at least one active delegation to the finality provider
+type FinalityProviderStakerCountDocument struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	ActiveStakerCount     int64  `bson:"active_staker_count"`
+}
+
+// FinalityProviderStakerCountPagination is used to paginate the finality providers by active staker count
+// ActiveStakerCount is used as the sorting key, whereas FinalityProviderPkHex is used as the secondary sorting key
+type FinalityProviderStakerCountPagination struct {
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	ActiveStakerCount     int64  `json:"active_staker_count"`
+}
+
+func BuildFinalityProviderStakerCountPaginationToken(secret []byte, d *FinalityProviderStakerCountDocument) (string, error) {
+	page := FinalityProviderStakerCountPagination{
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		ActiveStakerCount:     d.ActiveStakerCount,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// FinalityProviderDelegationCountDocument is the number of active delegations,
+// including those whose unbonding is requested, to the finality provider along
+// with the number of distinct stakers behind them
+type FinalityProviderDelegationCountDocument struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	ActiveDelegations     int64  `bson:"active_delegations"`
+	ActiveStakerCount     int64  `bson:"active_staker_count"`
+}
+
+// FinalityProviderDelegationCountPagination is used to paginate the delegation
+// counts of the finality providers, sorted by FinalityProviderPkHex
+type FinalityProviderDelegationCountPagination struct {
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+}
+
+func BuildFinalityProviderDelegationCountPaginationToken(secret []byte, d *FinalityProviderDelegationCountDocument) (string, error) {
+	page := FinalityProviderDelegationCountPagination{
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+	}
+	token, err := GetPaginationToken(secret, page)
 	if err != nil {
 		return "", err
 	}
 	return token, nil
 }
 
+// FinalityProviderSelfStakeDocument is the total active stake the finality
+// provider delegated to itself, i.e with its own pk as the staker pk
+type FinalityProviderSelfStakeDocument struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	SelfStake             int64  `bson:"self_stake"`
+}
+
+// FinalityProviderSelfStakePagination is used to paginate the finality providers by self stake
+// SelfStake is used as the sorting key, whereas FinalityProviderPkHex is used as the secondary sorting key
+type FinalityProviderSelfStakePagination struct {
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	SelfStake             int64  `json:"self_stake"`
+}
+
+func BuildFinalityProviderSelfStakePaginationToken(secret []byte, d *FinalityProviderSelfStakeDocument) (string, error) {
+	page := FinalityProviderSelfStakePagination{
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		SelfStake:             d.SelfStake,
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// FinalityProviderStakeStats is the total staking value and the number of
+// distinct stakers of a set of delegations to a finality provider
+type FinalityProviderStakeStats struct {
+	TotalStake  int64 `bson:"total_stake"`
+	StakerCount int64 `bson:"staker_count"`
+}
+
+// StakerFinalityProviderStats is the stats of the delegations of a staker to a finality provider
+type StakerFinalityProviderStats struct {
+	ActiveTvl         int64 `bson:"active_tvl"`
+	TotalTvl          int64 `bson:"total_tvl"`
+	ActiveDelegations int64 `bson:"active_delegations"`
+	TotalDelegations  int64 `bson:"total_delegations"`
+}
+
+// DailyUnbondingStats is the number and the total staking value of the
+// delegations which started unbonding on the day, formatted as YYYY-MM-DD in UTC
+type DailyUnbondingStats struct {
+	Date              string `bson:"_id"`
+	UnbondingTvl      int64  `bson:"unbonding_tvl"`
+	UnbondingRequests int64  `bson:"unbonding_requests"`
+}
+
+// StakingHistoryBucket is the number and the total staking value of the
+// delegations which started staking within the bucket, keyed by the unix
+// timestamp of the bucket start
+type StakingHistoryBucket struct {
+	BucketStart  int64 `bson:"_id"`
+	StakingValue int64 `bson:"staking_value"`
+	Delegations  int64 `bson:"delegations"`
+}
+
+// StakingHistory is the time bucketed staking activity within a range, along
+// with the totals of the delegations which started staking before the range
+type StakingHistory struct {
+	StakingValueBefore int64
+	DelegationsBefore  int64
+	Buckets            []StakingHistoryBucket
+}
+
+// StakerDailyActivity is the number and the total staking value of the
+// delegations a staker created on the day, formatted as YYYY-MM-DD in UTC
+type StakerDailyActivity struct {
+	Date         string `bson:"_id"`
+	StakingValue int64  `bson:"staking_value"`
+	Delegations  int64  `bson:"delegations"`
+}
+
 type StakerStatsDocument struct {
 	StakerPkHex       string `bson:"_id"`
 	ActiveTvl         int64  `bson:"active_tvl"`
@@ -71,12 +219,12 @@ type StakerStatsByStakerPagination struct {
 	ActiveTvl   int64  `json:"active_tvl"`
 }
 
-func BuildStakerStatsByStakerPaginationToken(d *StakerStatsDocument) (string, error) {
+func BuildStakerStatsByStakerPaginationToken(secret []byte, d *StakerStatsDocument) (string, error) {
 	page := StakerStatsByStakerPagination{
 		StakerPkHex: d.StakerPkHex,
 		ActiveTvl:   d.ActiveTvl,
 	}
-	token, err := GetPaginationToken(page)
+	token, err := GetPaginationToken(secret, page)
 	if err != nil {
 		return "", err
 	}
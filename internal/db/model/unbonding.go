@@ -1,5 +1,10 @@
 package model
 
+import (
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
 const (
 	UnbondingInitialState = "INSERTED"
 )
@@ -17,3 +22,32 @@ type UnbondingDocument struct {
 	StakingAmount      uint64 `bson:"staking_amount"`
 	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
 }
+
+// StakerUnbondingRequestDocument is an unbonding request of a staker along with
+// the current state of the delegation it unbonds. The request time is carried
+// by the id of the unbonding document.
+type StakerUnbondingRequestDocument struct {
+	Id                      primitive.ObjectID    `bson:"_id"`
+	UnbondingTxHashHex      string                `bson:"unbonding_tx_hash_hex"`
+	StakingTxHashHex        string                `bson:"staking_tx_hash_hex"`
+	StakingAmount           uint64                `bson:"staking_amount"`
+	State                   types.DelegationState `bson:"state"`
+	UnbondingStartTimestamp int64                 `bson:"unbonding_start_timestamp,omitempty"`
+}
+
+// StakerUnbondingRequestPagination is used to paginate the unbonding requests
+// of a staker, which are sorted by the id of the unbonding document
+type StakerUnbondingRequestPagination struct {
+	Id string `json:"id"`
+}
+
+func BuildStakerUnbondingRequestPaginationToken(secret []byte, d StakerUnbondingRequestDocument) (string, error) {
+	page := &StakerUnbondingRequestPagination{
+		Id: d.Id.Hex(),
+	}
+	token, err := GetPaginationToken(secret, page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
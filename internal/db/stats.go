@@ -185,6 +185,116 @@ func (db *Database) GetOverallStats(ctx context.Context) (*model.OverallStatsDoc
 	return &result, nil
 }
 
+// AggregateOverallStatsByFinalityProviders computes the overall stats as if only
+// the given finality providers existed. The tvl and delegation counts are summed
+// up from the finality provider stats, whereas the total stakers is the number of
+// distinct stakers with non-overflow delegations to them, in line with the stats.
+func (db *Database) AggregateOverallStatsByFinalityProviders(
+	ctx context.Context, finalityProviderPkHex []string,
+) (*model.OverallStatsDocument, error) {
+	fpStatsClient := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsCollection)
+	statsPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": finalityProviderPkHex}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                nil,
+			"active_tvl":         bson.M{"$sum": "$active_tvl"},
+			"total_tvl":          bson.M{"$sum": "$total_tvl"},
+			"active_delegations": bson.M{"$sum": "$active_delegations"},
+			"total_delegations":  bson.M{"$sum": "$total_delegations"},
+		}}},
+	}
+	cursor, err := fpStatsClient.Aggregate(ctx, statsPipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var stats []model.OverallStatsDocument
+	if err = cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	var result model.OverallStatsDocument
+	if len(stats) > 0 {
+		result = stats[0]
+	}
+
+	delegationClient := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	stakersPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"finality_provider_pk_hex": bson.M{"$in": finalityProviderPkHex},
+			"is_overflow":              false,
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$staker_pk_hex"}}},
+		{{Key: "$count", Value: "total_stakers"}},
+	}
+	stakersCursor, err := delegationClient.Aggregate(ctx, stakersPipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer stakersCursor.Close(ctx)
+	var stakers []struct {
+		TotalStakers uint64 `bson:"total_stakers"`
+	}
+	if err = stakersCursor.All(ctx, &stakers); err != nil {
+		return nil, err
+	}
+	if len(stakers) > 0 {
+		result.TotalStakers = stakers[0].TotalStakers
+	}
+
+	return &result, nil
+}
+
+// AggregateStakingValueDistribution computes the median, the 90th percentile and
+// the average staking value of the active non-overflow delegations, in line with
+// the stats. Only the delegations to the given finality providers are accounted
+// for, unless finalityProviderPkHex is nil. The percentiles are approximated by
+// mongo, which requires MongoDB 7.0 or later.
+func (db *Database) AggregateStakingValueDistribution(
+	ctx context.Context, finalityProviderPkHex []string,
+) (*model.StakingValueDistribution, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := bson.M{
+		"state":       types.Active,
+		"is_overflow": false,
+	}
+	if finalityProviderPkHex != nil {
+		filter["finality_provider_pk_hex"] = bson.M{"$in": finalityProviderPkHex}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id": nil,
+			"median": bson.M{"$median": bson.M{
+				"input": "$staking_value", "method": "approximate",
+			}},
+			"p90": bson.M{"$percentile": bson.M{
+				"input": "$staking_value", "p": bson.A{0.9}, "method": "approximate",
+			}},
+			"average": bson.M{"$avg": "$staking_value"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"median":  1,
+			"p90":     bson.M{"$arrayElemAt": bson.A{"$p90", 0}},
+			"average": 1,
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.StakingValueDistribution
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	// No active delegation
+	if len(results) == 0 {
+		return &model.StakingValueDistribution{}, nil
+	}
+	return &results[0], nil
+}
+
 // Generate the id for the overall stats document. Id is a random number ranged from 0-LogicalShardCount-1
 // It's a logical shard to avoid locking the same field during concurrent writes
 // The sharding number should never be reduced after roll out
@@ -245,26 +355,30 @@ func (db *Database) SubtractFinalityProviderStats(
 	return db.updateFinalityProviderStats(ctx, types.Unbonded.ToString(), stakingTxHashHex, fpPkHex, upsertUpdate)
 }
 
-// FindFinalityProviderStats fetches the finality provider stats from the database
-func (db *Database) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*DbResultMap[*model.FinalityProviderStatsDocument], error) {
+// FindFinalityProviderStats fetches the finality provider stats from the database.
+// If finalityProviderPkHex is not nil, only the given finality providers are fetched.
+func (db *Database) FindFinalityProviderStats(
+	ctx context.Context, paginationToken string, finalityProviderPkHex []string,
+) (*DbResultMap[*model.FinalityProviderStatsDocument], error) {
 	client := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsCollection)
 	options := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}}) // Sorting in descending order
 	options.SetLimit(db.cfg.MaxPaginationLimit)
-	var filter bson.M
+	filter := bson.M{}
+	if finalityProviderPkHex != nil {
+		filter["_id"] = bson.M{"$in": finalityProviderPkHex}
+	}
 
 	// Decode the pagination token first if it exist
 	if paginationToken != "" {
-		decodedToken, err := model.DecodePaginationToken[model.FinalityProviderStatsPagination](paginationToken)
+		decodedToken, err := model.DecodePaginationToken[model.FinalityProviderStatsPagination](db.paginationTokenSecret, paginationToken)
 		if err != nil {
 			return nil, &InvalidPaginationTokenError{
 				Message: "Invalid pagination token",
 			}
 		}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"active_tvl": bson.M{"$lt": decodedToken.ActiveTvl}},
-				{"active_tvl": decodedToken.ActiveTvl, "_id": bson.M{"$lt": decodedToken.FinalityProviderPkHex}},
-			},
+		filter["$or"] = []bson.M{
+			{"active_tvl": bson.M{"$lt": decodedToken.ActiveTvl}},
+			{"active_tvl": decodedToken.ActiveTvl, "_id": bson.M{"$lt": decodedToken.FinalityProviderPkHex}},
 		}
 	}
 
@@ -301,6 +415,261 @@ func (db *Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	return finalityProviders, nil
 }
 
+// activeStakeDelegationStates are the states of the delegations accounted for in
+// the active stake, which is only released once the unbonding tx is confirmed.
+var activeStakeDelegationStates = []types.DelegationState{types.Active, types.UnbondingRequested}
+
+// FindFinalityProvidersByActiveStakerCount fetches the finality providers sorted by
+// the number of distinct stakers with active delegations to them in descending order.
+// As for the active stake, the delegations which requested unbonding are still active.
+// The finality provider pk hex is used as the tie-breaker so that the pagination is stable.
+// Finality providers without any active delegation are not part of the result.
+// If finalityProviderPkHex is not nil, only the given finality providers are fetched.
+func (db *Database) FindFinalityProvidersByActiveStakerCount(
+	ctx context.Context, paginationToken string, finalityProviderPkHex []string,
+) (*DbResultMap[*model.FinalityProviderStakerCountDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	match := bson.M{"state": bson.M{"$in": activeStakeDelegationStates}}
+	if finalityProviderPkHex != nil {
+		match["finality_provider_pk_hex"] = bson.M{"$in": finalityProviderPkHex}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		// Deduplicate the stakers having multiple delegations to the same finality provider
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"fp": "$finality_provider_pk_hex", "staker": "$staker_pk_hex"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                 "$_id.fp",
+			"active_staker_count": bson.M{"$sum": 1},
+		}}},
+	}
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.FinalityProviderStakerCountPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"$or": []bson.M{
+				{"active_staker_count": bson.M{"$lt": decodedToken.ActiveStakerCount}},
+				{"active_staker_count": decodedToken.ActiveStakerCount, "_id": bson.M{"$gt": decodedToken.FinalityProviderPkHex}},
+			},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{
+			{Key: "active_staker_count", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+		bson.D{{Key: "$limit", Value: db.cfg.MaxPaginationLimit}},
+	)
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var finalityProviders []*model.FinalityProviderStakerCountDocument
+	if err = cursor.All(ctx, &finalityProviders); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, finalityProviders, model.BuildFinalityProviderStakerCountPaginationToken)
+}
+
+// FindFinalityProviderDelegationCounts counts per finality provider the active
+// delegations and the distinct stakers behind them, with a single grouped
+// aggregation. The active delegations are those accounted for in the active
+// stake, as in FindFinalityProvidersByActiveStakerCount. The result is sorted
+// by the finality provider pk hex. Finality providers without any active
+// delegation are not part of the result. The extra filter, if any, further
+// restricts the delegations accounted for.
+func (db *Database) FindFinalityProviderDelegationCounts(
+	ctx context.Context, paginationToken string, extraFilter *DelegationFilter,
+) (*DbResultMap[*model.FinalityProviderDelegationCountDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	// The conditions of the counts are and-ed with the extra filter, which
+	// could otherwise overwrite them, e.g. with its own states
+	conditions := []bson.M{{"state": bson.M{"$in": activeStakeDelegationStates}}}
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.FinalityProviderDelegationCountPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		conditions = append(conditions, bson.M{
+			"finality_provider_pk_hex": bson.M{"$gt": decodedToken.FinalityProviderPkHex},
+		})
+	}
+	match := buildAdditionalDelegationFilter(bson.M{}, extraFilter)
+	andFilters, _ := match["$and"].([]bson.M)
+	match["$and"] = append(andFilters, conditions...)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		// Deduplicate the stakers having multiple delegations to the same finality provider
+		{{Key: "$group", Value: bson.M{
+			"_id":                bson.M{"fp": "$finality_provider_pk_hex", "staker": "$staker_pk_hex"},
+			"active_delegations": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                 "$_id.fp",
+			"active_delegations":  bson.M{"$sum": "$active_delegations"},
+			"active_staker_count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$limit", Value: db.cfg.MaxPaginationLimit}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegationCounts []*model.FinalityProviderDelegationCountDocument
+	if err = cursor.All(ctx, &delegationCounts); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, delegationCounts, model.BuildFinalityProviderDelegationCountPaginationToken)
+}
+
+// selfStakePipeline returns the aggregation stages computing the self stake of
+// the finality providers, i.e the active stake of the delegations whose staker
+// pk is the finality provider pk. Overflow delegations are not accounted for,
+// in line with the rest of the stats.
+func selfStakePipeline(extraMatch bson.M) mongo.Pipeline {
+	match := bson.M{
+		"state":       types.Active,
+		"is_overflow": false,
+		"$expr":       bson.M{"$eq": bson.A{"$staker_pk_hex", "$finality_provider_pk_hex"}},
+	}
+	for k, v := range extraMatch {
+		match[k] = v
+	}
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$finality_provider_pk_hex",
+			"self_stake": bson.M{"$sum": "$staking_value"},
+		}}},
+	}
+}
+
+// FindFinalityProvidersBySelfStake fetches the finality providers having self stake
+// of at least minSelfStake, sorted by their self stake in descending order.
+// If finalityProviderPkHex is not nil, only the given finality providers are fetched.
+func (db *Database) FindFinalityProvidersBySelfStake(
+	ctx context.Context, minSelfStake int64, paginationToken string, finalityProviderPkHex []string,
+) (*DbResultMap[*model.FinalityProviderSelfStakeDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	var extraMatch bson.M
+	if finalityProviderPkHex != nil {
+		extraMatch = bson.M{"finality_provider_pk_hex": bson.M{"$in": finalityProviderPkHex}}
+	}
+	pipeline := selfStakePipeline(extraMatch)
+	if minSelfStake > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"self_stake": bson.M{"$gte": minSelfStake},
+		}}})
+	}
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.FinalityProviderSelfStakePagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"$or": []bson.M{
+				{"self_stake": bson.M{"$lt": decodedToken.SelfStake}},
+				{"self_stake": decodedToken.SelfStake, "_id": bson.M{"$gt": decodedToken.FinalityProviderPkHex}},
+			},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{
+			{Key: "self_stake", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+		bson.D{{Key: "$limit", Value: db.cfg.MaxPaginationLimit}},
+	)
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var finalityProviders []*model.FinalityProviderSelfStakeDocument
+	if err = cursor.All(ctx, &finalityProviders); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, finalityProviders, model.BuildFinalityProviderSelfStakePaginationToken)
+}
+
+// FindFinalityProviderSelfStakeByFinalityProviderPkHex computes the self stake of
+// the given finality providers. The ones without self stake are omitted.
+func (db *Database) FindFinalityProviderSelfStakeByFinalityProviderPkHex(
+	ctx context.Context, finalityProviderPkHex []string,
+) ([]*model.FinalityProviderSelfStakeDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := selfStakePipeline(bson.M{
+		"finality_provider_pk_hex": bson.M{"$in": finalityProviderPkHex},
+	})
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var selfStakes []*model.FinalityProviderSelfStakeDocument
+	if err = cursor.All(ctx, &selfStakes); err != nil {
+		return nil, err
+	}
+	return selfStakes, nil
+}
+
+// AggregateFinalityProviderStatsByMembership sums up the active stake of the
+// finality providers, split by whether their pk is in the given list.
+// Only the finality providers with active stake are counted, and a group is
+// omitted if none of its finality providers has active stake.
+func (db *Database) AggregateFinalityProviderStatsByMembership(
+	ctx context.Context, memberFinalityProviderPkHex []string,
+) ([]model.FinalityProviderMembershipStakeAggregate, error) {
+	client := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"active_tvl": bson.M{"$gt": 0},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                bson.M{"$in": bson.A{"$_id", memberFinalityProviderPkHex}},
+			"active_tvl":         bson.M{"$sum": "$active_tvl"},
+			"active_delegations": bson.M{"$sum": "$active_delegations"},
+			"finality_providers": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.FinalityProviderMembershipStakeAggregate
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (db *Database) updateFinalityProviderStats(ctx context.Context, state, stakingTxHashHex, fpPkHex string, upsertUpdate primitive.M) error {
 	client := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsCollection)
 
@@ -395,15 +764,100 @@ func (db *Database) updateStakerStats(ctx context.Context, state, stakingTxHashH
 	return txErr
 }
 
-func (db *Database) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*DbResultMap[*model.StakerStatsDocument], error) {
+// AggregateStakerFinalityProviderStats computes the stats of the delegations of the
+// staker to the finality provider. Overflow delegations are not accounted for,
+// in line with the rest of the stats.
+func (db *Database) AggregateStakerFinalityProviderStats(
+	ctx context.Context, stakerPkHex, fpPkHex string,
+) (*model.StakerFinalityProviderStats, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	isActive := bson.M{"$eq": bson.A{"$state", types.Active}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"staker_pk_hex":            stakerPkHex,
+			"finality_provider_pk_hex": fpPkHex,
+			"is_overflow":              false,
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               nil,
+			"total_tvl":         bson.M{"$sum": "$staking_value"},
+			"total_delegations": bson.M{"$sum": 1},
+			"active_tvl": bson.M{"$sum": bson.M{
+				"$cond": bson.A{isActive, "$staking_value", 0},
+			}},
+			"active_delegations": bson.M{"$sum": bson.M{
+				"$cond": bson.A{isActive, 1, 0},
+			}},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.StakerFinalityProviderStats
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	// The staker has no delegation to the finality provider
+	if len(results) == 0 {
+		return &model.StakerFinalityProviderStats{}, nil
+	}
+	return &results[0], nil
+}
+
+// AggregateFinalityProviderStakeStats sums up the staking value and counts the
+// distinct stakers of the delegations to the finality provider matching the
+// given filter.
+func (db *Database) AggregateFinalityProviderStakeStats(
+	ctx context.Context, fpPkHex string, extraFilter *DelegationFilter,
+) (*model.FinalityProviderStakeStats, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := buildAdditionalDelegationFilter(bson.M{"finality_provider_pk_hex": fpPkHex}, extraFilter)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$staker_pk_hex",
+			"total_stake": bson.M{"$sum": "$staking_value"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          nil,
+			"total_stake":  bson.M{"$sum": "$total_stake"},
+			"staker_count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.FinalityProviderStakeStats
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	// No delegation to the finality provider matched the filter
+	if len(results) == 0 {
+		return &model.FinalityProviderStakeStats{}, nil
+	}
+	return &results[0], nil
+}
+
+func (db *Database) FindTopStakersByTvl(
+	ctx context.Context, paginationToken string, limit int64,
+) (*DbResultMap[*model.StakerStatsDocument], error) {
 	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
 
+	if limit <= 0 {
+		limit = db.cfg.MaxPaginationLimit
+	}
 	opts := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}}).
-		SetLimit(db.cfg.MaxPaginationLimit)
+		SetLimit(limit)
 	var filter bson.M
 	// Decode the pagination token first if it exist
 	if paginationToken != "" {
-		decodedToken, err := model.DecodePaginationToken[model.StakerStatsByStakerPagination](paginationToken)
+		decodedToken, err := model.DecodePaginationToken[model.StakerStatsByStakerPagination](db.paginationTokenSecret, paginationToken)
 		if err != nil {
 			return nil, &InvalidPaginationTokenError{
 				Message: "Invalid pagination token",
@@ -428,5 +882,175 @@ func (db *Database) FindTopStakersByTvl(ctx context.Context, paginationToken str
 		return nil, err
 	}
 
-	return toResultMapWithPaginationToken(db.cfg, stakerStats, model.BuildStakerStatsByStakerPaginationToken)
+	return toResultMapWithLimitedPaginationToken(db.paginationTokenSecret, limit, stakerStats, model.BuildStakerStatsByStakerPaginationToken)
+}
+
+// FindStakerStatsByStakerPk fetches the stats of the staker.
+// It returns a NotFoundError if the staker has no stats.
+func (db *Database) FindStakerStatsByStakerPk(
+	ctx context.Context, stakerPkHex string,
+) (*model.StakerStatsDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
+	var stakerStats model.StakerStatsDocument
+	err := client.FindOne(ctx, bson.M{"_id": stakerPkHex}).Decode(&stakerStats)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &NotFoundError{
+				Key:     stakerPkHex,
+				Message: "Staker stats not found",
+			}
+		}
+		return nil, err
+	}
+	return &stakerStats, nil
+}
+
+// CountStakersRankedAboveByActiveTvl counts the stakers preceding the given one
+// in the top stakers order, i.e by active tvl in descending order and then by
+// staker pk in descending order.
+func (db *Database) CountStakersRankedAboveByActiveTvl(
+	ctx context.Context, stakerStats *model.StakerStatsDocument,
+) (int64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
+	filter := bson.M{
+		"$or": []bson.M{
+			{"active_tvl": bson.M{"$gt": stakerStats.ActiveTvl}},
+			{"active_tvl": stakerStats.ActiveTvl, "_id": bson.M{"$gt": stakerStats.StakerPkHex}},
+		},
+	}
+	return client.CountDocuments(ctx, filter)
+}
+
+// AggregateDailyUnbondingStats computes per UTC day the stats of the delegations
+// whose unbonding tx got confirmed within [fromTimestamp, toTimestamp).
+// Days without any unbonding are omitted. The result is sorted by day in ascending order.
+func (db *Database) AggregateDailyUnbondingStats(
+	ctx context.Context, fromTimestamp, toTimestamp int64,
+) ([]model.DailyUnbondingStats, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"unbonding_tx.start_timestamp": bson.M{"$gte": fromTimestamp, "$lt": toTimestamp},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format": "%Y-%m-%d",
+				"date":   bson.M{"$toDate": bson.M{"$multiply": bson.A{"$unbonding_tx.start_timestamp", 1000}}},
+			}},
+			"unbonding_tvl":      bson.M{"$sum": "$staking_value"},
+			"unbonding_requests": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.DailyUnbondingStats
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AggregateStakingHistory groups the non-overflow delegations by the bucket of
+// bucketSeconds their staking timestamp falls in within [fromTimestamp, toTimestamp).
+// Buckets are aligned to fromTimestamp, so a day bucket is a UTC day if it starts
+// at midnight UTC. Buckets without any delegation are omitted. The result is sorted by bucket in ascending
+// order and carries the totals of the delegations which started before fromTimestamp.
+func (db *Database) AggregateStakingHistory(
+	ctx context.Context, fromTimestamp, toTimestamp, bucketSeconds int64,
+) (*model.StakingHistory, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"is_overflow":                false,
+			"staking_tx.start_timestamp": bson.M{"$lt": toTimestamp},
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"before": bson.A{
+				bson.M{"$match": bson.M{"staking_tx.start_timestamp": bson.M{"$lt": fromTimestamp}}},
+				bson.M{"$group": bson.M{
+					"_id":           nil,
+					"staking_value": bson.M{"$sum": "$staking_value"},
+					"delegations":   bson.M{"$sum": 1},
+				}},
+			},
+			"buckets": bson.A{
+				bson.M{"$match": bson.M{"staking_tx.start_timestamp": bson.M{"$gte": fromTimestamp}}},
+				bson.M{"$group": bson.M{
+					"_id": bson.M{"$subtract": bson.A{
+						"$staking_tx.start_timestamp",
+						bson.M{"$mod": bson.A{
+							bson.M{"$subtract": bson.A{"$staking_tx.start_timestamp", fromTimestamp}},
+							bucketSeconds,
+						}},
+					}},
+					"staking_value": bson.M{"$sum": "$staking_value"},
+					"delegations":   bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Before  []model.StakingHistoryBucket `bson:"before"`
+		Buckets []model.StakingHistoryBucket `bson:"buckets"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	history := &model.StakingHistory{}
+	if len(results) == 0 {
+		return history, nil
+	}
+	if len(results[0].Before) > 0 {
+		history.StakingValueBefore = results[0].Before[0].StakingValue
+		history.DelegationsBefore = results[0].Before[0].Delegations
+	}
+	history.Buckets = results[0].Buckets
+	return history, nil
+}
+
+// AggregateStakerDailyActivity groups the delegations of the staker by the UTC
+// day of their staking timestamp within [fromTimestamp, toTimestamp).
+// The days without any delegation are omitted.
+func (db *Database) AggregateStakerDailyActivity(
+	ctx context.Context, stakerPkHex string, fromTimestamp, toTimestamp int64,
+) ([]model.StakerDailyActivity, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"staker_pk_hex":              stakerPkHex,
+			"staking_tx.start_timestamp": bson.M{"$gte": fromTimestamp, "$lt": toTimestamp},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format": "%Y-%m-%d",
+				"date":   bson.M{"$toDate": bson.M{"$multiply": bson.A{"$staking_tx.start_timestamp", 1000}}},
+			}},
+			"staking_value": bson.M{"$sum": "$staking_value"},
+			"delegations":   bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.StakerDailyActivity
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
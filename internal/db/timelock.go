@@ -23,5 +23,5 @@ func (db *Database) SaveTimeLockExpireCheck(
 func (db *Database) TransitionToUnbondedState(
 	ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
 ) error {
-	return db.transitionState(ctx, stakingTxHashHex, types.Unbonded.ToString(), eligiblePreviousState, nil)
+	return db.transitionState(ctx, stakingTxHashHex, types.Unbonded.ToString(), eligiblePreviousState, nil, 0)
 }
@@ -3,12 +3,15 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (db *Database) SaveUnbondingTx(
@@ -43,7 +46,7 @@ func (db *Database) SaveUnbondingTx(
 			return nil, err
 		}
 		// Update the state to UnbondingRequested
-		delegationUpdate := bson.M{"$set": bson.M{"state": types.UnbondingRequested}}
+		delegationUpdate := bson.M{"$set": bson.M{"state": types.UnbondingRequested, "updated_at": time.Now().Unix()}}
 		result, err := delegationClient.UpdateOne(sessCtx, delegationFilter, delegationUpdate)
 		if err != nil {
 			return nil, err
@@ -86,6 +89,11 @@ func (db *Database) SaveUnbondingTx(
 			return nil, err
 		}
 
+		err = db.saveStateChange(sessCtx, stakingTxHashHex, types.Active, types.UnbondingRequested, 0)
+		if err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	}
 
@@ -114,10 +122,175 @@ func (db *Database) TransitionToUnbondingState(
 
 	err := db.transitionState(
 		ctx, txHashHex, types.Unbonding.ToString(),
-		utils.QualifiedStatesToUnbonding(), unbondingTxMap,
+		utils.QualifiedStatesToUnbonding(), unbondingTxMap, startHeight,
 	)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// FindUnbondingDelegations fetches the delegations that have started unbonding
+// but have not yet completed it. A delegation stays in the `unbonding` state until
+// the unbonding timelock expires, after which it's transitioned to `unbonded`.
+// The result is sorted by the unbonding start height in ascending order.
+func (db *Database) FindUnbondingDelegations(
+	ctx context.Context, extraFilter *DelegationFilter, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+
+	filter := bson.M{"state": types.Unbonding}
+	options := options.Find().SetSort(bson.D{
+		{Key: "unbonding_tx.start_height", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+	options.SetLimit(db.cfg.MaxPaginationLimit)
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.UnbondingDelegationPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter = bson.M{
+			"state": types.Unbonding,
+			"$or": []bson.M{
+				{"unbonding_tx.start_height": bson.M{"$gt": decodedToken.UnbondingStartHeight}},
+				{"unbonding_tx.start_height": decodedToken.UnbondingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+			},
+		}
+	}
+	filter = buildAdditionalDelegationFilter(filter, extraFilter)
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []model.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, delegations, model.BuildUnbondingDelegationPaginationToken)
+}
+
+// FindUnbondingDelegationsByExpireHeight fetches the delegations that have started
+// unbonding but have not yet completed it, sorted by the height their unbonding
+// timelock expires at in ascending order, i.e the ones completing first come first.
+func (db *Database) FindUnbondingDelegationsByExpireHeight(
+	ctx context.Context, extraFilter *DelegationFilter, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildAdditionalDelegationFilter(bson.M{"state": types.Unbonding}, extraFilter)}},
+		{{Key: "$addFields", Value: bson.M{
+			"unbonding_expire_height": bson.M{"$add": bson.A{"$unbonding_tx.start_height", "$unbonding_tx.timelock"}},
+		}}},
+	}
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.UnbondingDelegationByExpirePagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"$or": []bson.M{
+				{"unbonding_expire_height": bson.M{"$gt": decodedToken.UnbondingExpireHeight}},
+				{"unbonding_expire_height": decodedToken.UnbondingExpireHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+			},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{
+			{Key: "unbonding_expire_height", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+		bson.D{{Key: "$limit", Value: db.cfg.MaxPaginationLimit}},
+	)
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []model.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, delegations, model.BuildUnbondingDelegationByExpirePaginationToken)
+}
+
+// FindStakerUnbondingRequests fetches the unbonding requests of the staker along
+// with the current state of the delegations they unbond, the most recent first.
+// Only the requests whose delegation is in one of the given states are matched,
+// unless states is nil.
+func (db *Database) FindStakerUnbondingRequests(
+	ctx context.Context, stakerPkHex string, states []types.DelegationState, paginationToken string,
+) (*DbResultMap[model.StakerUnbondingRequestDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.UnbondingCollection)
+
+	filter := bson.M{"staker_pk_hex": stakerPkHex}
+	// Decode the pagination token first if it exist
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.StakerUnbondingRequestPagination](db.paginationTokenSecret, paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		lastId, err := primitive.ObjectIDFromHex(decodedToken.Id)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$lt": lastId}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.M{"_id": -1}}},
+		// The staking tx hash of the unbonding document is stored under its default key
+		{{Key: "$lookup", Value: bson.M{
+			"from":         model.DelegationCollection,
+			"localField":   "stakingtxhashhex",
+			"foreignField": "_id",
+			"as":           "delegation",
+		}}},
+		{{Key: "$unwind", Value: "$delegation"}},
+	}
+	if states != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"delegation.state": bson.M{"$in": states},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$limit", Value: db.cfg.MaxPaginationLimit}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"unbonding_tx_hash_hex":     1,
+			"staking_tx_hash_hex":       "$stakingtxhashhex",
+			"staking_amount":            1,
+			"state":                     "$delegation.state",
+			"unbonding_start_timestamp": "$delegation.unbonding_tx.start_timestamp",
+		}}},
+	)
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var requests []model.StakerUnbondingRequestDocument
+	if err = cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+
+	return toResultMapWithPaginationToken(db.cfg, requests, model.BuildStakerUnbondingRequestPaginationToken)
+}
@@ -10,7 +10,7 @@ import (
 func (db *Database) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
 	err := db.transitionState(
 		ctx, txHashHex, types.Withdrawn.ToString(),
-		utils.QualifiedStatesToWithdraw(), nil,
+		utils.QualifiedStatesToWithdraw(), nil, 0,
 	)
 	if err != nil {
 		return err
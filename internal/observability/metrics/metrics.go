@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi"
@@ -27,15 +28,24 @@ var (
 	once                             sync.Once
 	metricsRouter                    *chi.Mux
 	httpRequestDurationHistogram     *prometheus.HistogramVec
+	httpRequestCounter               *prometheus.CounterVec
+	httpRequestsInFlightGauge        prometheus.Gauge
+	internalServiceErrorCounter      *prometheus.CounterVec
 	eventProcessingDurationHistogram *prometheus.HistogramVec
 	unprocessableEntityCounter       *prometheus.CounterVec
 	queueOperationFailureCounter     *prometheus.CounterVec
 	httpResponseWriteFailureCounter  *prometheus.CounterVec
+	indexedBtcHeightGauge            prometheus.Gauge
+	processStartTimestamp            int64
+	lastBtcInfoUpdateTimestamp       atomic.Int64
+	btcTipHeight                     atomic.Uint64
+	processedEventBtcHeight          atomic.Uint64
 )
 
 // Init initializes the metrics package.
 func Init(metricsPort int) {
 	once.Do(func() {
+		processStartTimestamp = time.Now().Unix()
 		initMetricsRouter(metricsPort)
 		registerMetrics()
 	})
@@ -70,6 +80,31 @@ func registerMetrics() {
 		[]string{"endpoint", "status"},
 	)
 
+	httpRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of http requests per route, method and response status.",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	httpRequestsInFlightGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of http requests currently being served.",
+		},
+	)
+
+	// The services surface the failures of the downstream dependencies, such
+	// as the db, as internal service errors
+	internalServiceErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_internal_service_error_total",
+			Help: "Total number of http requests failed with an internal service error per endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+
 	eventProcessingDurationHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "event_processing_duration_seconds",
@@ -103,12 +138,62 @@ func registerMetrics() {
 		[]string{"status"},
 	)
 
+	indexedBtcHeightGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "indexed_btc_height",
+			Help: "Latest BTC height reported by the indexer through the btc info events.",
+		},
+	)
+
+	// The indexer emits a btc info event for every new BTC block it processes,
+	// hence the time elapsed since the last new height is the indexing lag in
+	// seconds. Until one is known, the lag is measured from the process start
+	// so that an indexer which stopped emitting is still alerted on.
+	btcInfoLagSecondsGauge := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "indexed_btc_info_lag_seconds",
+			Help: "Seconds elapsed since the indexed BTC height last increased.",
+		},
+		func() float64 {
+			lastUpdate := lastBtcInfoUpdateTimestamp.Load()
+			if lastUpdate == 0 {
+				lastUpdate = processStartTimestamp
+			}
+			return time.Since(time.Unix(lastUpdate, 0)).Seconds()
+		},
+	)
+
+	// The btc info events report the BTC height indexed so far, while the
+	// stored delegations are at the height of their staking tx. As blocks
+	// without any staking tx are not reported, the lag also grows while there
+	// is no staking activity, alerts should allow for it.
+	btcBlockLagGauge := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "indexed_btc_block_lag",
+			Help: "Blocks between the indexed BTC height and the highest staking tx height of the stored delegations.",
+		},
+		func() float64 {
+			tipHeight := btcTipHeight.Load()
+			processedHeight := processedEventBtcHeight.Load()
+			if processedHeight == 0 || tipHeight <= processedHeight {
+				return 0
+			}
+			return float64(tipHeight - processedHeight)
+		},
+	)
+
 	prometheus.MustRegister(
 		httpRequestDurationHistogram,
+		httpRequestCounter,
+		httpRequestsInFlightGauge,
+		internalServiceErrorCounter,
 		eventProcessingDurationHistogram,
 		unprocessableEntityCounter,
 		queueOperationFailureCounter,
 		httpResponseWriteFailureCounter,
+		indexedBtcHeightGauge,
+		btcInfoLagSecondsGauge,
+		btcBlockLagGauge,
 	)
 }
 
@@ -124,6 +209,21 @@ func StartHttpRequestDurationTimer(endpoint string) func(statusCode int) {
 	}
 }
 
+// StartHttpRequest accounts for a http request being in flight until the
+// returned function is called with the outcome of the request.
+func StartHttpRequest() func(route, method string, statusCode int) {
+	httpRequestsInFlightGauge.Inc()
+	return func(route, method string, statusCode int) {
+		httpRequestsInFlightGauge.Dec()
+		httpRequestCounter.WithLabelValues(route, method, fmt.Sprintf("%d", statusCode)).Inc()
+	}
+}
+
+// RecordInternalServiceError increments the internal service error counter.
+func RecordInternalServiceError(endpoint string) {
+	internalServiceErrorCounter.WithLabelValues(endpoint).Inc()
+}
+
 func StartEventProcessingDurationTimer(queuename string, attempts int32) func(statusCode int) {
 	startTime := time.Now()
 	return func(statusCode int) {
@@ -151,3 +251,46 @@ func RecordQueueOperationFailure(operation, queuename string) {
 func RecordHttpResponseWriteFailure(statusCode int) {
 	httpResponseWriteFailureCounter.WithLabelValues(fmt.Sprintf("%d", statusCode)).Inc()
 }
+
+// RecordBtcInfoUpdate sets the latest indexed BTC height and resets the
+// indexing lag which is measured from the time of this call. As for the
+// persisted btc info, the heights not greater than the latest one are ignored.
+func RecordBtcInfoUpdate(btcHeight uint64) {
+	for {
+		tipHeight := btcTipHeight.Load()
+		if btcHeight <= tipHeight {
+			return
+		}
+		if btcTipHeight.CompareAndSwap(tipHeight, btcHeight) {
+			break
+		}
+	}
+	indexedBtcHeightGauge.Set(float64(btcHeight))
+	lastBtcInfoUpdateTimestamp.Store(time.Now().Unix())
+}
+
+// SeedBtcLag seeds the indexer lag metrics from the persisted state on
+// startup, i.e the latest indexed BTC height along with the unix timestamp it
+// was stored at, 0 if unknown, and the highest staking tx height of the stored
+// delegations. It must be called before any event is processed.
+func SeedBtcLag(btcHeight uint64, updatedAt int64, stakingHeight uint64) {
+	indexedBtcHeightGauge.Set(float64(btcHeight))
+	btcTipHeight.Store(btcHeight)
+	if updatedAt > 0 {
+		lastBtcInfoUpdateTimestamp.Store(updatedAt)
+	}
+	RecordStakingEventProcessed(stakingHeight)
+}
+
+// RecordStakingEventProcessed records the staking tx height of a stored
+// delegation, the block lag is measured from the highest one. The events are
+// not processed in height order, hence the lower heights are ignored.
+func RecordStakingEventProcessed(btcHeight uint64) {
+	for {
+		processedHeight := processedEventBtcHeight.Load()
+		if btcHeight <= processedHeight ||
+			processedEventBtcHeight.CompareAndSwap(processedHeight, btcHeight) {
+			return
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	queueClient "github.com/babylonchain/staking-queue-client/client"
 	"github.com/rs/zerolog/log"
@@ -73,6 +74,7 @@ func (h *QueueHandler) ActiveStakingHandler(ctx context.Context, messageBody str
 	if saveErr != nil {
 		return saveErr
 	}
+	metrics.RecordStakingEventProcessed(activeStakingEvent.StakingStartHeight)
 
 	return nil
 }
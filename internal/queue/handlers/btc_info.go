@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	queueClient "github.com/babylonchain/staking-queue-client/client"
 	"github.com/rs/zerolog/log"
@@ -25,5 +26,6 @@ func (h *QueueHandler) BtcInfoHandler(ctx context.Context, messageBody string) *
 		log.Error().Err(statsErr).Msg("Failed to process unconfirmed tvl stats")
 		return types.NewInternalServiceError(statsErr)
 	}
+	metrics.RecordBtcInfoUpdate(btcInfo.Height)
 	return nil
 }
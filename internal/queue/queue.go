@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -161,6 +162,40 @@ func (q *Queues) StopReceivingMessages() {
 	// ...add more queues here
 }
 
+// Ping checks the connection of each of the queue clients, failing on the
+// first unhealthy one or once the context is done.
+func (q *Queues) Ping(ctx context.Context) error {
+	queueClients := []client.QueueClient{
+		q.ActiveStakingQueueClient,
+		q.ExpiredStakingQueueClient,
+		q.UnbondingStakingQueueClient,
+		q.WithdrawStakingQueueClient,
+		q.StatsQueueClient,
+		q.BtcInfoQueueClient,
+	}
+	for _, queueClient := range queueClients {
+		if err := pingQueueClient(ctx, queueClient); err != nil {
+			return fmt.Errorf("queue %s is not healthy: %w", queueClient.GetQueueName(), err)
+		}
+	}
+	return nil
+}
+
+// pingQueueClient pings the queue client, giving up once the context is done
+// as the client ping does not take a context.
+func pingQueueClient(ctx context.Context, queueClient client.QueueClient) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- queueClient.Ping()
+	}()
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func startQueueMessageProcessing(
 	queueClient client.QueueClient,
 	handler handlers.MessageHandler, unprocessableHandler handlers.UnprocessableMessageHandler,
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
+)
+
+const (
+	btcTipHeightCacheKey = "btc:tip-height"
+	// The tip height is only refreshed from the DB once per TTL, it's polled
+	// on every read request when the tip height header is enabled.
+	btcTipHeightCacheTTL = 5 * time.Second
+)
+
+// GetBtcTipHeight returns the latest indexed BTC height. The second return
+// value is false if the height is not known, i.e nothing has been indexed yet
+// or the height could not be fetched.
+func (s *Services) GetBtcTipHeight(ctx context.Context) (uint64, bool) {
+	cached, found, err := s.Cache.Get(ctx, btcTipHeightCacheKey)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while fetching the btc tip height from cache")
+	}
+	if found {
+		height, err := strconv.ParseUint(string(cached), 10, 64)
+		if err == nil {
+			return height, true
+		}
+		log.Ctx(ctx).Warn().Err(err).Msg("invalid btc tip height in cache")
+	}
+
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		}
+		return 0, false
+	}
+	err = s.Cache.Set(
+		ctx, btcTipHeightCacheKey, []byte(strconv.FormatUint(btcInfo.BtcHeight, 10)), btcTipHeightCacheTTL,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while caching the btc tip height")
+	}
+	return btcInfo.BtcHeight, true
+}
+
+// InitBtcLagMetrics seeds the indexer lag metrics from the persisted btc info
+// and delegations, so that they carry over restarts instead of being reset
+// until the next events. It must be called before the events are processed.
+func (s *Services) InitBtcLagMetrics(ctx context.Context) error {
+	var btcHeight uint64
+	var updatedAt int64
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+			return err
+		}
+	} else {
+		btcHeight, updatedAt = btcInfo.BtcHeight, btcInfo.UpdatedAt
+	}
+	stakingHeight, err := s.DbClient.FindLatestDelegationStakingHeight(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching the latest delegation staking height")
+		return err
+	}
+	metrics.SeedBtcLag(btcHeight, updatedAt, stakingHeight)
+	return nil
+}
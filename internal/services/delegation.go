@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
@@ -28,9 +32,57 @@ type DelegationPublic struct {
 	StakingTx             *TransactionPublic `json:"staking_tx"`
 	UnbondingTx           *TransactionPublic `json:"unbonding_tx,omitempty"`
 	IsOverflow            bool               `json:"is_overflow"`
+	StakingTxExplorerUrl  string             `json:"staking_tx_explorer_url"`
+	// From the params version at the staking height
+	RequiredCovenantQuorum uint64 `json:"required_covenant_quorum"`
+	// Only set once the delegation has ended
+	UnbondingType         *string `json:"unbonding_type"`
+	StakedDurationSeconds int64   `json:"staked_duration_seconds"`
+	UpdatedAt             string  `json:"updated_at"`
+	// Only available when fetching a single delegation with its cap position
+	// included. The cap position is the active stake in sats filling the staking
+	// cap of the params version ahead of the delegation, to be compared against
+	// the staking cap
+	CapPosition *uint64 `json:"cap_position,omitempty"`
+	StakingCap  *uint64 `json:"staking_cap,omitempty"`
 }
 
-func fromDelegationDocument(d model.DelegationDocument) DelegationPublic {
+// DelegationMinimalPublic is the compact form of a delegation, for the clients
+// syncing incrementally to find out which delegations changed since their last sync.
+type DelegationMinimalPublic struct {
+	DelegationId string `json:"delegation_id"`
+	State        string `json:"state"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// delegationMinimalFields are the delegation fields DelegationMinimalPublic is
+// built from, including the tx timestamps the last update time defaults to
+var delegationMinimalFields = []string{
+	"state", "updated_at", "staking_tx.start_timestamp", "unbonding_tx.start_timestamp",
+}
+
+func fromDelegationDocumentToMinimal(d model.DelegationDocument) DelegationMinimalPublic {
+	return DelegationMinimalPublic{
+		DelegationId: d.StakingTxHashHex,
+		State:        d.State.ToString(),
+		UpdatedAt:    utils.ParseTimestampToIsoFormat(delegationUpdatedAt(d)),
+	}
+}
+
+// delegationUpdatedAt returns when the delegation was last written. The
+// delegations not written since it's been tracked default to their latest
+// known tx timestamp.
+func delegationUpdatedAt(d model.DelegationDocument) int64 {
+	if d.UpdatedAt != 0 {
+		return d.UpdatedAt
+	}
+	if d.UnbondingTx != nil && d.UnbondingTx.StartTimestamp != 0 {
+		return d.UnbondingTx.StartTimestamp
+	}
+	return d.StakingTx.StartTimestamp
+}
+
+func (s *Services) fromDelegationDocument(d model.DelegationDocument) DelegationPublic {
 	delPublic := DelegationPublic{
 		StakingTxHashHex:      d.StakingTxHashHex,
 		StakerPkHex:           d.StakerPkHex,
@@ -44,7 +96,19 @@ func fromDelegationDocument(d model.DelegationDocument) DelegationPublic {
 			StartHeight:    d.StakingTx.StartHeight,
 			TimeLock:       d.StakingTx.TimeLock,
 		},
-		IsOverflow: d.IsOverflow,
+		IsOverflow:            d.IsOverflow,
+		StakingTxExplorerUrl:  s.buildBtcExplorerTxUrl(d.StakingTxHashHex),
+		StakedDurationSeconds: stakedDurationSeconds(d, time.Now()),
+		UpdatedAt:             utils.ParseTimestampToIsoFormat(delegationUpdatedAt(d)),
+	}
+
+	if paramsVersion := s.GetVersionedGlobalParamsByHeight(d.StakingTx.StartHeight); paramsVersion != nil {
+		delPublic.RequiredCovenantQuorum = paramsVersion.CovenantQuorum
+	}
+
+	if unbondingType := getUnbondingType(d); unbondingType != "" {
+		unbondingTypeStr := unbondingType.ToString()
+		delPublic.UnbondingType = &unbondingTypeStr
 	}
 
 	// Add unbonding transaction if it exists
@@ -60,23 +124,392 @@ func fromDelegationDocument(d model.DelegationDocument) DelegationPublic {
 	return delPublic
 }
 
-func (s *Services) DelegationsByStakerPk(ctx context.Context, stakerPk string, pageToken string) ([]DelegationPublic, string, *types.Error) {
-	resultMap, err := s.DbClient.FindDelegationsByStakerPk(ctx, stakerPk, pageToken)
+// getUnbondingType returns how the delegation ended, or an empty string if the
+// delegation has not ended yet.
+func getUnbondingType(d model.DelegationDocument) types.UnbondingType {
+	if d.State != types.Unbonded && d.State != types.Withdrawn {
+		return ""
+	}
+	if d.UnbondingTx != nil {
+		return types.EarlyUnbonding
+	}
+	return types.NaturalExpiry
+}
+
+// stakedDurationSeconds returns for how long the delegation has been staked.
+// The stake stops being staked once its unbonding tx is confirmed, or once the
+// staking timelock expires. As only the height of the expiry is known, the
+// duration of a naturally expired delegation is estimated from its timelock.
+func stakedDurationSeconds(d model.DelegationDocument, now time.Time) int64 {
+	startTimestamp := d.StakingTx.StartTimestamp
+	var endTimestamp int64
+	switch {
+	case d.UnbondingTx != nil:
+		endTimestamp = d.UnbondingTx.StartTimestamp
+	case d.State == types.Unbonded || d.State == types.Withdrawn:
+		endTimestamp = startTimestamp + int64(d.StakingTx.TimeLock*averageBtcBlockTimeInSeconds)
+	default:
+		endTimestamp = now.Unix()
+	}
+	if endTimestamp < startTimestamp {
+		return 0
+	}
+	return endTimestamp - startTimestamp
+}
+
+// buildBtcExplorerTxUrl builds the explorer URL of the given BTC transaction
+// from the configured template. It returns an empty string if no template is configured.
+func (s *Services) buildBtcExplorerTxUrl(txHashHex string) string {
+	template := s.cfg.Server.BtcExplorerTxUrlTemplate
+	if template == "" {
+		return ""
+	}
+	networkPath := btcExplorerNetworkPath(s.cfg.Server.BTCNet)
+	if networkPath == "" {
+		// Drop the network path segment along with its slash
+		template = strings.Replace(template, config.BtcExplorerNetworkPlaceholder+"/", "", 1)
+	}
+	return strings.NewReplacer(
+		config.BtcExplorerTxIdPlaceholder, txHashHex,
+		config.BtcExplorerNetworkPlaceholder, networkPath,
+	).Replace(template)
+}
+
+// btcExplorerNetworkPath returns the path segment of the BTC network in the
+// explorer URLs, following the mempool.space conventions. Mainnet is served
+// from the root, hence has no path segment.
+func btcExplorerNetworkPath(btcNet string) string {
+	switch btcNet {
+	case "mainnet":
+		return ""
+	case "testnet3":
+		return "testnet"
+	default:
+		return btcNet
+	}
+}
+
+// minDisplayConfirmationsFilter adds the minimum confirmations to the filter
+// so that the delegations which are not deep enough at the given btc tip height
+// are hidden from the listings. The configured minimum display confirmations
+// applies unless the requested minimum confirmations is stricter.
+func (s *Services) minDisplayConfirmationsFilter(
+	filter *db.DelegationFilter, requestedMinConfirmations, btcTipHeight uint64,
+) *db.DelegationFilter {
+	minConfirmations := s.minDisplayConfirmations(requestedMinConfirmations)
+	if minConfirmations == 0 {
+		return filter
+	}
+	if filter == nil {
+		filter = &db.DelegationFilter{}
+	}
+	filter.MinConfirmations = minConfirmations
+	filter.BtcTipHeight = btcTipHeight
+	return filter
+}
+
+// minDisplayConfirmations returns the stricter of the configured minimum
+// display confirmations and the requested one.
+func (s *Services) minDisplayConfirmations(requestedMinConfirmations uint64) uint64 {
+	return max(s.cfg.Server.MinDisplayConfirmations, requestedMinConfirmations)
+}
+
+// overflowFilter adds the overflow delegations exclusion to the filter. The
+// overflow delegations are included unless this filter excludes them: it does
+// so if the request opts out of them, or if the request doesn't say and the
+// configuration hides them by default. A nil includeOverflow hence always
+// follows the configured default.
+func (s *Services) overflowFilter(
+	filter *db.DelegationFilter, includeOverflow *bool,
+) *db.DelegationFilter {
+	excludeOverflow := s.cfg.Server.HideOverflowByDefault
+	if includeOverflow != nil {
+		excludeOverflow = !*includeOverflow
+	}
+	if !excludeOverflow {
+		return filter
+	}
+	if filter == nil {
+		filter = &db.DelegationFilter{}
+	}
+	filter.ExcludeOverflow = true
+	return filter
+}
+
+// pageSize returns the requested page size of a delegation listing, or the
+// configured default one if not requested.
+func (s *Services) pageSize(limit int64) int64 {
+	if limit > 0 {
+		return limit
+	}
+	defaultPageSize, _ := s.cfg.PageSizeLimits()
+	return defaultPageSize
+}
+
+// StakerDelegationsFilter narrows down the delegations of a staker.
+// The zero value of each field means no filtering on it.
+type StakerDelegationsFilter struct {
+	States        []types.DelegationState
+	UnbondingType types.UnbondingType
+	// Only the delegations to the finality provider are listed
+	FinalityProviderPkHex string
+	// Overrides the configured default of whether overflow delegations are listed
+	IncludeOverflow *bool
+	// Only the delegations whose staking value is at most (if true) or above
+	// (if false) the min staking amount of their params version are listed
+	BelowMinStakingAmount *bool
+	// Only the delegations whose staking timelock is within the range are listed
+	StakingTimelock *StakingTimelockRange
+	// Only the delegations with at least this many confirmations are listed,
+	// unless the configured minimum display confirmations is stricter
+	MinConfirmations uint64
+}
+
+type StakerDelegationsSortBy string
+
+const (
+	StakerDelegationsSortByStakingAmount StakerDelegationsSortBy = "staking_amount"
+	StakerDelegationsSortByStartHeight   StakerDelegationsSortBy = "start_height"
+)
+
+// StakerDelegationsSort is an explicit sort of the delegations of a staker,
+// the staking tx hash being the tie breaker.
+type StakerDelegationsSort struct {
+	By        StakerDelegationsSortBy
+	Ascending bool
+}
+
+// StakingTimelockRange is an inclusive range of staking timelock values.
+// A nil bound means the range is unbounded on that side.
+type StakingTimelockRange struct {
+	Min *uint64
+	Max *uint64
+}
+
+// validateStakingTimelockRange makes sure the bounds of the range are within
+// the staking times allowed by the global params, as no delegation can be
+// found beyond them.
+func (s *Services) validateStakingTimelockRange(timelockRange *StakingTimelockRange) *types.Error {
+	if len(s.params.Versions) == 0 {
+		return nil
+	}
+	minAllowed := s.params.Versions[0].MinStakingTime
+	maxAllowed := s.params.Versions[0].MaxStakingTime
+	for _, paramsVersion := range s.params.Versions[1:] {
+		minAllowed = min(minAllowed, paramsVersion.MinStakingTime)
+		maxAllowed = max(maxAllowed, paramsVersion.MaxStakingTime)
+	}
+	for _, bound := range []*uint64{timelockRange.Min, timelockRange.Max} {
+		if bound != nil && (*bound < minAllowed || *bound > maxAllowed) {
+			return types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				fmt.Sprintf("staking timelock must be within the allowed range [%d, %d]", minAllowed, maxAllowed),
+			)
+		}
+	}
+	return nil
+}
+
+// DelegationsByStakerPk returns a page of at most limit delegations of the staker
+// matching the filter, the configured default page size applying if limit is 0.
+// They are sorted by the staking start height in descending order, unless an
+// explicit sort is given. The pagination token is only valid for the same sort.
+func (s *Services) DelegationsByStakerPk(
+	ctx context.Context, stakerPk string, filter *StakerDelegationsFilter,
+	sort *StakerDelegationsSort, pageToken string, limit int64,
+) ([]DelegationPublic, string, *types.Error) {
+	resultMap, err := s.findDelegationsByStakerPk(ctx, stakerPk, filter, sort, pageToken, limit, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var delegations []DelegationPublic = make([]DelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, s.fromDelegationDocument(d))
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// MinimalDelegationsByStakerPk returns the same page of delegations of the
+// staker as DelegationsByStakerPk, in their minimal form. Only the fields the
+// minimal form is built from are fetched.
+func (s *Services) MinimalDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, filter *StakerDelegationsFilter,
+	sort *StakerDelegationsSort, pageToken string, limit int64,
+) ([]DelegationMinimalPublic, string, *types.Error) {
+	resultMap, err := s.findDelegationsByStakerPk(
+		ctx, stakerPk, filter, sort, pageToken, limit, delegationMinimalFields,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	delegations := make([]DelegationMinimalPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, fromDelegationDocumentToMinimal(d))
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// findDelegationsByStakerPk fetches a page of delegations of the staker
+// matching the filter, only the given fields of them if not nil.
+func (s *Services) findDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, filter *StakerDelegationsFilter,
+	sort *StakerDelegationsSort, pageToken string, limit int64, fields []string,
+) (*db.DbResultMap[model.DelegationDocument], *types.Error) {
+	var extraFilter *db.DelegationFilter
+	var includeOverflow *bool
+	var minConfirmations uint64
+	if filter != nil {
+		extraFilter = &db.DelegationFilter{
+			States:                filter.States,
+			UnbondingType:         filter.UnbondingType,
+			FinalityProviderPkHex: filter.FinalityProviderPkHex,
+		}
+		includeOverflow = filter.IncludeOverflow
+		minConfirmations = filter.MinConfirmations
+		if filter.BelowMinStakingAmount != nil {
+			extraFilter.StakingAmountThresholds = s.getMinStakingAmountThresholds()
+			extraFilter.AboveStakingAmountThresholds = !*filter.BelowMinStakingAmount
+		}
+		if filter.StakingTimelock != nil {
+			if err := s.validateStakingTimelockRange(filter.StakingTimelock); err != nil {
+				return nil, err
+			}
+			if filter.StakingTimelock.Min != nil {
+				extraFilter.MinStakingTimelock = *filter.StakingTimelock.Min
+			}
+			if filter.StakingTimelock.Max != nil {
+				extraFilter.MaxStakingTimelock = *filter.StakingTimelock.Max
+			}
+		}
+	}
+	extraFilter = s.overflowFilter(extraFilter, includeOverflow)
+	if s.minDisplayConfirmations(minConfirmations) != 0 {
+		btcInfo, btcInfoErr := s.getLatestBtcInfo(ctx)
+		if btcInfoErr != nil {
+			return nil, btcInfoErr
+		}
+		extraFilter = s.minDisplayConfirmationsFilter(extraFilter, minConfirmations, btcInfo.BtcHeight)
+	}
+	var dbSort *db.DelegationSort
+	if sort != nil {
+		dbSort = &db.DelegationSort{Field: db.SortByStakingStartHeight, Ascending: sort.Ascending}
+		if sort.By == StakerDelegationsSortByStakingAmount {
+			dbSort.Field = db.SortByStakingValue
+		}
+	}
+	resultMap, err := s.DbClient.FindDelegationsByStakerPk(
+		ctx, stakerPk, extraFilter, dbSort, pageToken, s.pageSize(limit), fields,
+	)
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by staker pk")
-			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+			return nil, types.NewError(http.StatusBadRequest, types.BadRequest, err)
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by staker pk")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return resultMap, nil
+}
+
+// DelegationsByFinalityProviderPk returns a page of at most limit delegations to
+// the finality provider, the configured default page size applying if limit is 0.
+// They are optionally only the ones in the given states, sorted by the staking start
+// height in descending order. The overflow delegations and the ones below the minimum
+// display confirmations are hidden as they are from the staker delegations.
+func (s *Services) DelegationsByFinalityProviderPk(
+	ctx context.Context, fpPkHex string, states []types.DelegationState, pageToken string, limit int64,
+) ([]DelegationPublic, string, *types.Error) {
+	extraFilter := s.overflowFilter(&db.DelegationFilter{States: states}, nil)
+	if s.minDisplayConfirmations(0) != 0 {
+		btcInfo, btcInfoErr := s.getLatestBtcInfo(ctx)
+		if btcInfoErr != nil {
+			return nil, "", btcInfoErr
+		}
+		extraFilter = s.minDisplayConfirmationsFilter(extraFilter, 0, btcInfo.BtcHeight)
+	}
+	resultMap, err := s.DbClient.FindDelegationsByFinalityProviderPk(
+		ctx, fpPkHex, extraFilter, pageToken, s.pageSize(limit),
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by finality provider pk")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by finality provider pk")
 		return nil, "", types.NewInternalServiceError(err)
 	}
-	var delegations []DelegationPublic = make([]DelegationPublic, 0, len(resultMap.Data))
+	delegations := make([]DelegationPublic, 0, len(resultMap.Data))
 	for _, d := range resultMap.Data {
-		delegations = append(delegations, fromDelegationDocument(d))
+		delegations = append(delegations, s.fromDelegationDocument(d))
 	}
 	return delegations, resultMap.PaginationToken, nil
 }
 
+type StakerDelegationsPublic struct {
+	Delegations []DelegationPublic `json:"delegations"`
+	// Pagination key to fetch the next page of delegations of the staker
+	// from the staker delegations endpoint, empty if there are no more
+	NextKey string `json:"next_key"`
+}
+
+// DelegationsByStakerPks returns the first page of delegations of each of the
+// stakers, keyed by their pk hex, fetched at once rather than staker by staker.
+// Stakers without any delegation are included with an empty list.
+func (s *Services) DelegationsByStakerPks(
+	ctx context.Context, stakerPks []string,
+) (map[string]*StakerDelegationsPublic, *types.Error) {
+	extraFilter := s.overflowFilter(nil, nil)
+	if s.minDisplayConfirmations(0) != 0 {
+		btcInfo, btcInfoErr := s.getLatestBtcInfo(ctx)
+		if btcInfoErr != nil {
+			return nil, btcInfoErr
+		}
+		extraFilter = s.minDisplayConfirmationsFilter(extraFilter, 0, btcInfo.BtcHeight)
+	}
+	resultMaps, err := s.DbClient.FindDelegationsByStakerPks(ctx, stakerPks, extraFilter, s.pageSize(0))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by staker pks")
+		return nil, types.NewInternalServiceError(err)
+	}
+	stakersDelegations := make(map[string]*StakerDelegationsPublic, len(stakerPks))
+	for _, stakerPk := range stakerPks {
+		stakerDelegations := &StakerDelegationsPublic{Delegations: []DelegationPublic{}}
+		if resultMap, ok := resultMaps[stakerPk]; ok {
+			for _, d := range resultMap.Data {
+				stakerDelegations.Delegations = append(stakerDelegations.Delegations, s.fromDelegationDocument(d))
+			}
+			stakerDelegations.NextKey = resultMap.PaginationToken
+		}
+		stakersDelegations[stakerPk] = stakerDelegations
+	}
+	return stakersDelegations, nil
+}
+
+type StakerTotalStakePublic struct {
+	StakerPkHex string `json:"staker_pk_hex"`
+	// Sum of the staking value in satoshis
+	TotalStake uint64 `json:"total_stake"`
+}
+
+// GetStakerTotalStake sums up the staking value of the delegations of the
+// staker whose BTC is still locked, i.e excluding the unbonded and withdrawn
+// ones. The overflow delegations follow the configured default of overflowFilter.
+func (s *Services) GetStakerTotalStake(ctx context.Context, stakerPk string) (*StakerTotalStakePublic, *types.Error) {
+	filter := s.overflowFilter(&db.DelegationFilter{
+		StakerPkHex: stakerPk,
+		States:      lockedDelegationStates,
+	}, nil)
+	totalStake, err := s.DbClient.SumDelegationsStakingValue(ctx, filter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while summing up the staker total stake")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &StakerTotalStakePublic{
+		StakerPkHex: stakerPk,
+		TotalStake:  totalStake,
+	}, nil
+}
+
 // SaveActiveStakingDelegation saves the active staking delegation to the database.
 func (s *Services) SaveActiveStakingDelegation(
 	ctx context.Context, txHashHex, stakerPkHex, finalityProviderPkHex string,
@@ -134,6 +567,138 @@ func (s *Services) GetDelegation(ctx context.Context, txHashHex string) (*model.
 	return delegation, nil
 }
 
+// GetDelegationPublic returns the public delegation identified by the staking tx hash.
+// If includeCapPosition is set, the stake filling the staking cap of its params
+// version ahead of it is included, which is aggregated over the delegations of
+// the params version hence only computed on demand.
+func (s *Services) GetDelegationPublic(
+	ctx context.Context, txHashHex string, includeCapPosition bool,
+) (*DelegationPublic, *types.Error) {
+	delegation, err := s.GetDelegation(ctx, txHashHex)
+	if err != nil {
+		return nil, err
+	}
+	delPublic := s.fromDelegationDocument(*delegation)
+	if !includeCapPosition {
+		return &delPublic, nil
+	}
+
+	paramsVersion := s.GetVersionedGlobalParamsByHeight(delegation.StakingTx.StartHeight)
+	if paramsVersion == nil {
+		// The delegation was staked before the first params activation,
+		// hence it's not part of any staking cap ordering
+		return &delPublic, nil
+	}
+	capPosition, capErr := s.getCapPosition(ctx, delegation, paramsVersion)
+	if capErr != nil {
+		return nil, capErr
+	}
+	delPublic.CapPosition = &capPosition
+	delPublic.StakingCap = &paramsVersion.StakingCap
+	return &delPublic, nil
+}
+
+// GetDelegationsPublicByTxHashes returns the public delegations identified by the
+// staking tx hashes, keyed by hash, fetched in a single query. Hashes without a
+// matching delegation are included with a nil value. The cap position is left
+// out, it's only available when fetching a single delegation.
+func (s *Services) GetDelegationsPublicByTxHashes(
+	ctx context.Context, txHashHexes []string,
+) (map[string]*DelegationPublic, *types.Error) {
+	delegationDocs, err := s.DbClient.FindDelegationsByTxHashHexes(ctx, txHashHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by tx hash hexes")
+		return nil, types.NewInternalServiceError(err)
+	}
+	delegations := make(map[string]*DelegationPublic, len(txHashHexes))
+	for _, txHashHex := range txHashHexes {
+		delegations[txHashHex] = nil
+	}
+	for _, delegationDoc := range delegationDocs {
+		delPublic := s.fromDelegationDocument(delegationDoc)
+		delegations[delegationDoc.StakingTxHashHex] = &delPublic
+	}
+	return delegations, nil
+}
+
+// GetDelegationByStakingOutput returns the public delegation of the staker
+// identified by the staking tx hash and the staking output index.
+func (s *Services) GetDelegationByStakingOutput(
+	ctx context.Context, stakerPkHex, txHashHex string, outputIndex uint64,
+) (*DelegationPublic, *types.Error) {
+	delegation, err := s.DbClient.FindDelegationByStakingOutput(ctx, stakerPkHex, txHashHex, outputIndex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHash", txHashHex).
+				Uint64("outputIndex", outputIndex).Msg("Staking delegation not found by staking output")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staking delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by staking output")
+		return nil, types.NewInternalServiceError(err)
+	}
+	delPublic := s.fromDelegationDocument(*delegation)
+	return &delPublic, nil
+}
+
+// getCapPosition returns the stake in sats filling the staking cap of the
+// params version of the delegation ahead of it. Delegations are ordered by their
+// confirmation height, the staking tx hash is used as the tie-breaker within
+// the same block so that the position is deterministic. Only the stake of the
+// non-overflow delegations still counted in the active TVL fills the cap.
+func (s *Services) getCapPosition(
+	ctx context.Context, delegation *model.DelegationDocument,
+	paramsVersion *types.VersionedGlobalParams,
+) (uint64, *types.Error) {
+	precedingStake, err := s.DbClient.SumStakingValueBeforeInCapOrder(
+		ctx, delegation.StakingTxHashHex, delegation.StakingTx.StartHeight,
+		paramsVersion.ActivationHeight, s.getNextVersionActivationHeight(paramsVersion),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHash", delegation.StakingTxHashHex).
+			Msg("Failed to sum the stake preceding the delegation in the staking cap order")
+		return 0, types.NewInternalServiceError(err)
+	}
+	return precedingStake, nil
+}
+
+// GetStakerDelegationSummary counts the delegations of the staker per state,
+// keyed by state. Every state is included, with a zero count if the staker has
+// no delegation in it. The overflow delegations follow the configured default of
+// overflowFilter.
+func (s *Services) GetStakerDelegationSummary(
+	ctx context.Context, stakerPk string,
+) (map[types.DelegationState]int64, *types.Error) {
+	filter := s.overflowFilter(&db.DelegationFilter{StakerPkHex: stakerPk}, nil)
+	stateCounts, err := s.DbClient.CountDelegationsByState(ctx, filter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting the staker delegations by state")
+		return nil, types.NewInternalServiceError(err)
+	}
+	summary := make(map[types.DelegationState]int64)
+	for _, state := range types.DelegationStates() {
+		summary[state] = 0
+	}
+	for _, stateCount := range stateCounts {
+		summary[stateCount.State] = stateCount.Count
+	}
+	return summary, nil
+}
+
+// StakerPkByTaprootAddress maps the taproot address of a staker to its pk hex,
+// based on the stored delegations. It returns an empty pk if no delegation has
+// been made from the address.
+func (s *Services) StakerPkByTaprootAddress(ctx context.Context, address string) (string, *types.Error) {
+	stakerPk, err := s.DbClient.FindStakerPkByTaprootAddress(ctx, address)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return "", nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find staker pk by taproot address")
+		return "", types.NewInternalServiceError(err)
+	}
+	return stakerPk, nil
+}
+
 func (s *Services) CheckStakerHasActiveDelegationByAddress(
 	ctx context.Context, btcAddress string, afterTimestamp int64,
 ) (bool, *types.Error) {
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type DelegationStateChangePublic struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	// Empty when the delegation is created
+	FromState string `json:"from_state"`
+	ToState   string `json:"to_state"`
+	Timestamp string `json:"timestamp"`
+	// Only available if the transition is triggered by a btc transaction
+	BtcHeight *uint64 `json:"btc_height"`
+	// Set on the creation of the delegations made before the state changes
+	// are recorded, which is derived from their staking tx instead
+	Synthesized bool `json:"synthesized,omitempty"`
+}
+
+// DelegationStateChanges returns the state changes across all delegations in
+// the order they were recorded, allowing the consumers to sync incrementally.
+// The returned pagination token is never empty once a state change has been
+// recorded, so that the consumers can resume from it.
+func (s *Services) DelegationStateChanges(
+	ctx context.Context, pageToken string,
+) ([]DelegationStateChangePublic, string, *types.Error) {
+	resultMap, err := s.DbClient.FindDelegationStateChanges(ctx, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegation state changes")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation state changes")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	return toDelegationStateChangesPublic(resultMap.Data), resultMap.PaginationToken, nil
+}
+
+// DelegationStateHistory returns the state changes of the delegation identified
+// by the staking tx hash, from its creation onwards. It returns a NotFound error
+// if the delegation does not exist.
+// The delegations created before the state changes are recorded have no
+// recorded creation, it's synthesized from their staking tx. Their transitions
+// made before the recording started are unknown, hence missing.
+func (s *Services) DelegationStateHistory(
+	ctx context.Context, stakingTxHashHex string,
+) ([]DelegationStateChangePublic, *types.Error) {
+	delegation, delErr := s.GetDelegation(ctx, stakingTxHashHex)
+	if delErr != nil {
+		return nil, delErr
+	}
+	stateChanges, err := s.DbClient.FindDelegationStateChangesByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the state changes of the delegation")
+		return nil, types.NewInternalServiceError(err)
+	}
+	history := toDelegationStateChangesPublic(stateChanges)
+	if len(stateChanges) == 0 || stateChanges[0].FromState != "" {
+		history = append([]DelegationStateChangePublic{synthesizeDelegationCreation(delegation)}, history...)
+	}
+	return history, nil
+}
+
+// synthesizeDelegationCreation derives the creation of the delegation from its
+// staking tx, as the delegations are always created active by it.
+func synthesizeDelegationCreation(delegation *model.DelegationDocument) DelegationStateChangePublic {
+	btcHeight := delegation.StakingTx.StartHeight
+	return DelegationStateChangePublic{
+		StakingTxHashHex: delegation.StakingTxHashHex,
+		ToState:          types.Active.ToString(),
+		Timestamp:        utils.ParseTimestampToIsoFormat(delegation.StakingTx.StartTimestamp),
+		BtcHeight:        &btcHeight,
+		Synthesized:      true,
+	}
+}
+
+func toDelegationStateChangesPublic(docs []model.DelegationStateChangeDocument) []DelegationStateChangePublic {
+	stateChanges := make([]DelegationStateChangePublic, 0, len(docs))
+	for _, d := range docs {
+		stateChange := DelegationStateChangePublic{
+			StakingTxHashHex: d.StakingTxHashHex,
+			FromState:        d.FromState.ToString(),
+			ToState:          d.ToState.ToString(),
+			Timestamp:        utils.ParseTimestampToIsoFormat(d.Timestamp),
+		}
+		if d.BtcHeight != 0 {
+			btcHeight := d.BtcHeight
+			stateChange.BtcHeight = &btcHeight
+		}
+		stateChanges = append(stateChanges, stateChange)
+	}
+	return stateChanges
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// The export jobs and their files are kept in the cache until they expire,
+// hence they are only shared across the instances with the redis cache.
+const (
+	exportJobCacheKeyPrefix  = "export:job:"
+	exportFileCacheKeyPrefix = "export:file:"
+	// How long a job and its file are kept from the job creation
+	exportJobTtl = time.Hour
+	// Time after which a running export is abandoned and the job failed
+	exportJobTimeout = 10 * time.Minute
+	// Number of exports generated concurrently by the background workers
+	exportWorkers = 2
+	// Number of jobs waiting for a worker, beyond which new jobs are rejected
+	maxPendingExportJobs = 100
+)
+
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+type ExportJobPublic struct {
+	JobId  string          `json:"job_id"`
+	Status ExportJobStatus `json:"status"`
+	// The job and its file are no longer available after this time
+	ExpiresAt string `json:"expires_at"`
+	// Only set once completed
+	DownloadUrl string `json:"download_url,omitempty"`
+}
+
+// exportJobDocument is the state of an export job stored in the cache
+type exportJobDocument struct {
+	Status    ExportJobStatus `json:"status"`
+	ExpiresAt int64           `json:"expires_at"`
+}
+
+// stakerDelegationsExportJob is an export job waiting for a worker
+type stakerDelegationsExportJob struct {
+	// Carries the logger of the request the job was created from
+	ctx       context.Context
+	jobId     string
+	expiresAt time.Time
+	stakerPk  string
+	filter    *StakerDelegationsFilter
+	sort      *StakerDelegationsSort
+}
+
+var stakerDelegationsCsvHeader = []string{
+	"staking_tx_hash_hex", "state", "staking_value", "staking_start_height", "finality_provider_pk_hex",
+}
+
+// WriteStakerDelegationsCsv writes the delegations of the staker as CSV after
+// a header row, starting with the given page and then fetching the following
+// ones from nextKey one at a time, so that large stakers are not buffered in
+// memory. Each page is flushed to w once written.
+func (s *Services) WriteStakerDelegationsCsv(
+	ctx context.Context, w io.Writer, stakerPk string, filter *StakerDelegationsFilter,
+	sort *StakerDelegationsSort, delegations []DelegationPublic, nextKey string,
+) error {
+	_, pageSize := s.cfg.PageSizeLimits()
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(stakerDelegationsCsvHeader); err != nil {
+		return err
+	}
+	for {
+		for _, d := range delegations {
+			record := []string{
+				d.StakingTxHashHex,
+				d.State,
+				strconv.FormatUint(d.StakingValue, 10),
+				strconv.FormatUint(d.StakingTx.StartHeight, 10),
+				d.FinalityProviderPkHex,
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		if nextKey == "" {
+			return nil
+		}
+		var err *types.Error
+		delegations, nextKey, err = s.DelegationsByStakerPk(ctx, stakerPk, filter, sort, nextKey, pageSize)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// CreateStakerDelegationsExport creates a job exporting all the delegations of
+// the staker matching the filter as CSV, which is generated in the background.
+// An empty staker pk results in an export without any delegation.
+func (s *Services) CreateStakerDelegationsExport(
+	ctx context.Context, stakerPk string, filter *StakerDelegationsFilter, sort *StakerDelegationsSort,
+) (*ExportJobPublic, *types.Error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while generating the export job id")
+		return nil, types.NewInternalServiceError(err)
+	}
+	job := &stakerDelegationsExportJob{
+		ctx:       context.WithoutCancel(ctx),
+		jobId:     hex.EncodeToString(idBytes),
+		expiresAt: time.Now().Add(exportJobTtl),
+		stakerPk:  stakerPk,
+		filter:    filter,
+		sort:      sort,
+	}
+	if err := s.saveExportJob(ctx, job.jobId, ExportJobPending, job.expiresAt); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while saving the export job")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	select {
+	case s.exportJobs <- job:
+	default:
+		if err := s.Cache.Delete(ctx, exportJobCacheKeyPrefix+job.jobId); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("error while deleting the rejected export job")
+		}
+		return nil, types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.ServiceUnavailable,
+			"too many pending exports, please retry later",
+		)
+	}
+	return &ExportJobPublic{
+		JobId:     job.jobId,
+		Status:    ExportJobPending,
+		ExpiresAt: utils.ParseTimestampToIsoFormat(job.expiresAt.Unix()),
+	}, nil
+}
+
+// GetExportJob returns the export job identified by the job id. It returns a
+// not found error if the job does not exist or has expired.
+func (s *Services) GetExportJob(ctx context.Context, jobId string) (*ExportJobPublic, *types.Error) {
+	jobBytes, found, err := s.Cache.Get(ctx, exportJobCacheKeyPrefix+jobId)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching the export job")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if !found {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "export job not found")
+	}
+	var job exportJobDocument
+	if err := json.Unmarshal(jobBytes, &job); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while decoding the export job")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &ExportJobPublic{
+		JobId:     jobId,
+		Status:    job.Status,
+		ExpiresAt: utils.ParseTimestampToIsoFormat(job.ExpiresAt),
+	}, nil
+}
+
+// GetExportFile returns the file generated by the export job. It returns a
+// conflict error if the job has not completed.
+func (s *Services) GetExportFile(ctx context.Context, jobId string) ([]byte, *types.Error) {
+	job, err := s.GetExportJob(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != ExportJobCompleted {
+		return nil, types.NewErrorWithMsg(
+			http.StatusConflict, types.Conflict, "export job is "+string(job.Status),
+		)
+	}
+	file, found, cacheErr := s.Cache.Get(ctx, exportFileCacheKeyPrefix+jobId)
+	if cacheErr != nil {
+		log.Ctx(ctx).Error().Err(cacheErr).Msg("error while fetching the export file")
+		return nil, types.NewInternalServiceError(cacheErr)
+	}
+	if !found {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "export file not found")
+	}
+	return file, nil
+}
+
+// startExportWorkers starts the workers generating the exports of the jobs
+// in the background, for the lifetime of the process.
+func (s *Services) startExportWorkers() {
+	s.exportJobs = make(chan *stakerDelegationsExportJob, maxPendingExportJobs)
+	for i := 0; i < exportWorkers; i++ {
+		go func() {
+			for job := range s.exportJobs {
+				s.runStakerDelegationsExport(job)
+			}
+		}()
+	}
+}
+
+func (s *Services) runStakerDelegationsExport(job *stakerDelegationsExportJob) {
+	ctx, cancel := context.WithTimeout(job.ctx, exportJobTimeout)
+	defer cancel()
+
+	status := ExportJobCompleted
+	if err := s.generateStakerDelegationsExport(ctx, job); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("jobId", job.jobId).Msg("error while generating the export")
+		status = ExportJobFailed
+	}
+	// Saved even if the export timed out, for the job not to stay pending
+	if err := s.saveExportJob(job.ctx, job.jobId, status, job.expiresAt); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("jobId", job.jobId).Msg("error while saving the export job")
+	}
+}
+
+func (s *Services) generateStakerDelegationsExport(ctx context.Context, job *stakerDelegationsExportJob) error {
+	var delegations []DelegationPublic
+	var nextKey string
+	if job.stakerPk != "" {
+		_, pageSize := s.cfg.PageSizeLimits()
+		var err *types.Error
+		delegations, nextKey, err = s.DelegationsByStakerPk(ctx, job.stakerPk, job.filter, job.sort, "", pageSize)
+		if err != nil {
+			return err
+		}
+	}
+	var file bytes.Buffer
+	err := s.WriteStakerDelegationsCsv(ctx, &file, job.stakerPk, job.filter, job.sort, delegations, nextKey)
+	if err != nil {
+		return err
+	}
+	return s.setUntilExpiry(ctx, exportFileCacheKeyPrefix+job.jobId, file.Bytes(), job.expiresAt)
+}
+
+// saveExportJob stores the state of the export job until it expires
+func (s *Services) saveExportJob(
+	ctx context.Context, jobId string, status ExportJobStatus, expiresAt time.Time,
+) error {
+	jobBytes, err := json.Marshal(exportJobDocument{Status: status, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return err
+	}
+	return s.setUntilExpiry(ctx, exportJobCacheKeyPrefix+jobId, jobBytes, expiresAt)
+}
+
+// setUntilExpiry caches the value until expiresAt, nothing is cached if it
+// has already passed as a zero ttl would never expire.
+func (s *Services) setUntilExpiry(ctx context.Context, key string, value []byte, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.Cache.Set(ctx, key, value, ttl)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
@@ -34,6 +35,20 @@ type FpDetailsPublic struct {
 	TotalTvl          int64                `json:"total_tvl"`
 	ActiveDelegations int64                `json:"active_delegations"`
 	TotalDelegations  int64                `json:"total_delegations"`
+	SelfStake         int64                `json:"self_stake"`
+	// Only available when sorting by the number of active stakers
+	ActiveStakerCount *int64 `json:"active_staker_count,omitempty"`
+}
+
+type FpDelegationCountPublic struct {
+	ActiveDelegations int64 `json:"active_delegations"`
+	ActiveStakerCount int64 `json:"active_staker_count"`
+}
+
+type FpStakeStatsPublic struct {
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	TotalStake            int64  `json:"total_stake"`
+	ActiveStakerCount     int64  `json:"active_staker_count"`
 }
 
 type FpParamsPublic struct {
@@ -63,35 +78,98 @@ func (s *Services) GetFinalityProvidersFromGlobalParams() []*FpParamsPublic {
 	return fpDetails
 }
 
-func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*FpDetailsPublic, string, *types.Error) {
+// filterFpParamsByName keeps the finality providers whose moniker contains
+// the given name, ignoring the case
+func filterFpParamsByName(fpParams []*FpParamsPublic, name string) []*FpParamsPublic {
+	name = strings.ToLower(name)
+	var filtered []*FpParamsPublic
+	for _, fp := range fpParams {
+		if fp.Description != nil && strings.Contains(strings.ToLower(fp.Description.Moniker), name) {
+			filtered = append(filtered, fp)
+		}
+	}
+	return filtered
+}
+
+type FpSortBy string
+
+const (
+	FpSortByActiveTvl         FpSortBy = "active_tvl"
+	FpSortByActiveStakerCount FpSortBy = "active_staker_count"
+	FpSortBySelfStake         FpSortBy = "self_stake"
+)
+
+// FinalityProvidersFilter narrows down the listed finality providers.
+// The zero value of each field means no filtering on it.
+type FinalityProvidersFilter struct {
+	// Only supported when sorting by self stake
+	MinSelfStake int64
+	// Case-insensitive substring of the moniker
+	Name string
+}
+
+// GetFinalityProviders returns a page of finality providers sorted by sortBy.
+// The monikers are only known for the registered finality providers, so the
+// name filter narrows them down before the finality providers are paginated.
+// The page is cut short at the configured max unpaginated results if it would
+// exceed it, in which case truncated is set and the returned pagination token
+// resumes right after the last returned finality provider.
+func (s *Services) GetFinalityProviders(
+	ctx context.Context, page string, sortBy FpSortBy, filter *FinalityProvidersFilter,
+) (fps []*FpDetailsPublic, paginationToken string, truncated bool, err *types.Error) {
 	fpParams := s.GetFinalityProvidersFromGlobalParams()
 	if len(fpParams) == 0 {
 		log.Ctx(ctx).Error().Msg("No finality providers found from global params")
-		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "No finality providers found from global params")
+		return nil, "", false, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "No finality providers found from global params")
+	}
+	// nil means the finality providers are not restricted
+	var fpPkHexes []string
+	if filter != nil && filter.Name != "" {
+		fpParams = filterFpParamsByName(fpParams, filter.Name)
+		if len(fpParams) == 0 {
+			return []*FpDetailsPublic{}, "", false, nil
+		}
+		fpPkHexes = make([]string, 0, len(fpParams))
+		for _, fp := range fpParams {
+			fpPkHexes = append(fpPkHexes, fp.BtcPk)
+		}
 	}
 	// Convert the fpParams slice to a map with the BtcPk as the key
 	fpParamsMap := make(map[string]*FpParamsPublic)
 	for _, fp := range fpParams {
 		fpParamsMap[fp.BtcPk] = fp
 	}
+	switch sortBy {
+	case FpSortByActiveStakerCount:
+		return s.getFinalityProvidersByActiveStakerCount(ctx, page, fpPkHexes, fpParams, fpParamsMap)
+	case FpSortBySelfStake:
+		var minSelfStake int64
+		if filter != nil {
+			minSelfStake = filter.MinSelfStake
+		}
+		return s.getFinalityProvidersBySelfStake(ctx, page, minSelfStake, fpPkHexes, fpParams, fpParamsMap)
+	}
 
-	resultMap, err := s.DbClient.FindFinalityProviderStats(ctx, page)
-	if err != nil {
-		if db.IsInvalidPaginationTokenError(err) {
-			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality providers")
-			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+	resultMap, dbErr := s.DbClient.FindFinalityProviderStats(ctx, page, fpPkHexes)
+	if dbErr != nil {
+		if db.IsInvalidPaginationTokenError(dbErr) {
+			log.Ctx(ctx).Warn().Err(dbErr).Msg("Invalid pagination token when fetching finality providers")
+			return nil, "", false, types.NewError(http.StatusBadRequest, types.BadRequest, dbErr)
 		}
 		// We don't want to return an error here in case of DB error.
 		// we will continue the process with the data we have from global params as a fallback.
 		// TODO: Add metric for this error and alerting
-		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers from DB")
+		log.Ctx(ctx).Error().Err(dbErr).Msg("Error while fetching finality providers from DB")
 		// Return the finality providers from global params as a fallback
-		return buildFallbackFpDetailsPublic(fpParams), "", nil
+		return buildFallbackFpDetailsPublic(fpParams), "", false, nil
 	}
 	// If no finality providers are found in the DB,
-	// return the finality providers from global params as a fallback
-	if len(resultMap.Data) == 0 {
-		return buildFallbackFpDetailsPublic(fpParams), "", nil
+	// return the finality providers from global params as a fallback.
+	// A page resumed after the last finality provider of a truncated page
+	// has no finality provider from the DB either, but only the remaining
+	// registered ones are appended to it
+	if len(resultMap.Data) == 0 && page == "" {
+		return buildFallbackFpDetailsPublic(fpParams), "", false, nil
 	}
 
 	var finalityProviderDetailsPublic []*FpDetailsPublic
@@ -123,13 +201,242 @@ func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*Fp
 		fpsNotInUse, err := s.findRegisteredFinalityProvidersNotInUse(ctx, fpParams)
 		if err != nil {
 			log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers not in use")
-			return nil, "", types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+			return nil, "", false, types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 		}
 
 		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, fpsNotInUse...)
 	}
+	finalityProviderDetailsPublic, paginationToken, truncated, err = truncateFpDetailsPublic(
+		ctx, finalityProviderDetailsPublic, resultMap, s.cfg.Server.MaxUnpaginatedResults,
+		[]byte(s.cfg.Db.PaginationTokenSecret), model.BuildFinalityProviderStatsPaginationToken,
+	)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if err := s.attachSelfStake(ctx, finalityProviderDetailsPublic); err != nil {
+		return nil, "", false, err
+	}
+
+	return finalityProviderDetailsPublic, paginationToken, truncated, nil
+}
 
-	return finalityProviderDetailsPublic, resultMap.PaginationToken, nil
+// truncateFpDetailsPublic cuts the finality providers down to the configured
+// max unpaginated results, along with the pagination token resuming right
+// after the last finality provider kept. The first finality providers are the
+// ones of the DB page in resultMap. Only those can be resumed from, hence the
+// registered finality providers appended to the last page are never cut and
+// are returned at once on the page following the DB ones.
+func truncateFpDetailsPublic[T any](
+	ctx context.Context, fps []*FpDetailsPublic, resultMap *db.DbResultMap[T], maxResults int,
+	secret []byte, buildPaginationToken func([]byte, T) (string, error),
+) ([]*FpDetailsPublic, string, bool, *types.Error) {
+	cut := min(maxResults, len(resultMap.Data))
+	if maxResults <= 0 || len(fps) <= maxResults || cut == 0 {
+		return fps, resultMap.PaginationToken, false, nil
+	}
+	paginationToken, err := buildPaginationToken(secret, resultMap.Data[cut-1])
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while building the pagination token of a truncated page")
+		return nil, "", false, types.NewInternalServiceError(err)
+	}
+	return fps[:cut], paginationToken, true, nil
+}
+
+// getFinalityProvidersByActiveStakerCount returns the finality providers sorted by
+// the number of distinct stakers with active delegations in descending order.
+// The registered finality providers without any active delegation are appended
+// to the last page.
+func (s *Services) getFinalityProvidersByActiveStakerCount(
+	ctx context.Context, page string, fpPkHexFilter []string,
+	fpParams []*FpParamsPublic, fpParamsMap map[string]*FpParamsPublic,
+) ([]*FpDetailsPublic, string, bool, *types.Error) {
+	resultMap, err := s.DbClient.FindFinalityProvidersByActiveStakerCount(ctx, page, fpPkHexFilter)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality providers")
+			return nil, "", false, types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers by active staker count")
+		return nil, "", false, types.NewInternalServiceError(err)
+	}
+
+	fpPkHexes := make([]string, 0, len(resultMap.Data))
+	for _, fp := range resultMap.Data {
+		fpPkHexes = append(fpPkHexes, fp.FinalityProviderPkHex)
+	}
+	// On the last page, also look up the registered finality providers so that
+	// the ones without active delegations can be appended
+	if resultMap.PaginationToken == "" {
+		for _, fp := range fpParams {
+			fpPkHexes = append(fpPkHexes, fp.BtcPk)
+		}
+	}
+	fpStats, err := s.DbClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, fpPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider stats")
+		return nil, "", false, types.NewInternalServiceError(err)
+	}
+	fpStatsMap := make(map[string]*model.FinalityProviderStatsDocument)
+	for _, fpStat := range fpStats {
+		fpStatsMap[fpStat.FinalityProviderPkHex] = fpStat
+	}
+
+	finalityProviderDetailsPublic := make([]*FpDetailsPublic, 0, len(resultMap.Data))
+	returnedFps := make(map[string]bool)
+	for _, fp := range resultMap.Data {
+		detail := buildFpDetailsPublic(fp.FinalityProviderPkHex, fpParamsMap, fpStatsMap)
+		activeStakerCount := fp.ActiveStakerCount
+		detail.ActiveStakerCount = &activeStakerCount
+		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, detail)
+		returnedFps[fp.FinalityProviderPkHex] = true
+	}
+	if resultMap.PaginationToken == "" {
+		for _, fp := range fpParams {
+			stats := fpStatsMap[fp.BtcPk]
+			if returnedFps[fp.BtcPk] || (stats != nil && stats.ActiveDelegations > 0) {
+				continue
+			}
+			detail := buildFpDetailsPublic(fp.BtcPk, fpParamsMap, fpStatsMap)
+			noActiveStakers := int64(0)
+			detail.ActiveStakerCount = &noActiveStakers
+			finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, detail)
+		}
+	}
+	finalityProviderDetailsPublic, paginationToken, truncated, truncateErr := truncateFpDetailsPublic(
+		ctx, finalityProviderDetailsPublic, resultMap, s.cfg.Server.MaxUnpaginatedResults,
+		[]byte(s.cfg.Db.PaginationTokenSecret), model.BuildFinalityProviderStakerCountPaginationToken,
+	)
+	if truncateErr != nil {
+		return nil, "", false, truncateErr
+	}
+	if err := s.attachSelfStake(ctx, finalityProviderDetailsPublic); err != nil {
+		return nil, "", false, err
+	}
+
+	return finalityProviderDetailsPublic, paginationToken, truncated, nil
+}
+
+// getFinalityProvidersBySelfStake returns the finality providers having self stake
+// of at least minSelfStake, sorted by their self stake in descending order.
+// Unless a minimum self stake is requested, the registered finality providers
+// without self stake are appended to the last page.
+func (s *Services) getFinalityProvidersBySelfStake(
+	ctx context.Context, page string, minSelfStake int64, fpPkHexFilter []string,
+	fpParams []*FpParamsPublic, fpParamsMap map[string]*FpParamsPublic,
+) ([]*FpDetailsPublic, string, bool, *types.Error) {
+	resultMap, err := s.DbClient.FindFinalityProvidersBySelfStake(ctx, minSelfStake, page, fpPkHexFilter)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality providers")
+			return nil, "", false, types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers by self stake")
+		return nil, "", false, types.NewInternalServiceError(err)
+	}
+	appendRegisteredFps := resultMap.PaginationToken == "" && minSelfStake <= 0
+
+	fpPkHexes := make([]string, 0, len(resultMap.Data))
+	for _, fp := range resultMap.Data {
+		fpPkHexes = append(fpPkHexes, fp.FinalityProviderPkHex)
+	}
+	if appendRegisteredFps {
+		for _, fp := range fpParams {
+			fpPkHexes = append(fpPkHexes, fp.BtcPk)
+		}
+	}
+	fpStats, err := s.DbClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, fpPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider stats")
+		return nil, "", false, types.NewInternalServiceError(err)
+	}
+	fpStatsMap := make(map[string]*model.FinalityProviderStatsDocument)
+	for _, fpStat := range fpStats {
+		fpStatsMap[fpStat.FinalityProviderPkHex] = fpStat
+	}
+
+	finalityProviderDetailsPublic := make([]*FpDetailsPublic, 0, len(resultMap.Data))
+	returnedFps := make(map[string]bool)
+	for _, fp := range resultMap.Data {
+		detail := buildFpDetailsPublic(fp.FinalityProviderPkHex, fpParamsMap, fpStatsMap)
+		detail.SelfStake = fp.SelfStake
+		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, detail)
+		returnedFps[fp.FinalityProviderPkHex] = true
+	}
+	if appendRegisteredFps {
+		// The finality providers with self stake have all been returned by now,
+		// possibly on the previous pages
+		registeredFps := make([]*FpDetailsPublic, 0, len(fpParams))
+		for _, fp := range fpParams {
+			if returnedFps[fp.BtcPk] {
+				continue
+			}
+			registeredFps = append(registeredFps, buildFpDetailsPublic(fp.BtcPk, fpParamsMap, fpStatsMap))
+		}
+		if err := s.attachSelfStake(ctx, registeredFps); err != nil {
+			return nil, "", false, err
+		}
+		for _, fp := range registeredFps {
+			if fp.SelfStake == 0 {
+				finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, fp)
+			}
+		}
+	}
+
+	finalityProviderDetailsPublic, paginationToken, truncated, truncateErr := truncateFpDetailsPublic(
+		ctx, finalityProviderDetailsPublic, resultMap, s.cfg.Server.MaxUnpaginatedResults,
+		[]byte(s.cfg.Db.PaginationTokenSecret), model.BuildFinalityProviderSelfStakePaginationToken,
+	)
+	if truncateErr != nil {
+		return nil, "", false, truncateErr
+	}
+	return finalityProviderDetailsPublic, paginationToken, truncated, nil
+}
+
+// attachSelfStake sets the self stake of the given finality providers
+func (s *Services) attachSelfStake(ctx context.Context, fps []*FpDetailsPublic) *types.Error {
+	if len(fps) == 0 {
+		return nil
+	}
+	fpPkHexes := make([]string, 0, len(fps))
+	for _, fp := range fps {
+		fpPkHexes = append(fpPkHexes, fp.BtcPk)
+	}
+	selfStakes, err := s.DbClient.FindFinalityProviderSelfStakeByFinalityProviderPkHex(ctx, fpPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider self stake")
+		return types.NewInternalServiceError(err)
+	}
+	selfStakeMap := make(map[string]int64, len(selfStakes))
+	for _, selfStake := range selfStakes {
+		selfStakeMap[selfStake.FinalityProviderPkHex] = selfStake.SelfStake
+	}
+	for _, fp := range fps {
+		fp.SelfStake = selfStakeMap[fp.BtcPk]
+	}
+	return nil
+}
+
+// buildFpDetailsPublic combines the registered finality provider details (if any)
+// with the finality provider stats (if any)
+func buildFpDetailsPublic(
+	fpPkHex string, fpParamsMap map[string]*FpParamsPublic,
+	fpStatsMap map[string]*model.FinalityProviderStatsDocument,
+) *FpDetailsPublic {
+	detail := &FpDetailsPublic{
+		Description: emptyFpDescriptionPublic,
+		BtcPk:       fpPkHex,
+	}
+	if paramsPublic := fpParamsMap[fpPkHex]; paramsPublic != nil {
+		detail.Description = paramsPublic.Description
+		detail.Commission = paramsPublic.Commission
+	}
+	if stats := fpStatsMap[fpPkHex]; stats != nil {
+		detail.ActiveTvl = stats.ActiveTvl
+		detail.TotalTvl = stats.TotalTvl
+		detail.ActiveDelegations = stats.ActiveDelegations
+		detail.TotalDelegations = stats.TotalDelegations
+	}
+	return detail
 }
 
 func (s *Services) findRegisteredFinalityProvidersNotInUse(
@@ -183,3 +490,128 @@ func buildFallbackFpDetailsPublic(fpParams []*FpParamsPublic) []*FpDetailsPublic
 	}
 	return finalityProviderDetailsPublic
 }
+
+// GetFinalityProvidersByPkHex returns the finality providers matching the given
+// pks, keyed by their pk hex. A pk is unknown, and hence omitted, if it's neither
+// a registered finality provider nor has any delegation.
+func (s *Services) GetFinalityProvidersByPkHex(
+	ctx context.Context, fpPkHexes []string,
+) (map[string]*FpDetailsPublic, *types.Error) {
+	fpParamsMap := make(map[string]*FpParamsPublic)
+	for _, fp := range s.GetFinalityProvidersFromGlobalParams() {
+		fpParamsMap[fp.BtcPk] = fp
+	}
+	fpStats, err := s.DbClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, fpPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider stats by pks")
+		return nil, types.NewInternalServiceError(err)
+	}
+	fpStatsMap := make(map[string]*model.FinalityProviderStatsDocument)
+	for _, fpStat := range fpStats {
+		fpStatsMap[fpStat.FinalityProviderPkHex] = fpStat
+	}
+
+	finalityProviders := make(map[string]*FpDetailsPublic)
+	for _, fpPkHex := range fpPkHexes {
+		if fpParamsMap[fpPkHex] == nil && fpStatsMap[fpPkHex] == nil {
+			continue
+		}
+		finalityProviders[fpPkHex] = buildFpDetailsPublic(fpPkHex, fpParamsMap, fpStatsMap)
+	}
+	fps := make([]*FpDetailsPublic, 0, len(finalityProviders))
+	for _, fp := range finalityProviders {
+		fps = append(fps, fp)
+	}
+	if err := s.attachSelfStake(ctx, fps); err != nil {
+		return nil, err
+	}
+	return finalityProviders, nil
+}
+
+// GetFinalityProvider returns the details of a single finality provider.
+// It returns a 404 error if the pk is neither a registered finality provider
+// nor has any delegation.
+func (s *Services) GetFinalityProvider(ctx context.Context, fpPkHex string) (*FpDetailsPublic, *types.Error) {
+	fps, err := s.GetFinalityProvidersByPkHex(ctx, []string{fpPkHex})
+	if err != nil {
+		return nil, err
+	}
+	fp, ok := fps[fpPkHex]
+	if !ok {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "finality provider not found")
+	}
+	return fp, nil
+}
+
+// GetFinalityProviderDelegationCounts returns the number of active delegations
+// and distinct stakers of each finality provider, keyed by their pk hex. The
+// delegations whose unbonding is requested are active until it's confirmed.
+// Finality providers without any active delegation are omitted. The overflow
+// delegations are included if requested, otherwise the configured default applies.
+func (s *Services) GetFinalityProviderDelegationCounts(
+	ctx context.Context, page string, includeOverflow *bool,
+) (map[string]*FpDelegationCountPublic, string, *types.Error) {
+	resultMap, err := s.DbClient.FindFinalityProviderDelegationCounts(ctx, page, s.overflowFilter(nil, includeOverflow))
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality provider delegation counts")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider delegation counts")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegationCounts := make(map[string]*FpDelegationCountPublic, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegationCounts[d.FinalityProviderPkHex] = &FpDelegationCountPublic{
+			ActiveDelegations: d.ActiveDelegations,
+			ActiveStakerCount: d.ActiveStakerCount,
+		}
+	}
+	return delegationCounts, resultMap.PaginationToken, nil
+}
+
+// GetFinalityProviderStakerCounts returns the number of distinct stakers with
+// an active delegation to each finality provider, keyed by their pk hex.
+// The counts come from the same grouped aggregation as the delegation counts,
+// so a page of finality providers is resolved with a single query, with the
+// same handling of the overflow delegations.
+func (s *Services) GetFinalityProviderStakerCounts(
+	ctx context.Context, page string, includeOverflow *bool,
+) (map[string]int64, string, *types.Error) {
+	resultMap, err := s.DbClient.FindFinalityProviderDelegationCounts(ctx, page, s.overflowFilter(nil, includeOverflow))
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality provider staker counts")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality provider staker counts")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	stakerCounts := make(map[string]int64, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		stakerCounts[d.FinalityProviderPkHex] = d.ActiveStakerCount
+	}
+	return stakerCounts, resultMap.PaginationToken, nil
+}
+
+// GetFinalityProviderStakeStats returns the total staking value and the number
+// of distinct stakers of the delegations to the finality provider whose BTC is
+// still locked, i.e. excluding the unbonded and withdrawn delegations.
+func (s *Services) GetFinalityProviderStakeStats(
+	ctx context.Context, fpPkHex string,
+) (*FpStakeStatsPublic, *types.Error) {
+	stats, err := s.DbClient.AggregateFinalityProviderStakeStats(ctx, fpPkHex, s.overflowFilter(&db.DelegationFilter{
+		States: lockedDelegationStates,
+	}, nil))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating the finality provider stake stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &FpStakeStatsPublic{
+		FinalityProviderPkHex: fpPkHex,
+		TotalStake:            stats.TotalStake,
+		ActiveStakerCount:     stats.StakerCount,
+	}, nil
+}
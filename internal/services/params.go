@@ -1,6 +1,14 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
@@ -24,30 +32,109 @@ type GlobalParamsPublic struct {
 	Versions []VersionedGlobalParamsPublic `json:"versions"`
 }
 
+// ContentVersion identifies the content of the params. It's derived from the
+// content only, hence stable across restarts as long as the params don't change.
+func (p *GlobalParamsPublic) ContentVersion() string {
+	// The params only hold plain values, hence marshalling them can't fail
+	content, _ := json.Marshal(p)
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:16])
+}
+
+type RangePublic struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+// StakingParamsPublic is the subset of the global params a wallet needs to
+// build a valid staking transaction.
+type StakingParamsPublic struct {
+	Version           uint64      `json:"version"`
+	Tag               string      `json:"tag"`
+	CovenantPks       []string    `json:"covenant_pks"`
+	CovenantQuorum    uint64      `json:"covenant_quorum"`
+	StakingAmount     RangePublic `json:"staking_amount"`
+	StakingTime       RangePublic `json:"staking_time"`
+	UnbondingTime     uint64      `json:"unbonding_time"`
+	UnbondingFee      uint64      `json:"unbonding_fee"`
+	ConfirmationDepth uint64      `json:"confirmation_depth"`
+}
+
+func toVersionedGlobalParamsPublic(version *types.VersionedGlobalParams) VersionedGlobalParamsPublic {
+	return VersionedGlobalParamsPublic{
+		Version:           version.Version,
+		ActivationHeight:  version.ActivationHeight,
+		StakingCap:        version.StakingCap,
+		Tag:               version.Tag,
+		CovenantPks:       version.CovenantPks,
+		CovenantQuorum:    version.CovenantQuorum,
+		UnbondingTime:     version.UnbondingTime,
+		UnbondingFee:      version.UnbondingFee,
+		MaxStakingAmount:  version.MaxStakingAmount,
+		MinStakingAmount:  version.MinStakingAmount,
+		MaxStakingTime:    version.MaxStakingTime,
+		MinStakingTime:    version.MinStakingTime,
+		ConfirmationDepth: version.ConfirmationDepth,
+	}
+}
+
 func (s *Services) GetGlobalParamsPublic() *GlobalParamsPublic {
 	var versionedParams []VersionedGlobalParamsPublic
 	for _, version := range s.params.Versions {
-		versionedParams = append(versionedParams, VersionedGlobalParamsPublic{
-			Version:           version.Version,
-			ActivationHeight:  version.ActivationHeight,
-			StakingCap:        version.StakingCap,
-			Tag:               version.Tag,
-			CovenantPks:       version.CovenantPks,
-			CovenantQuorum:    version.CovenantQuorum,
-			UnbondingTime:     version.UnbondingTime,
-			UnbondingFee:      version.UnbondingFee,
-			MaxStakingAmount:  version.MaxStakingAmount,
-			MinStakingAmount:  version.MinStakingAmount,
-			MaxStakingTime:    version.MaxStakingTime,
-			MinStakingTime:    version.MinStakingTime,
-			ConfirmationDepth: version.ConfirmationDepth,
-		})
+		versionedParams = append(versionedParams, toVersionedGlobalParamsPublic(version))
 	}
 	return &GlobalParamsPublic{
 		Versions: versionedParams,
 	}
 }
 
+// GetGlobalParamsVersionPublic returns the global params with only the given
+// version. It returns a 404 error if there is no such version.
+func (s *Services) GetGlobalParamsVersionPublic(version uint64) (*GlobalParamsPublic, *types.Error) {
+	for _, paramsVersion := range s.params.Versions {
+		if paramsVersion.Version == version {
+			return &GlobalParamsPublic{
+				Versions: []VersionedGlobalParamsPublic{toVersionedGlobalParamsPublic(paramsVersion)},
+			}, nil
+		}
+	}
+	return nil, types.NewErrorWithMsg(
+		http.StatusNotFound, types.NotFound, fmt.Sprintf("global params version %d not found", version),
+	)
+}
+
+// GetStakingParamsPublic returns the params a staking tx has to comply with
+// if it's included in the next btc block.
+func (s *Services) GetStakingParamsPublic(ctx context.Context) (*StakingParamsPublic, *types.Error) {
+	btcInfo, btcInfoErr := s.getLatestBtcInfo(ctx)
+	if btcInfoErr != nil {
+		return nil, btcInfoErr
+	}
+	params := s.GetVersionedGlobalParamsByHeight(btcInfo.BtcHeight + 1)
+	if params == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound, "no params version is active at the next btc height",
+		)
+	}
+	return &StakingParamsPublic{
+		Version:        params.Version,
+		Tag:            params.Tag,
+		CovenantPks:    params.CovenantPks,
+		CovenantQuorum: params.CovenantQuorum,
+		StakingAmount: RangePublic{
+			Min: params.MinStakingAmount,
+			Max: params.MaxStakingAmount,
+		},
+		StakingTime: RangePublic{
+			Min: params.MinStakingTime,
+			Max: params.MaxStakingTime,
+		},
+		UnbondingTime:     params.UnbondingTime,
+		UnbondingFee:      params.UnbondingFee,
+		ConfirmationDepth: params.ConfirmationDepth,
+	}, nil
+}
+
 // GetVersionedGlobalParamsByHeight returns the versioned global params
 // for a particular bitcoin height
 func (s *Services) GetVersionedGlobalParamsByHeight(height uint64) *types.VersionedGlobalParams {
@@ -62,3 +149,28 @@ func (s *Services) GetVersionedGlobalParamsByHeight(height uint64) *types.Versio
 	}
 	return nil
 }
+
+// getMinStakingAmountThresholds returns the min staking amount of each params
+// version along with the height range the version applies to
+func (s *Services) getMinStakingAmountThresholds() []db.StakingAmountThreshold {
+	thresholds := make([]db.StakingAmountThreshold, 0, len(s.params.Versions))
+	for _, paramsVersion := range s.params.Versions {
+		thresholds = append(thresholds, db.StakingAmountThreshold{
+			FromHeight: paramsVersion.ActivationHeight,
+			ToHeight:   s.getNextVersionActivationHeight(paramsVersion),
+			Amount:     paramsVersion.MinStakingAmount,
+		})
+	}
+	return thresholds
+}
+
+// getNextVersionActivationHeight returns the activation height of the params
+// version following the given one, or 0 if the given version is the latest.
+func (s *Services) getNextVersionActivationHeight(params *types.VersionedGlobalParams) uint64 {
+	for _, paramsVersion := range s.params.Versions {
+		if paramsVersion.Version > params.Version {
+			return paramsVersion.ActivationHeight
+		}
+	}
+	return 0
+}
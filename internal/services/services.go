@@ -6,6 +6,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/babylonchain/staking-api-service/internal/cache"
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/types"
@@ -15,9 +16,15 @@ import (
 // the database and other external clients (if any).
 type Services struct {
 	DbClient          db.DBClient
+	Cache             cache.Cache
 	cfg               *config.Config
 	params            *types.GlobalParams
 	finalityProviders []types.FinalityProviderDetails
+	// Readiness checks of the dependencies not owned by the services, e.g.
+	// the queues, keyed by component name
+	readinessChecks map[string]func(context.Context) error
+	// Export jobs waiting for a background worker
+	exportJobs chan *stakerDelegationsExportJob
 }
 
 func New(
@@ -31,12 +38,21 @@ func New(
 		log.Ctx(ctx).Fatal().Err(err).Msg("error while creating db client")
 		return nil, err
 	}
-	return &Services{
+	cacheClient, err := cache.New(ctx, cfg.Cache)
+	if err != nil {
+		log.Ctx(ctx).Fatal().Err(err).Msg("error while creating cache client")
+		return nil, err
+	}
+	s := &Services{
 		DbClient:          dbClient,
+		Cache:             cacheClient,
 		cfg:               cfg,
 		params:            globalParams,
 		finalityProviders: finalityProviders,
-	}, nil
+		readinessChecks:   make(map[string]func(context.Context) error),
+	}
+	s.startExportWorkers()
+	return s, nil
 }
 
 // DoHealthCheck checks the health of the services by ping the database.
@@ -44,6 +60,63 @@ func (s *Services) DoHealthCheck(ctx context.Context) error {
 	return s.DbClient.Ping(ctx)
 }
 
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+type ReadinessPublic struct {
+	// Down if any of the components is down
+	Status     HealthStatus            `json:"status"`
+	Components map[string]HealthStatus `json:"components"`
+}
+
+// AddReadinessCheck adds the health check of a dependency created alongside
+// the services, e.g. the queues, to the readiness check. It must be called
+// before the server starts serving requests.
+func (s *Services) AddReadinessCheck(component string, check func(context.Context) error) {
+	s.readinessChecks[component] = check
+}
+
+// CheckReadiness pings each of the dependencies of the service, each within
+// the configured readiness check timeout so that a hanging dependency does
+// not hold the whole check. The failures are logged rather than returned to
+// not leak internal details.
+func (s *Services) CheckReadiness(ctx context.Context) *ReadinessPublic {
+	checks := map[string]func(context.Context) error{
+		"db":    s.DbClient.Ping,
+		"cache": s.Cache.Ping,
+	}
+	for component, check := range s.readinessChecks {
+		checks[component] = check
+	}
+	readiness := &ReadinessPublic{
+		Status:     HealthStatusUp,
+		Components: make(map[string]HealthStatus, len(checks)),
+	}
+	for component, ping := range checks {
+		if err := s.pingWithTimeout(ctx, ping); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("component", component).Msg("readiness check failed")
+			readiness.Components[component] = HealthStatusDown
+			readiness.Status = HealthStatusDown
+			continue
+		}
+		readiness.Components[component] = HealthStatusUp
+	}
+	return readiness
+}
+
+func (s *Services) pingWithTimeout(ctx context.Context, ping func(context.Context) error) error {
+	if s.cfg.Server.ReadinessCheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Server.ReadinessCheckTimeout)
+		defer cancel()
+	}
+	return ping(ctx)
+}
+
 func (s *Services) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error {
 	err := s.DbClient.SaveUnprocessableMessage(ctx, messageBody, receipt)
 	if err != nil {
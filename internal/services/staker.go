@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/rs/zerolog/log"
+)
+
+// Number of days of recent activity, including today, in the staker dashboard
+const stakerDashboardActivityDays = 30
+
+type StakerDashboardPublic struct {
+	StakerPkHex string `json:"staker_pk_hex"`
+	// Staking value of the delegations whose BTC is still locked
+	TotalStake uint64 `json:"total_stake"`
+	// Staking value of the delegations that can be unbonded, i.e the active ones
+	EligibleStake uint64 `json:"eligible_stake"`
+	// Finality providers the delegations whose BTC is still locked are delegated to
+	FinalityProviderCount int64 `json:"finality_provider_count"`
+	// Rank in the top stakers by active tvl, null if the staker has no active stake
+	Rank           *int64                      `json:"rank"`
+	RecentActivity []StakerDailyActivityPublic `json:"recent_activity"`
+}
+
+// GetStakerDashboard computes the headline figures of the staker concurrently.
+// The overflow delegations follow the configured default of overflowFilter,
+// except for the rank which is in line with the rest of the stats.
+func (s *Services) GetStakerDashboard(ctx context.Context, stakerPkHex string) (*StakerDashboardPublic, *types.Error) {
+	dashboard := &StakerDashboardPublic{StakerPkHex: stakerPkHex}
+	toDate := time.Now().UTC().Truncate(24 * time.Hour)
+	fromDate := toDate.AddDate(0, 0, -stakerDashboardActivityDays+1)
+
+	components := []func() *types.Error{
+		func() *types.Error {
+			totalStake, err := s.GetStakerTotalStake(ctx, stakerPkHex)
+			if err != nil {
+				return err
+			}
+			dashboard.TotalStake = totalStake.TotalStake
+			return nil
+		},
+		func() *types.Error {
+			eligibleStake, err := s.DbClient.SumDelegationsStakingValue(ctx, s.overflowFilter(&db.DelegationFilter{
+				StakerPkHex: stakerPkHex,
+				States:      []types.DelegationState{types.Active},
+			}, nil))
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("error while summing up the staker eligible stake")
+				return types.NewInternalServiceError(err)
+			}
+			dashboard.EligibleStake = eligibleStake
+			return nil
+		},
+		func() *types.Error {
+			fpCount, err := s.DbClient.CountDistinctFinalityProviders(ctx, s.overflowFilter(&db.DelegationFilter{
+				StakerPkHex: stakerPkHex,
+				States:      lockedDelegationStates,
+			}, nil))
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("error while counting the staker finality providers")
+				return types.NewInternalServiceError(err)
+			}
+			dashboard.FinalityProviderCount = fpCount
+			return nil
+		},
+		func() *types.Error {
+			rank, _, err := s.getStakerRankByActiveTvl(ctx, stakerPkHex)
+			if err != nil {
+				return err
+			}
+			dashboard.Rank = rank
+			return nil
+		},
+		func() *types.Error {
+			activity, err := s.GetStakerDailyActivity(ctx, stakerPkHex, fromDate, toDate)
+			if err != nil {
+				return err
+			}
+			dashboard.RecentActivity = activity
+			return nil
+		},
+	}
+
+	// Each component only sets its own fields of the dashboard
+	errs := make([]*types.Error, len(components))
+	var wg sync.WaitGroup
+	for i, component := range components {
+		wg.Add(1)
+		go func(i int, component func() *types.Error) {
+			defer wg.Done()
+			errs[i] = component()
+		}(i, component)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dashboard, nil
+}
+
+type StakerLinkagePublic struct {
+	StakerPkHexes []string `json:"staker_pk_hexes"`
+	// Whether the linkage of the stakers is known at all
+	Supported bool `json:"supported"`
+	// Whether the stakers belong to the same entity, only set if supported
+	Linked *bool `json:"linked"`
+}
+
+// GetStakerLinkage tells whether the given stakers belong to the same entity.
+// Stakers are only identified by their BTC pk, as the indexer publishes no data
+// linking several pks to one entity. The linkage is hence reported as
+// unsupported, rather than as the stakers not being linked.
+func (s *Services) GetStakerLinkage(stakerPkHexes []string) *StakerLinkagePublic {
+	return &StakerLinkagePublic{
+		StakerPkHexes: stakerPkHexes,
+		Supported:     false,
+	}
+}
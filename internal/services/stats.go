@@ -2,12 +2,33 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DataCompleteness indicates whether the stats are fresh and fully computed
+type DataCompleteness string
+
+const (
+	// The stats are freshly and fully computed
+	DataComplete DataCompleteness = "complete"
+	// The stats are served from a previous computation
+	DataStale DataCompleteness = "stale"
+	// Some of the stats could not be computed and are defaulted
+	DataPartial DataCompleteness = "partial"
 )
 
 type OverallStatsPublic struct {
@@ -17,6 +38,69 @@ type OverallStatsPublic struct {
 	TotalDelegations  int64  `json:"total_delegations"`
 	TotalStakers      uint64 `json:"total_stakers"`
 	UnconfirmedTvl    uint64 `json:"unconfirmed_tvl"`
+	// Distribution of the staking value over the active delegations, refreshed
+	// periodically. Omitted if it could not be computed.
+	MedianStakingValue  *int64           `json:"median_staking_value,omitempty"`
+	P90StakingValue     *int64           `json:"p90_staking_value,omitempty"`
+	AverageStakingValue *int64           `json:"average_staking_value,omitempty"`
+	DataCompleteness    DataCompleteness `json:"data_completeness"`
+	ComputedAt          string           `json:"computed_at"`
+}
+
+// SnapshotVersion identifies the stats data the overall stats are computed
+// from. It only changes when the data does, regardless of when it's computed.
+func (s *OverallStatsPublic) SnapshotVersion() string {
+	snapshot := fmt.Sprintf(
+		"%d:%d:%d:%d:%d:%d:%s:%s:%s:%s", s.ActiveTvl, s.TotalTvl, s.ActiveDelegations,
+		s.TotalDelegations, s.TotalStakers, s.UnconfirmedTvl, formatOptionalInt64(s.MedianStakingValue),
+		formatOptionalInt64(s.P90StakingValue), formatOptionalInt64(s.AverageStakingValue), s.DataCompleteness,
+	)
+	hash := sha256.Sum256([]byte(snapshot))
+	return hex.EncodeToString(hash[:16])
+}
+
+func formatOptionalInt64(value *int64) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *value)
+}
+
+type InactiveProviderStakePublic struct {
+	ActiveTvl         int64            `json:"active_tvl"`
+	ActiveDelegations int64            `json:"active_delegations"`
+	FinalityProviders int64            `json:"finality_providers"`
+	DataCompleteness  DataCompleteness `json:"data_completeness"`
+	ComputedAt        string           `json:"computed_at"`
+}
+
+type ActiveSetStakePublic struct {
+	ActiveTvl         int64 `json:"active_tvl"`
+	ActiveDelegations int64 `json:"active_delegations"`
+	FinalityProviders int64 `json:"finality_providers"`
+}
+
+type ActiveSetStakeBreakdownPublic struct {
+	InActiveSet      ActiveSetStakePublic `json:"in_active_set"`
+	OutOfActiveSet   ActiveSetStakePublic `json:"out_of_active_set"`
+	DataCompleteness DataCompleteness     `json:"data_completeness"`
+	ComputedAt       string               `json:"computed_at"`
+}
+
+type FinalityProviderCountPublic struct {
+	// Finality providers having at least one active delegation
+	ActiveFinalityProviders int64 `json:"active_finality_providers"`
+	// Finality providers registered in the finality providers config
+	RegisteredFinalityProviders int64  `json:"registered_finality_providers"`
+	ComputedAt                  string `json:"computed_at"`
+}
+
+type TvlPublic struct {
+	ActiveTvl   int64  `json:"active_tvl"`
+	TotalLocked uint64 `json:"total_locked"`
+	// What each of the figures above accounts for, keyed by the field name
+	Definitions map[string]string `json:"definitions"`
+	ComputedAt  string            `json:"computed_at"`
 }
 
 type StakerStatsPublic struct {
@@ -27,6 +111,77 @@ type StakerStatsPublic struct {
 	TotalDelegations  int64  `json:"total_delegations"`
 }
 
+// StakerRankPublic is the rank of a staker in the top stakers by active tvl,
+// starting from 1, along with its stats
+type StakerRankPublic struct {
+	Rank int64 `json:"rank"`
+	StakerStatsPublic
+}
+
+type StakerFinalityProviderStatsPublic struct {
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	ActiveTvl             int64  `json:"active_tvl"`
+	TotalTvl              int64  `json:"total_tvl"`
+	ActiveDelegations     int64  `json:"active_delegations"`
+	TotalDelegations      int64  `json:"total_delegations"`
+}
+
+type DailyUnbondingStatsPublic struct {
+	Date              string `json:"date"`
+	UnbondingTvl      int64  `json:"unbonding_tvl"`
+	UnbondingRequests int64  `json:"unbonding_requests"`
+}
+
+// StatsGranularity is the size of the buckets of a stats time series
+type StatsGranularity string
+
+const (
+	StatsGranularityDay  StatsGranularity = "day"
+	StatsGranularityHour StatsGranularity = "hour"
+	// Weeks start on Monday, as ISO weeks
+	StatsGranularityWeek StatsGranularity = "week"
+)
+
+// Duration returns the length of a bucket of the granularity
+func (g StatsGranularity) Duration() time.Duration {
+	switch g {
+	case StatsGranularityHour:
+		return time.Hour
+	case StatsGranularityWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// bucketStart returns the start of the bucket of the granularity the UTC day
+// falls in.
+func (g StatsGranularity) bucketStart(day time.Time) time.Time {
+	if g != StatsGranularityWeek {
+		return day
+	}
+	daysSinceMonday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -daysSinceMonday)
+}
+
+// StakingHistoryPointPublic is the staking activity within a bucket starting at
+// Timestamp. TotalTvl and TotalDelegations are cumulative up to the end of the
+// bucket, with the same semantics as in the overall stats.
+type StakingHistoryPointPublic struct {
+	Timestamp        string `json:"timestamp"`
+	NewTvl           int64  `json:"new_tvl"`
+	NewDelegations   int64  `json:"new_delegations"`
+	TotalTvl         int64  `json:"total_tvl"`
+	TotalDelegations int64  `json:"total_delegations"`
+}
+
+type StakerDailyActivityPublic struct {
+	Date         string `json:"date"`
+	StakingValue int64  `json:"staking_value"`
+	Delegations  int64  `json:"delegations"`
+}
+
 // ProcessStakingStatsCalculation calculates the staking stats and updates the database.
 // This method tolerates duplicated calls, only the first call will be processed.
 func (s *Services) ProcessStakingStatsCalculation(
@@ -125,13 +280,81 @@ func (s *Services) ProcessStakingStatsCalculation(
 	return nil
 }
 
-func (s *Services) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
+// Cache keys of the last successfully computed overall stats, network-wide and
+// scoped to the allowlisted finality providers respectively
+const (
+	overallStatsCacheKey            = "stats:overall"
+	allowlistedOverallStatsCacheKey = "stats:overall:allowlisted"
+)
+
+// GetOverallStats computes the overall stats. If the computation does not complete
+// within the configured deadline, the last computed stats are returned instead,
+// marked as stale. A 503 error is returned if there are no computed stats yet.
+// If respectAllowlist is set, all the stats only account for the delegations to
+// the finality providers in the finality providers config.
+func (s *Services) GetOverallStats(ctx context.Context, respectAllowlist bool) (*OverallStatsPublic, *types.Error) {
+	cacheKey := overallStatsCacheKey
+	if respectAllowlist {
+		cacheKey = allowlistedOverallStatsCacheKey
+	}
+
+	computeCtx := ctx
+	if timeout := s.cfg.Server.StatsComputationTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		computeCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	stats, err := s.computeOverallStats(computeCtx, respectAllowlist)
+	if err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) && !mongo.IsTimeout(err) {
+			return nil, types.NewInternalServiceError(err)
+		}
+		log.Ctx(ctx).Warn().Err(err).Msg("overall stats computation timed out, falling back to the cached stats")
+		return s.getCachedOverallStats(ctx, cacheKey)
+	}
+
+	statsBytes, err := json.Marshal(stats)
+	if err == nil {
+		err = s.Cache.Set(ctx, cacheKey, statsBytes, 0)
+	}
+	if err != nil {
+		// The fresh stats are still served, only the fallback is affected
+		log.Ctx(ctx).Error().Err(err).Msg("error while caching overall stats")
+	}
+	return stats, nil
+}
+
+func (s *Services) getCachedOverallStats(ctx context.Context, cacheKey string) (*OverallStatsPublic, *types.Error) {
+	statsBytes, found, err := s.Cache.Get(ctx, cacheKey)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching cached overall stats")
+	}
+	if err != nil || !found {
+		return nil, types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.ServiceUnavailable,
+			"overall stats are not available at the moment, please retry",
+		)
+	}
+	var stats OverallStatsPublic
+	if err := json.Unmarshal(statsBytes, &stats); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while decoding cached overall stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	stats.DataCompleteness = DataStale
+	return &stats, nil
+}
+
+func (s *Services) computeOverallStats(ctx context.Context, respectAllowlist bool) (*OverallStatsPublic, error) {
+	if respectAllowlist {
+		return s.computeAllowlistedOverallStats(ctx)
+	}
 	stats, err := s.DbClient.GetOverallStats(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
-		return nil, types.NewInternalServiceError(err)
+		return nil, err
 	}
 	unconfirmedTvl := uint64(0)
+	completeness := DataComplete
 	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
 	if err != nil {
 		// Handle missing BTC information, which may occur during initial setup.
@@ -139,26 +362,133 @@ func (s *Services) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *t
 		// after processing new BTC blocks, all subsequent requests will be served with the correct value.
 		if db.IsNotFoundError(err) {
 			log.Ctx(ctx).Error().Err(err).Msg("latest btc info not found")
+			completeness = DataPartial
 		} else {
 			log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
-			return nil, types.NewInternalServiceError(err)
+			return nil, err
 		}
 	} else {
 		unconfirmedTvl = btcInfo.UnconfirmedTvl
 	}
 
-	return &OverallStatsPublic{
+	overallStats := &OverallStatsPublic{
 		ActiveTvl:         stats.ActiveTvl,
 		TotalTvl:          stats.TotalTvl,
 		ActiveDelegations: stats.ActiveDelegations,
 		TotalDelegations:  stats.TotalDelegations,
 		TotalStakers:      stats.TotalStakers,
 		UnconfirmedTvl:    unconfirmedTvl,
-	}, nil
+		DataCompleteness:  completeness,
+		ComputedAt:        utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}
+	overallStats.setStakingValueDistribution(s.getStakingValueDistribution(ctx, nil))
+	return overallStats, nil
+}
+
+// computeAllowlistedOverallStats computes the overall stats over the delegations
+// to the allowlisted finality providers only. The unconfirmed tvl is reported by
+// the indexer for the whole network and cannot be scoped, so it's defaulted to 0
+// and the stats are marked as partial rather than mixing up both scopes.
+func (s *Services) computeAllowlistedOverallStats(ctx context.Context) (*OverallStatsPublic, error) {
+	fpPkHexes := make([]string, 0, len(s.finalityProviders))
+	for _, fp := range s.finalityProviders {
+		fpPkHexes = append(fpPkHexes, fp.BtcPk)
+	}
+	stats, err := s.DbClient.AggregateOverallStatsByFinalityProviders(ctx, fpPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating allowlisted overall stats")
+		return nil, err
+	}
+
+	overallStats := &OverallStatsPublic{
+		ActiveTvl:         stats.ActiveTvl,
+		TotalTvl:          stats.TotalTvl,
+		ActiveDelegations: stats.ActiveDelegations,
+		TotalDelegations:  stats.TotalDelegations,
+		TotalStakers:      stats.TotalStakers,
+		UnconfirmedTvl:    0,
+		DataCompleteness:  DataPartial,
+		ComputedAt:        utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}
+	overallStats.setStakingValueDistribution(s.getStakingValueDistribution(ctx, fpPkHexes))
+	return overallStats, nil
 }
 
-func (s *Services) GetTopStakersByActiveTvl(ctx context.Context, pageToken string) ([]StakerStatsPublic, string, *types.Error) {
-	resultMap, err := s.DbClient.FindTopStakersByTvl(ctx, pageToken)
+// Cache keys of the staking value distributions, network-wide and scoped to
+// the allowlisted finality providers respectively, and how long they are
+// served from the cache before being aggregated again
+const (
+	stakingValueDistributionCacheKey            = "stats:distribution"
+	allowlistedStakingValueDistributionCacheKey = "stats:distribution:allowlisted"
+	stakingValueDistributionCacheTtl            = 10 * time.Minute
+)
+
+// getStakingValueDistribution returns the staking value distribution over the
+// active delegations to the given finality providers, or all of them if nil.
+// The aggregation runs over all the matching delegations, hence its result is
+// cached for stakingValueDistributionCacheTtl. It's best effort: nil is
+// returned if it cannot be computed, e.g. on MongoDB servers older than 7.0,
+// so that it never fails the overall stats.
+func (s *Services) getStakingValueDistribution(
+	ctx context.Context, finalityProviderPkHex []string,
+) *model.StakingValueDistribution {
+	cacheKey := stakingValueDistributionCacheKey
+	if finalityProviderPkHex != nil {
+		cacheKey = allowlistedStakingValueDistributionCacheKey
+	}
+	distributionBytes, found, err := s.Cache.Get(ctx, cacheKey)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while fetching the cached staking value distribution")
+	}
+	if found {
+		var distribution model.StakingValueDistribution
+		err := json.Unmarshal(distributionBytes, &distribution)
+		if err == nil {
+			return &distribution
+		}
+		log.Ctx(ctx).Warn().Err(err).Msg("error while decoding the cached staking value distribution")
+	}
+
+	distribution, err := s.DbClient.AggregateStakingValueDistribution(ctx, finalityProviderPkHex)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while aggregating the staking value distribution, omitting it")
+		return nil
+	}
+	// Nothing is cached until there is an active delegation, so that the
+	// first ones are accounted for right away
+	if *distribution == (model.StakingValueDistribution{}) {
+		return distribution
+	}
+	distributionBytes, err = json.Marshal(distribution)
+	if err == nil {
+		err = s.Cache.Set(ctx, cacheKey, distributionBytes, stakingValueDistributionCacheTtl)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while caching the staking value distribution")
+	}
+	return distribution
+}
+
+// setStakingValueDistribution sets the staking value distribution fields,
+// which are omitted if the distribution is nil.
+func (o *OverallStatsPublic) setStakingValueDistribution(distribution *model.StakingValueDistribution) {
+	if distribution == nil {
+		return
+	}
+	median := int64(math.Round(distribution.Median))
+	p90 := int64(math.Round(distribution.P90))
+	average := int64(math.Round(distribution.Average))
+	o.MedianStakingValue = &median
+	o.P90StakingValue = &p90
+	o.AverageStakingValue = &average
+}
+
+// GetTopStakersByActiveTvl returns a page of the stakers in descending order of
+// active tvl. A limit of 0 falls back to the configured page size.
+func (s *Services) GetTopStakersByActiveTvl(
+	ctx context.Context, pageToken string, limit int64,
+) ([]StakerStatsPublic, string, *types.Error) {
+	resultMap, err := s.DbClient.FindTopStakersByTvl(ctx, pageToken, limit)
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("invalid pagination token while fetching top stakers by active tvl")
@@ -191,3 +521,328 @@ func (s *Services) ProcessBtcInfoStats(
 	}
 	return nil
 }
+
+// aggregateStakeByActiveSet sums up the active stake of the finality providers
+// in the active set, i.e registered in the finality providers config, and of
+// the ones outside of it.
+func (s *Services) aggregateStakeByActiveSet(
+	ctx context.Context,
+) (inActiveSet, outOfActiveSet model.FinalityProviderStakeAggregate, err error) {
+	activeFpPkHexes := make([]string, 0, len(s.finalityProviders))
+	for _, fp := range s.finalityProviders {
+		activeFpPkHexes = append(activeFpPkHexes, fp.BtcPk)
+	}
+	aggregates, err := s.DbClient.AggregateFinalityProviderStatsByMembership(ctx, activeFpPkHexes)
+	if err != nil {
+		return inActiveSet, outOfActiveSet, err
+	}
+	// A group is omitted if none of its finality providers has active stake
+	for _, aggregate := range aggregates {
+		if aggregate.IsMember {
+			inActiveSet = aggregate.FinalityProviderStakeAggregate
+		} else {
+			outOfActiveSet = aggregate.FinalityProviderStakeAggregate
+		}
+	}
+	return inActiveSet, outOfActiveSet, nil
+}
+
+// GetInactiveProviderStake returns the total active stake delegated to the
+// finality providers outside of the active set, i.e the ones that are not
+// registered in the finality providers config.
+func (s *Services) GetInactiveProviderStake(ctx context.Context) (*InactiveProviderStakePublic, *types.Error) {
+	_, inactive, err := s.aggregateStakeByActiveSet(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating inactive finality provider stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &InactiveProviderStakePublic{
+		ActiveTvl:         inactive.ActiveTvl,
+		ActiveDelegations: inactive.ActiveDelegations,
+		FinalityProviders: inactive.FinalityProviders,
+		DataCompleteness:  DataComplete,
+		ComputedAt:        utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}, nil
+}
+
+// GetActiveSetStakeBreakdown returns the total active stake split by whether
+// the finality provider it's delegated to is in the active set, i.e registered
+// in the finality providers config.
+func (s *Services) GetActiveSetStakeBreakdown(ctx context.Context) (*ActiveSetStakeBreakdownPublic, *types.Error) {
+	inActiveSet, outOfActiveSet, err := s.aggregateStakeByActiveSet(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating finality provider stats by active set membership")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &ActiveSetStakeBreakdownPublic{
+		InActiveSet:      toActiveSetStakePublic(inActiveSet),
+		OutOfActiveSet:   toActiveSetStakePublic(outOfActiveSet),
+		DataCompleteness: DataComplete,
+		ComputedAt:       utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}, nil
+}
+
+func toActiveSetStakePublic(aggregate model.FinalityProviderStakeAggregate) ActiveSetStakePublic {
+	return ActiveSetStakePublic{
+		ActiveTvl:         aggregate.ActiveTvl,
+		ActiveDelegations: aggregate.ActiveDelegations,
+		FinalityProviders: aggregate.FinalityProviders,
+	}
+}
+
+const (
+	finalityProviderCountCacheKey = "stats:finality-provider-count"
+	finalityProviderCountCacheTTL = 30 * time.Second
+)
+
+// GetFinalityProviderCount returns the number of distinct finality providers
+// having active stake network-wide. The result is cached for a short while as
+// it's expected to be polled by the network overview pages.
+func (s *Services) GetFinalityProviderCount(ctx context.Context) (*FinalityProviderCountPublic, *types.Error) {
+	cached, found, err := s.Cache.Get(ctx, finalityProviderCountCacheKey)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while fetching the finality provider count from cache")
+	}
+	if found {
+		var count FinalityProviderCountPublic
+		unmarshalErr := json.Unmarshal(cached, &count)
+		if unmarshalErr == nil {
+			return &count, nil
+		}
+		log.Ctx(ctx).Warn().Err(unmarshalErr).Msg("invalid finality provider count in cache")
+	}
+
+	inActiveSet, outOfActiveSet, err := s.aggregateStakeByActiveSet(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating finality provider stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	count := &FinalityProviderCountPublic{
+		ActiveFinalityProviders:     inActiveSet.FinalityProviders + outOfActiveSet.FinalityProviders,
+		RegisteredFinalityProviders: int64(len(s.finalityProviders)),
+		ComputedAt:                  utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}
+	countBytes, err := json.Marshal(count)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while marshalling the finality provider count")
+		return count, nil
+	}
+	if err := s.Cache.Set(ctx, finalityProviderCountCacheKey, countBytes, finalityProviderCountCacheTTL); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while caching the finality provider count")
+	}
+	return count, nil
+}
+
+// lockedDelegationStates are the states of the delegations whose staked BTC
+// is still locked by the staking or the unbonding timelock.
+var lockedDelegationStates = []types.DelegationState{
+	types.Active, types.UnbondingRequested, types.Unbonding,
+}
+
+// GetTvl returns the active TVL along with the total amount of BTC locked in
+// the staking and unbonding outputs, with the definition of each of them.
+func (s *Services) GetTvl(ctx context.Context) (*TvlPublic, *types.Error) {
+	stats, err := s.DbClient.GetOverallStats(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	activeTvl := stats.ActiveTvl
+	activeTvlDefinition := "Sum of the staking value of the delegations that are active or " +
+		"have requested unbonding, excluding the overflow delegations and the ones whose " +
+		"unbonding tx is confirmed or which have ended"
+	if s.cfg.Server.TvlExcludeInactiveProviders {
+		_, inactive, err := s.aggregateStakeByActiveSet(ctx)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("error while aggregating inactive finality provider stats")
+			return nil, types.NewInternalServiceError(err)
+		}
+		activeTvl -= inactive.ActiveTvl
+		activeTvlDefinition += ", as well as the ones delegated to finality providers outside of the active set"
+	}
+
+	totalLocked, err := s.DbClient.SumDelegationsStakingValue(ctx, &db.DelegationFilter{
+		States: lockedDelegationStates,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while summing up the locked staking value")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &TvlPublic{
+		ActiveTvl:   activeTvl,
+		TotalLocked: totalLocked,
+		Definitions: map[string]string{
+			"active_tvl": activeTvlDefinition,
+			"total_locked": "Sum of the staking value of the delegations whose BTC is still locked " +
+				"by the staking or the unbonding timelock, including the overflow delegations",
+		},
+		ComputedAt: utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}, nil
+}
+
+// GetStakerFinalityProviderStats returns the stats of the staker's delegations
+// to the given finality provider.
+func (s *Services) GetStakerFinalityProviderStats(
+	ctx context.Context, stakerPkHex, fpPkHex string,
+) (*StakerFinalityProviderStatsPublic, *types.Error) {
+	stats, err := s.DbClient.AggregateStakerFinalityProviderStats(ctx, stakerPkHex, fpPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating staker stats for the finality provider")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &StakerFinalityProviderStatsPublic{
+		StakerPkHex:           stakerPkHex,
+		FinalityProviderPkHex: fpPkHex,
+		ActiveTvl:             stats.ActiveTvl,
+		TotalTvl:              stats.TotalTvl,
+		ActiveDelegations:     stats.ActiveDelegations,
+		TotalDelegations:      stats.TotalDelegations,
+	}, nil
+}
+
+// GetDailyUnbondingStats returns per UTC day the number and the total staking
+// value of the delegations which started unbonding, for each day within
+// [fromDate, toDate]. Days without any unbonding are reported with zero values.
+func (s *Services) GetDailyUnbondingStats(
+	ctx context.Context, fromDate, toDate time.Time,
+) ([]DailyUnbondingStatsPublic, *types.Error) {
+	dailyStats, err := s.DbClient.AggregateDailyUnbondingStats(
+		ctx, fromDate.Unix(), toDate.AddDate(0, 0, 1).Unix(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating daily unbonding stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	statsByDate := make(map[string]model.DailyUnbondingStats, len(dailyStats))
+	for _, d := range dailyStats {
+		statsByDate[d.Date] = d
+	}
+
+	var result []DailyUnbondingStatsPublic
+	for day := fromDate; !day.After(toDate); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		d := statsByDate[date]
+		result = append(result, DailyUnbondingStatsPublic{
+			Date:              date,
+			UnbondingTvl:      d.UnbondingTvl,
+			UnbondingRequests: d.UnbondingRequests,
+		})
+	}
+	return result, nil
+}
+
+// GetStakingHistory returns the staking activity of the non-overflow delegations
+// bucketed by the granularity within [from, to), from being a UTC day. For the
+// week granularity, the range starts from the week of from so that the first
+// bucket is complete. Only the buckets in which any delegation started staking
+// are returned, so a range without any activity results in an empty series.
+// Active tvl and delegations are not part of the history as the end of a
+// delegation is not kept with a timestamp.
+func (s *Services) GetStakingHistory(
+	ctx context.Context, from, to time.Time, granularity StatsGranularity,
+) ([]StakingHistoryPointPublic, *types.Error) {
+	from = granularity.bucketStart(from)
+	history, err := s.DbClient.AggregateStakingHistory(
+		ctx, from.Unix(), to.Unix(), int64(granularity.Duration().Seconds()),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating staking history")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	totalTvl := history.StakingValueBefore
+	totalDelegations := history.DelegationsBefore
+	result := make([]StakingHistoryPointPublic, 0, len(history.Buckets))
+	for _, b := range history.Buckets {
+		totalTvl += b.StakingValue
+		totalDelegations += b.Delegations
+		result = append(result, StakingHistoryPointPublic{
+			Timestamp:        time.Unix(b.BucketStart, 0).UTC().Format(time.RFC3339),
+			NewTvl:           b.StakingValue,
+			NewDelegations:   b.Delegations,
+			TotalTvl:         totalTvl,
+			TotalDelegations: totalDelegations,
+		})
+	}
+	return result, nil
+}
+
+// GetStakerRank returns the rank of the staker in the top stakers by active tvl.
+// It returns a not found error if the staker has no active stake.
+func (s *Services) GetStakerRank(ctx context.Context, stakerPkHex string) (*StakerRankPublic, *types.Error) {
+	rank, stakerStats, err := s.getStakerRankByActiveTvl(ctx, stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+	if rank == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staker has no active stake")
+	}
+	return &StakerRankPublic{
+		Rank: *rank,
+		StakerStatsPublic: StakerStatsPublic{
+			StakerPkHex:       stakerStats.StakerPkHex,
+			ActiveTvl:         stakerStats.ActiveTvl,
+			TotalTvl:          stakerStats.TotalTvl,
+			ActiveDelegations: stakerStats.ActiveDelegations,
+			TotalDelegations:  stakerStats.TotalDelegations,
+		},
+	}, nil
+}
+
+// getStakerRankByActiveTvl returns the rank of the staker in the top stakers
+// by active tvl, starting from 1, along with its stats. A nil rank is returned
+// if the staker has no active stake.
+func (s *Services) getStakerRankByActiveTvl(
+	ctx context.Context, stakerPkHex string,
+) (*int64, *model.StakerStatsDocument, *types.Error) {
+	stakerStats, err := s.DbClient.FindStakerStatsByStakerPk(ctx, stakerPkHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching staker stats")
+		return nil, nil, types.NewInternalServiceError(err)
+	}
+	if stakerStats.ActiveTvl <= 0 {
+		return nil, stakerStats, nil
+	}
+	rankedAbove, err := s.DbClient.CountStakersRankedAboveByActiveTvl(ctx, stakerStats)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting the stakers ranked above")
+		return nil, nil, types.NewInternalServiceError(err)
+	}
+	rank := rankedAbove + 1
+	return &rank, stakerStats, nil
+}
+
+// GetStakerDailyActivity returns per UTC day the number and the total staking
+// value of the delegations created by the staker, for each day within
+// [fromDate, toDate]. Days without any delegation are reported with zero values.
+func (s *Services) GetStakerDailyActivity(
+	ctx context.Context, stakerPkHex string, fromDate, toDate time.Time,
+) ([]StakerDailyActivityPublic, *types.Error) {
+	dailyActivity, err := s.DbClient.AggregateStakerDailyActivity(
+		ctx, stakerPkHex, fromDate.Unix(), toDate.AddDate(0, 0, 1).Unix(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while aggregating staker daily activity")
+		return nil, types.NewInternalServiceError(err)
+	}
+	activityByDate := make(map[string]model.StakerDailyActivity, len(dailyActivity))
+	for _, d := range dailyActivity {
+		activityByDate[d.Date] = d
+	}
+
+	var result []StakerDailyActivityPublic
+	for day := fromDate; !day.After(toDate); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		d := activityByDate[date]
+		result = append(result, StakerDailyActivityPublic{
+			Date:         date,
+			StakingValue: d.StakingValue,
+			Delegations:  d.Delegations,
+		})
+	}
+	return result, nil
+}
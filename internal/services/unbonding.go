@@ -2,19 +2,234 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+// Average time in seconds between two BTC blocks, used to estimate when the
+// unbonding timelock of a delegation is going to elapse.
+const averageBtcBlockTimeInSeconds = 600
+
+const (
+	unbondingIdempotencyCacheKeyPrefix = "idempotency:unbonding:"
+	// Outcome of an unbonding request is replayed for retries within the TTL
+	unbondingIdempotencyTTL = 24 * time.Hour
+	// Upper bound of the processing of an unbonding request, a retry arriving
+	// meanwhile is rejected rather than processed concurrently. The lock is
+	// released once processed, it only expires if the instance dies meanwhile.
+	unbondingIdempotencyLockTTL = 30 * time.Second
+)
+
+type UnbondingDelegationPublic struct {
+	DelegationPublic
+	RemainingBlocks              uint64 `json:"remaining_blocks"`
+	EstimatedCompletionTimestamp string `json:"estimated_completion_timestamp"`
+}
+
+type UnbondingSortBy string
+
+const (
+	UnbondingSortByStartHeight     UnbondingSortBy = "start_height"
+	UnbondingSortByRemainingBlocks UnbondingSortBy = "remaining_blocks"
+)
+
+// remainingUnbondingBlocks returns the number of BTC blocks left until the
+// unbonding timelock of the delegation elapses, based on the given btc height.
+func remainingUnbondingBlocks(d model.DelegationDocument, btcHeight uint64) uint64 {
+	if d.UnbondingTx == nil {
+		return 0
+	}
+	expireHeight := d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock
+	if btcHeight >= expireHeight {
+		return 0
+	}
+	return expireHeight - btcHeight
+}
+
+// UnbondingDelegations returns the delegations that have started unbonding but
+// whose unbonding timelock has not yet elapsed, along with the number of blocks
+// and the estimated time remaining until the unbonding completes.
+// They are sorted by the unbonding start height, or by the remaining blocks
+// in ascending order if requested.
+func (s *Services) UnbondingDelegations(
+	ctx context.Context, includeOverflow *bool, minConfirmations uint64,
+	sortBy UnbondingSortBy, pageToken string,
+) ([]UnbondingDelegationPublic, string, *types.Error) {
+	btcInfo, btcInfoErr := s.getLatestBtcInfo(ctx)
+	if btcInfoErr != nil {
+		return nil, "", btcInfoErr
+	}
+	extraFilter := s.overflowFilter(nil, includeOverflow)
+	extraFilter = s.minDisplayConfirmationsFilter(extraFilter, minConfirmations, btcInfo.BtcHeight)
+	findUnbondingDelegations := s.DbClient.FindUnbondingDelegations
+	if sortBy == UnbondingSortByRemainingBlocks {
+		findUnbondingDelegations = s.DbClient.FindUnbondingDelegationsByExpireHeight
+	}
+	resultMap, err := findUnbondingDelegations(ctx, extraFilter, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching unbonding delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find unbonding delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	now := time.Now()
+	delegations := make([]UnbondingDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		remaining := remainingUnbondingBlocks(d, btcInfo.BtcHeight)
+		completionTime := now.Add(time.Duration(remaining*averageBtcBlockTimeInSeconds) * time.Second)
+		delegations = append(delegations, UnbondingDelegationPublic{
+			DelegationPublic:             s.fromDelegationDocument(d),
+			RemainingBlocks:              remaining,
+			EstimatedCompletionTimestamp: utils.ParseTimestampToIsoFormat(completionTime.Unix()),
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// StakerUnbondingRequestPublic is an unbonding request of a staker along with
+// the current state of the delegation it unbonds. The unbonding start timestamp
+// is only set once the unbonding tx is confirmed.
+type StakerUnbondingRequestPublic struct {
+	StakingTxHashHex        string `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex      string `json:"unbonding_tx_hash_hex"`
+	StakingValue            uint64 `json:"staking_value"`
+	State                   string `json:"state"`
+	RequestedTimestamp      string `json:"requested_timestamp"`
+	UnbondingStartTimestamp string `json:"unbonding_start_timestamp,omitempty"`
+}
+
+// GetStakerUnbondingRequests returns the unbonding requests submitted for the
+// delegations of the staker, the most recent first. Only the requests whose
+// delegation is in one of the given states are returned, unless states is nil.
+func (s *Services) GetStakerUnbondingRequests(
+	ctx context.Context, stakerPkHex string, states []types.DelegationState, pageToken string,
+) ([]StakerUnbondingRequestPublic, string, *types.Error) {
+	resultMap, err := s.DbClient.FindStakerUnbondingRequests(ctx, stakerPkHex, states, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching staker unbonding requests")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find staker unbonding requests")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	requests := make([]StakerUnbondingRequestPublic, 0, len(resultMap.Data))
+	for _, r := range resultMap.Data {
+		request := StakerUnbondingRequestPublic{
+			StakingTxHashHex:   r.StakingTxHashHex,
+			UnbondingTxHashHex: r.UnbondingTxHashHex,
+			StakingValue:       r.StakingAmount,
+			State:              r.State.ToString(),
+			RequestedTimestamp: utils.ParseTimestampToIsoFormat(r.Id.Timestamp().Unix()),
+		}
+		if r.UnbondingStartTimestamp != 0 {
+			request.UnbondingStartTimestamp = utils.ParseTimestampToIsoFormat(r.UnbondingStartTimestamp)
+		}
+		requests = append(requests, request)
+	}
+	return requests, resultMap.PaginationToken, nil
+}
+
+// getLatestBtcInfo returns the latest btc info, or a retryable error if the
+// btc height has not been indexed yet.
+func (s *Services) getLatestBtcInfo(ctx context.Context) (*model.BtcInfo, *types.Error) {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found")
+			return nil, types.NewErrorWithMsg(
+				http.StatusServiceUnavailable, types.InternalServiceError,
+				"latest btc height is not available yet, please retry",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return btcInfo, nil
+}
+
+// UnbondingDryRunPublic is the outcome an unbonding request would have, had it
+// not been a dry run.
+type UnbondingDryRunPublic struct {
+	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string `json:"unbonding_tx_hash_hex"`
+	// State the delegation would transition to
+	State string `json:"state"`
+}
+
 // UnbondDelegation verifies the unbonding request and saves the unbonding tx into the DB.
 // It returns an error if the delegation is not eligible for unbonding or if the unbonding request is invalid.
 // If successful, it will change the delegation state to `unbonding_requested`
 func (s *Services) UnbondDelegation(
+	ctx context.Context,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) *types.Error {
+	if err := s.verifyUnbondDelegation(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+	); err != nil {
+		return err
+	}
+
+	// 3. save unbonding tx into DB
+	err := s.DbClient.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
+	if err != nil {
+		if ok := db.IsDuplicateKeyError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
+			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+		} else if ok := db.IsNotFoundError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
+			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	return nil
+}
+
+// ValidateUnbondDelegation runs the same checks as UnbondDelegation, i.e the
+// eligibility of the delegation and the verification of the unbonding tx and
+// signature, without saving anything. It returns the outcome the request
+// would have, or the error UnbondDelegation would return.
+func (s *Services) ValidateUnbondDelegation(
+	ctx context.Context,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) (*UnbondingDryRunPublic, *types.Error) {
+	if err := s.verifyUnbondDelegation(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+	); err != nil {
+		return nil, err
+	}
+	return &UnbondingDryRunPublic{
+		StakingTxHashHex:   stakingTxHashHex,
+		UnbondingTxHashHex: unbondingTxHashHex,
+		State:              types.UnbondingRequested.ToString(),
+	}, nil
+}
+
+// verifyUnbondDelegation checks the delegation is eligible for unbonding and
+// verifies the unbonding request against it.
+func (s *Services) verifyUnbondDelegation(
 	ctx context.Context,
 	stakingTxHashHex,
 	unbondingTxHashHex,
@@ -60,41 +275,195 @@ func (s *Services) UnbondDelegation(
 		s.cfg.Server.BTCNetParam,
 	); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("did not pass unbonding request verification")
-		return types.NewError(http.StatusForbidden, types.ValidationError, err)
+		if errors.Is(err, utils.ErrInvalidUnbondingSignature) {
+			return types.NewError(http.StatusBadRequest, types.InvalidSignature, err)
+		}
+		return types.NewError(http.StatusBadRequest, types.ValidationError, err)
 	}
+	return nil
+}
 
-	// 3. save unbonding tx into DB
-	err = s.DbClient.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
+// unbondingIdempotencyRecord is the outcome of an unbonding request stored
+// under its idempotency key. A zero status code means the request was accepted.
+type unbondingIdempotencyRecord struct {
+	RequestHash string          `json:"request_hash"`
+	StatusCode  int             `json:"status_code,omitempty"`
+	ErrorCode   types.ErrorCode `json:"error_code,omitempty"`
+	Message     string          `json:"message,omitempty"`
+}
+
+func (r *unbondingIdempotencyRecord) toError() *types.Error {
+	if r.StatusCode == 0 {
+		return nil
+	}
+	return types.NewErrorWithMsg(r.StatusCode, r.ErrorCode, r.Message)
+}
+
+func (s *Services) getUnbondingIdempotencyRecord(
+	ctx context.Context, idempotencyKey string,
+) (*unbondingIdempotencyRecord, error) {
+	recordBytes, found, err := s.Cache.Get(ctx, unbondingIdempotencyCacheKeyPrefix+idempotencyKey)
+	if err != nil || !found {
+		return nil, err
+	}
+	var record unbondingIdempotencyRecord
+	if err := json.Unmarshal(recordBytes, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// replayUnbondingIdempotencyRecord returns the outcome of the original request,
+// or a conflict error if the idempotency key was used for a different request.
+func replayUnbondingIdempotencyRecord(
+	ctx context.Context, record *unbondingIdempotencyRecord, requestHash string,
+) *types.Error {
+	if record.RequestHash != requestHash {
+		log.Ctx(ctx).Warn().Msg("idempotency key reused with a different unbonding request")
+		return types.NewErrorWithMsg(
+			http.StatusConflict, types.Conflict,
+			"idempotency key has already been used for a different unbonding request",
+		)
+	}
+	return record.toError()
+}
+
+// UnbondDelegationWithIdempotencyKey processes the unbonding request once per
+// idempotency key. A retry with the same key and request gets the outcome of
+// the original one instead of processing it again, whereas a retry with the
+// same key but a different request is rejected with a conflict error. Outcomes
+// are stored in the cache, if it's unavailable the request is processed as is.
+func (s *Services) UnbondDelegationWithIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) *types.Error {
+	hash := sha256.Sum256([]byte(strings.Join(
+		[]string{stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex}, ":",
+	)))
+	requestHash := hex.EncodeToString(hash[:])
+
+	record, err := s.getUnbondingIdempotencyRecord(ctx, idempotencyKey)
 	if err != nil {
-		if ok := db.IsDuplicateKeyError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
-		} else if ok := db.IsNotFoundError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+		log.Ctx(ctx).Warn().Err(err).Msg("error while fetching the unbonding idempotency record")
+	}
+	if record != nil {
+		return replayUnbondingIdempotencyRecord(ctx, record, requestHash)
+	}
+	lockKey := unbondingIdempotencyCacheKeyPrefix + idempotencyKey + ":lock"
+	inFlight, err := s.Cache.Incr(ctx, lockKey, unbondingIdempotencyLockTTL)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while locking the unbonding idempotency key")
+	} else if inFlight == 1 {
+		// Released whatever the outcome, so that a retry is not rejected as a
+		// concurrent request until the lock expires. Once the outcome is stored,
+		// the retries get it before taking the lock.
+		defer func() {
+			if err := s.Cache.Delete(ctx, lockKey); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("error while releasing the unbonding idempotency key")
+			}
+		}()
+		// The original request may have completed and released the lock since
+		// the first lookup
+		if record, err := s.getUnbondingIdempotencyRecord(ctx, idempotencyKey); err == nil && record != nil {
+			return replayUnbondingIdempotencyRecord(ctx, record, requestHash)
 		}
-		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	} else {
+		// The original request may have completed in the meantime
+		if record, err := s.getUnbondingIdempotencyRecord(ctx, idempotencyKey); err == nil && record != nil {
+			return replayUnbondingIdempotencyRecord(ctx, record, requestHash)
+		}
+		return types.NewErrorWithMsg(
+			http.StatusConflict, types.Conflict,
+			"a request with the same idempotency key is being processed, please retry",
+		)
+	}
+
+	unbondErr := s.UnbondDelegation(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
+	// Internal errors are transient, the retries should be processed again
+	if unbondErr != nil && unbondErr.StatusCode >= http.StatusInternalServerError {
+		return unbondErr
+	}
+	record = &unbondingIdempotencyRecord{RequestHash: requestHash}
+	if unbondErr != nil {
+		record.StatusCode = unbondErr.StatusCode
+		record.ErrorCode = unbondErr.ErrorCode
+		record.Message = unbondErr.Err.Error()
+	}
+	recordBytes, err := json.Marshal(record)
+	if err == nil {
+		err = s.Cache.Set(ctx, unbondingIdempotencyCacheKeyPrefix+idempotencyKey, recordBytes, unbondingIdempotencyTTL)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error while storing the unbonding idempotency record")
+	}
+	return unbondErr
+}
+
+// UnbondingIneligibilityReason is the machine readable reason a delegation is
+// not eligible for unbonding
+type UnbondingIneligibilityReason string
+
+const (
+	DelegationNotFound        UnbondingIneligibilityReason = "DELEGATION_NOT_FOUND"
+	UnbondingAlreadyRequested UnbondingIneligibilityReason = "UNBONDING_ALREADY_REQUESTED"
+	DelegationUnbonding       UnbondingIneligibilityReason = "DELEGATION_UNBONDING"
+	DelegationEnded           UnbondingIneligibilityReason = "DELEGATION_ENDED"
+)
+
+// UnbondingEligibilityPublic tells whether a delegation is eligible for
+// unbonding. The reason and the message are only set if it's not.
+type UnbondingEligibilityPublic struct {
+	Eligible bool                         `json:"eligible"`
+	Reason   UnbondingIneligibilityReason `json:"reason,omitempty"`
+	Message  string                       `json:"message,omitempty"`
+}
+
+func newIneligibleForUnbonding(reason UnbondingIneligibilityReason, message string) *UnbondingEligibilityPublic {
+	return &UnbondingEligibilityPublic{Reason: reason, Message: message}
+}
+
+// unbondingEligibilityByState tells whether a delegation in the given state is
+// eligible for unbonding, only the active ones are.
+func unbondingEligibilityByState(state types.DelegationState) *UnbondingEligibilityPublic {
+	switch state {
+	case types.Active:
+		return &UnbondingEligibilityPublic{Eligible: true}
+	case types.UnbondingRequested:
+		return newIneligibleForUnbonding(
+			UnbondingAlreadyRequested, "unbonding has already been requested for the delegation",
+		)
+	case types.Unbonding:
+		return newIneligibleForUnbonding(DelegationUnbonding, "the delegation is already unbonding")
+	default:
+		return newIneligibleForUnbonding(
+			DelegationEnded, fmt.Sprintf("the delegation has already ended, its state is %s", state),
+		)
 	}
-	return nil
 }
 
-func (s *Services) IsEligibleForUnbondingRequest(ctx context.Context, stakingTxHashHex string) *types.Error {
+// GetUnbondingEligibility tells whether the delegation is eligible for an
+// unbonding request, and if not, why.
+func (s *Services) GetUnbondingEligibility(
+	ctx context.Context, stakingTxHashHex string,
+) (*UnbondingEligibilityPublic, *types.Error) {
 	delegationDoc, err := s.DbClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
 		if ok := db.IsNotFoundError(err); ok {
 			log.Ctx(ctx).Warn().Err(err).Msg("delegation not found, hence not eligible for unbonding")
-			return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, "delegation not found")
+			return newIneligibleForUnbonding(DelegationNotFound, "delegation not found"), nil
 		}
-		log.Error().Err(err).Msg("error while fetching delegation")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegation")
+		return nil, types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
 
-	if delegationDoc.State != types.Active {
-		log.Ctx(ctx).Warn().Msg("delegation state is not active, hence not eligible for unbonding")
-		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not active")
+	eligibility := unbondingEligibilityByState(delegationDoc.State)
+	if !eligibility.Eligible {
+		log.Ctx(ctx).Warn().Str("reason", string(eligibility.Reason)).Msg("delegation is not eligible for unbonding")
 	}
-	return nil
+	return eligibility, nil
 }
 
 // TransitionToUnbondingState process the actual confirmed unbonding tx by updating the delegation state to `unbonding`
@@ -12,6 +12,11 @@ const (
 	Withdrawn          DelegationState = "withdrawn"
 )
 
+// DelegationStates returns all the states a delegation can be in
+func DelegationStates() []DelegationState {
+	return []DelegationState{Active, UnbondingRequested, Unbonding, Unbonded, Withdrawn}
+}
+
 func (s DelegationState) ToString() string {
 	return string(s)
 }
@@ -32,3 +37,28 @@ func FromStringToDelegationState(s string) (DelegationState, error) {
 		return "", fmt.Errorf("invalid delegation state: %s", s)
 	}
 }
+
+// UnbondingType describes how a delegation ended
+type UnbondingType string
+
+const (
+	// The staker unbonded the delegation before the staking timelock expired
+	EarlyUnbonding UnbondingType = "early_unbonding"
+	// The staking timelock of the delegation expired
+	NaturalExpiry UnbondingType = "natural_expiry"
+)
+
+func (t UnbondingType) ToString() string {
+	return string(t)
+}
+
+func FromStringToUnbondingType(s string) (UnbondingType, error) {
+	switch s {
+	case "early_unbonding":
+		return EarlyUnbonding, nil
+	case "natural_expiry":
+		return NaturalExpiry, nil
+	default:
+		return "", fmt.Errorf("invalid unbonding type: %s", s)
+	}
+}
@@ -18,6 +18,12 @@ const (
 	NotFound             ErrorCode = "NOT_FOUND"
 	BadRequest           ErrorCode = "BAD_REQUEST"
 	Forbidden            ErrorCode = "FORBIDDEN"
+	ServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+	TooManyRequests      ErrorCode = "TOO_MANY_REQUESTS"
+	Conflict             ErrorCode = "CONFLICT"
+	InvalidSignature     ErrorCode = "INVALID_SIGNATURE"
+	RequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	Unauthorized         ErrorCode = "UNAUTHORIZED"
 )
 
 // Error represents an error with an HTTP status code and an application-specific error code.
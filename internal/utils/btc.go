@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/babylonchain/babylon/btcstaking"
@@ -17,6 +18,12 @@ import (
 	"github.com/btcsuite/btcd/wire"
 )
 
+// ErrInvalidUnbondingSignature is returned by VerifyUnbondingRequest if the
+// unbonding tx is not signed by the staker over the unbonding path of the staking output
+var ErrInvalidUnbondingSignature = errors.New(
+	"unbonding signature does not match the staker public key and the staking output",
+)
+
 // GetSchnorrPkFromHex parses Schnorr public keys in 32 bytes
 func GetSchnorrPkFromHex(pkHex string) (*btcec.PublicKey, error) {
 	pkBytes, err := hex.DecodeString(pkHex)
@@ -182,7 +189,7 @@ func VerifyUnbondingRequest(
 		stakerPk,
 		sigBytes,
 	); err != nil {
-		return fmt.Errorf("invalid unbonding signature")
+		return ErrInvalidUnbondingSignature
 	}
 	return nil
 }
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+const (
+	// Version of the pagination token format, to be bumped whenever the
+	// encoding changes so that the tokens issued before are rejected
+	paginationTokenVersion byte = 1
+	// Length of the truncated HMAC-SHA256 appended to the pagination token
+	paginationTokenMacSize = 16
+)
+
+var ErrInvalidPaginationToken = errors.New("invalid pagination token")
+
+func paginationTokenMac(secret, versionedPayload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(versionedPayload)
+	return mac.Sum(nil)[:paginationTokenMacSize]
+}
+
+// EncodePaginationToken encodes the pagination payload as the token version,
+// followed by the payload and a MAC of both keyed with the secret, so that
+// clients cannot forge it. The token is base64 encoded with the URL safe alphabet.
+func EncodePaginationToken(secret, payload []byte) string {
+	tokenBytes := append([]byte{paginationTokenVersion}, payload...)
+	tokenBytes = append(tokenBytes, paginationTokenMac(secret, tokenBytes)...)
+	return base64.URLEncoding.EncodeToString(tokenBytes)
+}
+
+// DecodePaginationToken returns the payload of a token issued by
+// EncodePaginationToken with the same secret. Tokens of another version or
+// secret, truncated or tampered with are rejected with ErrInvalidPaginationToken.
+func DecodePaginationToken(secret []byte, token string) ([]byte, error) {
+	tokenBytes, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPaginationToken
+	}
+	if len(tokenBytes) < 1+paginationTokenMacSize || tokenBytes[0] != paginationTokenVersion {
+		return nil, ErrInvalidPaginationToken
+	}
+	macOffset := len(tokenBytes) - paginationTokenMacSize
+	if !hmac.Equal(tokenBytes[macOffset:], paginationTokenMac(secret, tokenBytes[:macOffset])) {
+		return nil, ErrInvalidPaginationToken
+	}
+	return tokenBytes[1:macOffset], nil
+}
@@ -9,13 +9,36 @@ func ParseTimestampToIsoFormat(epochtime int64) string {
 	return t.Format(time.RFC3339)
 }
 
+// ConvertIsoTimestampToLocation converts an ISO8601 timestamp into the same
+// instant formatted in the given location.
+func ConvertIsoTimestampToLocation(isoTimestamp string, loc *time.Location) (string, error) {
+	t, err := time.Parse(time.RFC3339, isoTimestamp)
+	if err != nil {
+		return "", err
+	}
+	return t.In(loc).Format(time.RFC3339), nil
+}
+
 func GetTodayStartTimestampInSeconds() int64 {
-	// Get the current time in UTC
-	now := time.Now().UTC()
+	return GetDayStartTimestampInSeconds(time.Now())
+}
 
-	// Create a new time representing today at 12AM UTC
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+// GetDayStartTimestampInSeconds returns the Unix timestamp in seconds of 12AM
+// UTC of the day of the given time
+func GetDayStartTimestampInSeconds(t time.Time) int64 {
+	return GetDayStartTimestampInLocation(t, time.UTC)
+}
 
-	// Convert the start of today to a Unix timestamp in seconds
+// GetDayStartTimestampInLocation returns the Unix timestamp in seconds of the
+// start of the day of the given time in the given location
+func GetDayStartTimestampInLocation(t time.Time, loc *time.Location) int64 {
+	t = t.In(loc)
+	startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
 	return startOfDay.Unix()
 }
+
+// GetDaysAgoTimestampInSeconds returns the Unix timestamp in seconds of the
+// given number of days, of 24 hours each, before the given time
+func GetDaysAgoTimestampInSeconds(t time.Time, days int) int64 {
+	return t.Add(-time.Duration(days) * 24 * time.Hour).Unix()
+}
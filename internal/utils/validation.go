@@ -1,10 +1,9 @@
 package utils
 
 import (
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"regexp"
+	"strings"
 
 	bbntypes "github.com/babylonchain/babylon/types"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -34,6 +33,16 @@ func IsValidBtcAddress(btcAddress string, params *chaincfg.Params) error {
 	}
 }
 
+// IsTaprootAddress checks if the provided address is a valid Taproot address
+func IsTaprootAddress(btcAddress string, params *chaincfg.Params) bool {
+	decodedAddr, err := btcutil.DecodeAddress(btcAddress, params)
+	if err != nil {
+		return false
+	}
+	_, ok := decodedAddr.(*btcutil.AddressTaproot)
+	return ok
+}
+
 // IsValidTxHash checks if the given string is a valid BTC transaction hash
 // Note: it does not check the actual content of the hash.
 func IsValidTxHash(txHash string) bool {
@@ -42,23 +51,19 @@ func IsValidTxHash(txHash string) bool {
 	return err == nil
 }
 
-// IsBase64Encoded checks if the given string is a valid Base64 encoded string.
-// Note: it does not check the actual content of the string.
-func IsBase64Encoded(s string) bool {
-	// Check if the string length is a multiple of 4.
-	if len(s)%4 != 0 {
-		return false
+// NormalizeTxHash validates the given BTC transaction hash and returns it in the
+// canonical lowercase hex format it's stored in. An optional 0x prefix is tolerated.
+func NormalizeTxHash(txHash string) (string, error) {
+	txHash = strings.TrimPrefix(strings.TrimPrefix(txHash, "0x"), "0X")
+	if len(txHash) != chainhash.MaxHashStringSize {
+		return "", fmt.Errorf(
+			"expected %d hex characters, got %d", chainhash.MaxHashStringSize, len(txHash),
+		)
 	}
-
-	// Regular expression to match valid Base64 characters.
-	base64Regex := regexp.MustCompile(`^[a-zA-Z0-9+/]*={0,2}$`)
-	if !base64Regex.MatchString(s) {
-		return false
+	if _, err := hex.DecodeString(txHash); err != nil {
+		return "", fmt.Errorf("not a hex string")
 	}
-
-	// Try to decode the string.
-	_, err := base64.StdEncoding.DecodeString(s)
-	return err == nil
+	return strings.ToLower(txHash), nil
 }
 
 // IsValidTxHex checks if the given string is a valid BTC transaction hex
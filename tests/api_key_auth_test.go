@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const testApiKey = "test-api-key"
+
+func setupApiKeyAuthTestServer(t *testing.T, allRoutes bool) *TestServer {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	apiKeyHash := sha256.Sum256([]byte(testApiKey))
+	cfg.Server.ApiKeyHashes = []string{hex.EncodeToString(apiKeyHash[:])}
+	cfg.Server.ApiKeyAuthAllRoutes = allRoutes
+	if err := cfg.Server.Validate(); err != nil {
+		t.Fatalf("Invalid test config: %v", err)
+	}
+	return setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+}
+
+func sendApiKeyRequest(t *testing.T, method, url, apiKey string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set(middlewares.ApiKeyHeader, apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err, "making request should not fail")
+	return resp
+}
+
+func assertUnauthorized(t *testing.T, resp *http.Response) {
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response respond.ErrorResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.Unauthorized.String(), response.ErrorCode)
+}
+
+func TestApiKeyAuthOnWriteRoutes(t *testing.T) {
+	testServer := setupApiKeyAuthTestServer(t, false)
+	defer testServer.Close()
+	unbondingUrl := testServer.Server.URL + unbondingPath
+
+	for _, apiKey := range []string{"", "wrong-api-key"} {
+		resp := sendApiKeyRequest(t, http.MethodPost, unbondingUrl, apiKey)
+		defer resp.Body.Close()
+		assertUnauthorized(t, resp)
+	}
+
+	// The request is let through with the key, and rejected for its empty payload
+	resp := sendApiKeyRequest(t, http.MethodPost, unbondingUrl, testApiKey)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+
+	// The read routes stay open
+	readResp := sendApiKeyRequest(t, http.MethodGet, testServer.Server.URL+globalParamsPath, "")
+	defer readResp.Body.Close()
+	assert.Equal(t, http.StatusOK, readResp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestApiKeyAuthOnAllRoutes(t *testing.T) {
+	testServer := setupApiKeyAuthTestServer(t, true)
+	defer testServer.Close()
+	globalParamsUrl := testServer.Server.URL + globalParamsPath
+
+	resp := sendApiKeyRequest(t, http.MethodGet, globalParamsUrl, "")
+	defer resp.Body.Close()
+	assertUnauthorized(t, resp)
+
+	authorizedResp := sendApiKeyRequest(t, http.MethodGet, globalParamsUrl, testApiKey)
+	defer authorizedResp.Body.Close()
+	assert.Equal(t, http.StatusOK, authorizedResp.StatusCode, "expected HTTP 200 OK status")
+
+	unbondingResp := sendApiKeyRequest(t, http.MethodPost, testServer.Server.URL+unbondingPath, "")
+	defer unbondingResp.Body.Close()
+	assertUnauthorized(t, unbondingResp)
+
+	// The health checks don't require an API key
+	healthResp := sendApiKeyRequest(t, http.MethodGet, testServer.Server.URL+healthCheckPath, "")
+	defer healthResp.Body.Close()
+	assert.Equal(t, http.StatusOK, healthResp.StatusCode, "expected HTTP 200 OK status")
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+func TestBtcTipHeightHeader(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.BtcTipHeightHeader = true
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	url := testServer.Server.URL + overallStatsEndpoint
+
+	// Nothing has been indexed yet
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Empty(t, resp.Header.Get(middlewares.BtcTipHeightHeader))
+
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    123,
+	}})
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(url)
+	assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, "123", resp.Header.Get(middlewares.BtcTipHeightHeader))
+}
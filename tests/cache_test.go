@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/cache"
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+// testCacheBackend is a cache backend along with a way to move its clock
+// forward, so that the expiries can be tested
+type testCacheBackend struct {
+	name    string
+	cache   cache.Cache
+	advance func(d time.Duration)
+}
+
+func setupTestCacheBackends(t *testing.T) []testCacheBackend {
+	redisServer := miniredis.RunT(t)
+	redisCfg := config.CacheConfig{
+		Type: config.RedisCacheType,
+		Redis: config.RedisConfig{
+			Address: redisServer.Addr(),
+		},
+	}
+	require.NoError(t, redisCfg.Validate())
+	redisCache, err := cache.New(context.Background(), redisCfg)
+	require.NoError(t, err)
+
+	return []testCacheBackend{
+		{
+			name:    "memory",
+			cache:   cache.NewMemoryCache(),
+			advance: time.Sleep,
+		},
+		{
+			name:    "redis",
+			cache:   redisCache,
+			advance: redisServer.FastForward,
+		},
+	}
+}
+
+func TestCacheGetSet(t *testing.T) {
+	ctx := context.Background()
+	for _, backend := range setupTestCacheBackends(t) {
+		t.Run(backend.name, func(t *testing.T) {
+			_, found, err := backend.cache.Get(ctx, "missing")
+			require.NoError(t, err)
+			assert.False(t, found, "expected a missing key not to be found")
+
+			require.NoError(t, backend.cache.Set(ctx, "key", []byte("value"), 0))
+			value, found, err := backend.cache.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, []byte("value"), value)
+
+			// Setting the key again overrides the value
+			require.NoError(t, backend.cache.Set(ctx, "key", []byte("other"), 0))
+			value, _, err = backend.cache.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("other"), value)
+		})
+	}
+}
+
+func TestCacheSetTTL(t *testing.T) {
+	ctx := context.Background()
+	for _, backend := range setupTestCacheBackends(t) {
+		t.Run(backend.name, func(t *testing.T) {
+			require.NoError(t, backend.cache.Set(ctx, "expiring", []byte("value"), 100*time.Millisecond))
+			require.NoError(t, backend.cache.Set(ctx, "persistent", []byte("value"), 0))
+			_, found, err := backend.cache.Get(ctx, "expiring")
+			require.NoError(t, err)
+			assert.True(t, found, "expected the key to be found before its expiry")
+
+			backend.advance(200 * time.Millisecond)
+			_, found, err = backend.cache.Get(ctx, "expiring")
+			require.NoError(t, err)
+			assert.False(t, found, "expected the key to be expired")
+			_, found, err = backend.cache.Get(ctx, "persistent")
+			require.NoError(t, err)
+			assert.True(t, found, "expected the key without ttl not to expire")
+		})
+	}
+}
+
+func TestCacheIncr(t *testing.T) {
+	ctx := context.Background()
+	for _, backend := range setupTestCacheBackends(t) {
+		t.Run(backend.name, func(t *testing.T) {
+			for expected := int64(1); expected <= 3; expected++ {
+				count, err := backend.cache.Incr(ctx, "counter", 0)
+				require.NoError(t, err)
+				assert.Equal(t, expected, count)
+			}
+			// Counters are independent of each other
+			count, err := backend.cache.Incr(ctx, "other-counter", 0)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+		})
+	}
+}
+
+func TestCacheIncrTTL(t *testing.T) {
+	ctx := context.Background()
+	for _, backend := range setupTestCacheBackends(t) {
+		t.Run(backend.name, func(t *testing.T) {
+			count, err := backend.cache.Incr(ctx, "counter", 300*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+
+			// The subsequent increments do not extend the expiry set on creation
+			backend.advance(200 * time.Millisecond)
+			count, err = backend.cache.Incr(ctx, "counter", 300*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), count)
+
+			backend.advance(200 * time.Millisecond)
+			count, err = backend.cache.Incr(ctx, "counter", 300*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count, "expected the counter to restart once expired")
+		})
+	}
+}
+
+func TestCacheDelete(t *testing.T) {
+	ctx := context.Background()
+	for _, backend := range setupTestCacheBackends(t) {
+		t.Run(backend.name, func(t *testing.T) {
+			require.NoError(t, backend.cache.Set(ctx, "key", []byte("value"), 0))
+			_, err := backend.cache.Incr(ctx, "counter", time.Minute)
+			require.NoError(t, err)
+
+			require.NoError(t, backend.cache.Delete(ctx, "key"))
+			require.NoError(t, backend.cache.Delete(ctx, "counter"))
+			_, found, err := backend.cache.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.False(t, found, "expected the deleted key not to be found")
+			// A deleted counter starts over
+			count, err := backend.cache.Incr(ctx, "counter", time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+
+			// Deleting a missing key is a no-op
+			require.NoError(t, backend.cache.Delete(ctx, "missing"))
+		})
+	}
+}
+
+func TestRedisCacheIncrAlwaysSetsExpiry(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	redisCache := cache.NewRedisCache(config.RedisConfig{Address: redisServer.Addr(), PoolSize: 1})
+	ctx := context.Background()
+
+	_, err := redisCache.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	// The expiry is set by the same command as the increment
+	assert.Equal(t, time.Minute, redisServer.TTL("counter"))
+
+	// A counter without ttl never expires
+	_, err = redisCache.Incr(ctx, "persistent-counter", 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), redisServer.TTL("persistent-counter"))
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+func sendCompressionRequest(t *testing.T, url, acceptEncoding string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	// Setting the header explicitly disables the transparent decompression of the client
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err, "making GET request should not fail")
+	return resp
+}
+
+func TestResponseCompression(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.CompressionMinSize = 256
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	resp := sendCompressionRequest(t, testServer.Server.URL+globalParamsPath, "gzip")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	assert.Contains(t, resp.Header.Values("Vary"), "Accept-Encoding")
+	gzipReader, err := gzip.NewReader(resp.Body)
+	assert.NoError(t, err, "the response body should be gzip compressed")
+	bodyBytes, err := io.ReadAll(gzipReader)
+	assert.NoError(t, err, "decompressing response body should not fail")
+	var response handlers.PublicResponse[services.GlobalParamsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.NotEmpty(t, response.Data.Versions)
+	// The ETag of the compressed response is weak, and still matches If-None-Match
+	etag := resp.Header.Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, "W/"), "expected a weak ETag, got %s", etag)
+	req, err := http.NewRequest(http.MethodGet, testServer.Server.URL+globalParamsPath, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", etag)
+	notModifiedResp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err, "making GET request should not fail")
+	defer notModifiedResp.Body.Close()
+	assert.Equal(t, http.StatusNotModified, notModifiedResp.StatusCode, "expected HTTP 304 status")
+
+	// The responses below the min size are not compressed
+	smallResp := sendCompressionRequest(t, testServer.Server.URL+healthCheckPath, "gzip")
+	defer smallResp.Body.Close()
+	assert.Equal(t, http.StatusOK, smallResp.StatusCode)
+	assert.Empty(t, smallResp.Header.Get("Content-Encoding"))
+
+	// Neither are the responses to the clients not accepting gzip
+	for _, acceptEncoding := range []string{"identity", "gzip;q=0"} {
+		plainResp := sendCompressionRequest(t, testServer.Server.URL+globalParamsPath, acceptEncoding)
+		defer plainResp.Body.Close()
+		assert.Empty(t, plainResp.Header.Get("Content-Encoding"), "unexpected encoding for %s", acceptEncoding)
+		bodyBytes, err := io.ReadAll(plainResp.Body)
+		assert.NoError(t, err)
+		assert.True(t, json.Valid(bodyBytes), "the response body should be plain JSON")
+		assert.False(t, strings.HasPrefix(plainResp.Header.Get("ETag"), "W/"), "expected a strong ETag")
+	}
+}
+
+func TestResponseCompressionDisabled(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.DisableCompression = true
+	cfg.Server.CompressionMinSize = 256
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	resp := sendCompressionRequest(t, testServer.Server.URL+globalParamsPath, "gzip")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+}
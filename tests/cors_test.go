@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+const allowedOrigin = "https://app.example.com"
+
+func setupCorsTestServer(t *testing.T) *TestServer {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.AllowedOrigins = []string{allowedOrigin}
+	return setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+}
+
+func sendCorsRequest(t *testing.T, method, url, origin string) *http.Response {
+	req, err := http.NewRequest(method, url, nil)
+	assert.NoError(t, err, "creating request should not fail")
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err, "sending request should not fail")
+	return resp
+}
+
+func TestCorsAllowedOrigin(t *testing.T) {
+	testServer := setupCorsTestServer(t)
+	defer testServer.Close()
+
+	resp := sendCorsRequest(t, http.MethodGet, testServer.Server.URL+globalParamsPath, allowedOrigin)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	assert.Equal(t, allowedOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	// The custom response headers are readable by the browser clients
+	exposedHeaders := strings.ToLower(resp.Header.Get("Access-Control-Expose-Headers"))
+	assert.Contains(t, exposedHeaders, strings.ToLower(middlewares.RateLimitLimitHeader))
+	assert.Contains(t, exposedHeaders, strings.ToLower(middlewares.RateLimitResetHeader))
+	assert.Contains(t, exposedHeaders, strings.ToLower(middlewares.BtcTipHeightHeader))
+}
+
+func TestCorsDisallowedOrigin(t *testing.T) {
+	testServer := setupCorsTestServer(t)
+	defer testServer.Close()
+
+	resp := sendCorsRequest(t, http.MethodGet, testServer.Server.URL+globalParamsPath, "https://evil.example.com")
+	defer resp.Body.Close()
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsPreflightRequest(t *testing.T) {
+	testServer := setupCorsTestServer(t)
+	defer testServer.Close()
+
+	resp := sendCorsRequest(t, http.MethodOptions, testServer.Server.URL+globalParamsPath, allowedOrigin)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode, "expected HTTP 204 No Content status")
+	assert.Equal(t, allowedOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), http.MethodGet)
+}
+
+func TestAllowedOriginsValidation(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	validOrigins := [][]string{
+		{"*"},
+		{allowedOrigin, "http://localhost:3000"},
+		{"https://*.example.com"},
+	}
+	for _, origins := range validOrigins {
+		cfg.Server.AllowedOrigins = origins
+		assert.NoError(t, cfg.Server.Validate(), "origins %v should be valid", origins)
+	}
+	invalidOrigins := []string{
+		"app.example.com",
+		"ftp://app.example.com",
+		"https://app.example.com/path",
+		"https://app.example.com?query=1",
+		"https://*.*.example.com",
+	}
+	for _, origin := range invalidOrigins {
+		cfg.Server.AllowedOrigins = []string{origin}
+		assert.Error(t, cfg.Server.Validate(), "origin %s should be invalid", origin)
+	}
+}
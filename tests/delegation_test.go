@@ -1,10 +1,13 @@
 package tests
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,12 +15,15 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
 const (
-	delegationRouter = "/v1/delegation"
+	delegationRouter         = "/v1/delegation"
+	delegationByOutputRouter = "/v1/delegation/by-output"
+	delegationsBatchRouter   = "/v1/delegations"
 )
 
 func TestActiveStaking(t *testing.T) {
@@ -55,4 +61,288 @@ func TestActiveStaking(t *testing.T) {
 
 	// Check that the response body is as expected
 	assert.Equal(t, "unbonded", response.Data.State)
+	// The duration of an expired delegation is estimated from its timelock
+	assert.Equal(t, int64(activeStakingEvent[0].StakingTimeLock*600), response.Data.StakedDurationSeconds)
+	assert.Equal(t,
+		"https://mempool.space/signet/tx/"+activeStakingEvent[0].StakingTxHashHex,
+		response.Data.StakingTxExplorerUrl,
+	)
+}
+
+func TestStakingTxExplorerUrlOnMainnet(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.BTCNet = "mainnet"
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvent)
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + activeStakingEvent[0].StakingTxHashHex
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Mainnet is served from the root of the explorer
+	assert.Equal(t,
+		"https://mempool.space/tx/"+activeStakingEvent[0].StakingTxHashHex,
+		response.Data.StakingTxExplorerUrl,
+	)
+}
+
+func TestDelegationCapPosition(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       4,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 4),
+	})
+	// All the delegations are within the first params version, only the stake
+	// of the earlier non-overflow one fills the cap ahead of the queried one
+	activeStakingEvents[0].StakingStartHeight = 160
+	activeStakingEvents[0].IsOverflow = false
+	activeStakingEvents[1].StakingStartHeight = 150
+	activeStakingEvents[1].IsOverflow = false
+	activeStakingEvents[2].StakingStartHeight = 155
+	activeStakingEvents[2].IsOverflow = true
+	// Staked after the queried delegation
+	activeStakingEvents[3].StakingStartHeight = 170
+	activeStakingEvents[3].IsOverflow = false
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchDelegation := func(query string) services.DelegationPublic {
+		url := testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" +
+			activeStakingEvents[0].StakingTxHashHex + query
+		resp, err := http.Get(url)
+		assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+
+		var response handlers.PublicResponse[services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return response.Data
+	}
+
+	// The cap position is only computed on demand
+	delegation := fetchDelegation("")
+	assert.Nil(t, delegation.CapPosition)
+	assert.Nil(t, delegation.StakingCap)
+
+	delegation = fetchDelegation("&include_cap_position=true")
+	assert.NotNil(t, delegation.CapPosition)
+	assert.Equal(t, activeStakingEvents[1].StakingValue, *delegation.CapPosition)
+	assert.NotNil(t, delegation.StakingCap)
+	assert.Equal(t, uint64(50), *delegation.StakingCap)
+	assert.Equal(t, uint64(3), delegation.RequiredCovenantQuorum)
+}
+
+func TestGetDelegationByStakingOutput(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})[0]
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	url := fmt.Sprintf(
+		"%s%s?staker_btc_pk=%s&txid=%s&vout=%d", testServer.Server.URL, delegationByOutputRouter,
+		activeStakingEvent.StakerPkHex, activeStakingEvent.StakingTxHashHex, activeStakingEvent.StakingOutputIndex,
+	)
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by staking output should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, response.Data.StakingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingOutputIndex, response.Data.StakingTx.OutputIndex)
+
+	// A different output of the same staking tx shall not match
+	url = fmt.Sprintf(
+		"%s%s?staker_btc_pk=%s&txid=%s&vout=%d", testServer.Server.URL, delegationByOutputRouter,
+		activeStakingEvent.StakerPkHex, activeStakingEvent.StakingTxHashHex, activeStakingEvent.StakingOutputIndex+1,
+	)
+	notFoundResp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by staking output should not fail")
+	defer notFoundResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, notFoundResp.StatusCode, "expected HTTP 404 status")
+
+	// Invalid vout
+	url = fmt.Sprintf(
+		"%s%s?staker_btc_pk=%s&txid=%s&vout=abc", testServer.Server.URL, delegationByOutputRouter,
+		activeStakingEvent.StakerPkHex, activeStakingEvent.StakingTxHashHex,
+	)
+	badRequestResp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by staking output should not fail")
+	defer badRequestResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badRequestResp.StatusCode, "expected HTTP 400 status")
+}
+
+func TestDelegationTimestampsInRequestedTimezone(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})[0]
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex + "&tz=Asia/Tokyo"
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	expected := time.Unix(activeStakingEvent.StakingStartTimestamp, 0).In(tokyo).Format(time.RFC3339)
+	assert.Equal(t, expected, response.Data.StakingTx.StartTimestamp)
+
+	// Invalid timezone names shall be rejected
+	url = testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex + "&tz=Mars/Olympus"
+	badResp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status")
+}
+
+func TestGetDelegationByTxHashNormalizesTxHash(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})[0]
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	fetch := func(txHash string) (int, handlers.PublicResponse[services.DelegationPublic]) {
+		resp, err := http.Get(testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + txHash)
+		assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+		defer resp.Body.Close()
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[services.DelegationPublic]
+		json.Unmarshal(bodyBytes, &response)
+		return resp.StatusCode, response
+	}
+
+	txHash := activeStakingEvent.StakingTxHashHex
+	for _, input := range []string{strings.ToUpper(txHash), "0x" + txHash, "0X" + strings.ToUpper(txHash)} {
+		statusCode, response := fetch(input)
+		assert.Equal(t, http.StatusOK, statusCode, "expected HTTP 200 OK status for %s", input)
+		assert.Equal(t, txHash, response.Data.StakingTxHashHex)
+	}
+
+	nonHexTxHash := "zz" + txHash[2:]
+	for _, input := range []string{txHash[:63], txHash + "0", "0x" + txHash[:62], nonHexTxHash, "0x" + nonHexTxHash} {
+		statusCode, _ := fetch(input)
+		assert.Equal(t, http.StatusBadRequest, statusCode, "expected HTTP 400 status for %s", input)
+	}
+}
+
+func TestGetDelegationsByTxHashes(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       2,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	postBatch := func(txHashes []string) *http.Response {
+		payload, err := json.Marshal(handlers.DelegationsBatchRequestPayload{StakingTxHashHexes: txHashes})
+		assert.NoError(t, err)
+		resp, err := http.Post(testServer.Server.URL+delegationsBatchRouter, "application/json", bytes.NewReader(payload))
+		assert.NoError(t, err, "making POST request to delegations batch endpoint should not fail")
+		return resp
+	}
+
+	_, missingTxHash := randomBytes(r, 32)
+	resp := postBatch([]string{
+		activeStakingEvents[0].StakingTxHashHex,
+		"0x" + strings.ToUpper(activeStakingEvents[1].StakingTxHashHex),
+		missingTxHash,
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]*services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, 3, len(response.Data))
+	for _, event := range activeStakingEvents {
+		delegation := response.Data[event.StakingTxHashHex]
+		if assert.NotNil(t, delegation, "expected delegation %s to be found", event.StakingTxHashHex) {
+			assert.Equal(t, event.StakingTxHashHex, delegation.StakingTxHashHex)
+			assert.Equal(t, event.StakerPkHex, delegation.StakerPkHex)
+			// The cap position is only available when fetching a single delegation
+			assert.Nil(t, delegation.CapPosition)
+		}
+	}
+	// The hash without a matching delegation is included with a null value
+	missing, ok := response.Data[missingTxHash]
+	assert.True(t, ok)
+	assert.Nil(t, missing)
+
+	emptyResp := postBatch([]string{})
+	defer emptyResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, emptyResp.StatusCode)
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		_, tooMany[i] = randomBytes(r, 32)
+	}
+	tooManyResp := postBatch(tooMany)
+	defer tooManyResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, tooManyResp.StatusCode)
+
+	invalidResp := postBatch([]string{activeStakingEvents[0].StakingTxHashHex, "invalid"})
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
 }
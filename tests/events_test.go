@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
+)
+
+const (
+	delegationStateChangesPath = "/v1/events/delegations"
+	delegationStateHistoryPath = "/v1/delegation/history"
+)
+
+func TestDelegationStateChangesFeed(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	requestBodyBytes, err := json.Marshal(requestBody)
+	require.NoError(t, err)
+	resp, err := http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(requestBodyBytes))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	unbondingEvent := client.UnbondingStakingEvent{
+		EventType:               client.UnbondingStakingEventType,
+		StakingTxHashHex:        requestBody.StakingTxHashHex,
+		UnbondingTxHashHex:      requestBody.UnbondingTxHashHex,
+		UnbondingTxHex:          requestBody.UnbondingTxHex,
+		UnbondingTimeLock:       10,
+		UnbondingStartTimestamp: time.Now().Unix(),
+		UnbondingStartHeight:    activeStakingEvent.StakingStartHeight + 100,
+		UnbondingOutputIndex:    1,
+	}
+	err = sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(testServer.Server.URL + delegationStateChangesPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handlers.PublicResponse[[]services.DelegationStateChangePublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	require.NoError(t, err)
+
+	stateChanges := response.Data
+	require.Equal(t, 3, len(stateChanges), "expected the 3 state changes in the feed")
+	for _, stateChange := range stateChanges {
+		assert.Equal(t, activeStakingEvent.StakingTxHashHex, stateChange.StakingTxHashHex)
+		_, err = time.Parse(time.RFC3339, stateChange.Timestamp)
+		assert.NoError(t, err, "expected timestamp to be in RFC3339 format")
+	}
+
+	assert.Equal(t, "", stateChanges[0].FromState)
+	assert.Equal(t, types.Active.ToString(), stateChanges[0].ToState)
+	require.NotNil(t, stateChanges[0].BtcHeight)
+	assert.Equal(t, activeStakingEvent.StakingStartHeight, *stateChanges[0].BtcHeight)
+
+	assert.Equal(t, types.Active.ToString(), stateChanges[1].FromState)
+	assert.Equal(t, types.UnbondingRequested.ToString(), stateChanges[1].ToState)
+	assert.Nil(t, stateChanges[1].BtcHeight)
+
+	assert.Equal(t, types.UnbondingRequested.ToString(), stateChanges[2].FromState)
+	assert.Equal(t, types.Unbonding.ToString(), stateChanges[2].ToState)
+	require.NotNil(t, stateChanges[2].BtcHeight)
+	assert.Equal(t, unbondingEvent.UnbondingStartHeight, *stateChanges[2].BtcHeight)
+
+	// The last page still carries a pagination key, polling with it returns
+	// the state changes recorded since, or the same key if there are none
+	require.NotEmpty(t, response.Pagination.NextKey)
+	pollResp, err := http.Get(testServer.Server.URL + delegationStateChangesPath + "?pagination_key=" + response.Pagination.NextKey)
+	require.NoError(t, err)
+	defer pollResp.Body.Close()
+	assert.Equal(t, http.StatusOK, pollResp.StatusCode, "expected HTTP 200 OK status")
+	var pollResponse handlers.PublicResponse[[]services.DelegationStateChangePublic]
+	err = json.NewDecoder(pollResp.Body).Decode(&pollResponse)
+	require.NoError(t, err)
+	assert.Empty(t, pollResponse.Data)
+	assert.Equal(t, response.Pagination.NextKey, pollResponse.Pagination.NextKey)
+}
+
+func TestDelegationStateChangesFeedWithInvalidPaginationKey(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + delegationStateChangesPath + "?pagination_key=invalid")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func getDelegationStateHistory(t *testing.T, testServer *TestServer, stakingTxHashHex string) []services.DelegationStateChangePublic {
+	resp, err := http.Get(testServer.Server.URL + delegationStateHistoryPath + "?staking_tx_hash_hex=" + stakingTxHashHex)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handlers.PublicResponse[[]services.DelegationStateChangePublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	require.NoError(t, err)
+	return response.Data
+}
+
+func TestDelegationStateHistory(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := getTestActiveStakingEvent()
+	otherActiveStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(
+		testServer.Queues.ActiveStakingQueueClient,
+		append([]*client.ActiveStakingEvent{activeStakingEvent}, otherActiveStakingEvents...),
+	)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	requestBodyBytes, err := json.Marshal(requestBody)
+	require.NoError(t, err)
+	resp, err := http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(requestBodyBytes))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	// Only the transitions of the requested delegation are part of its history
+	stateChanges := getDelegationStateHistory(t, testServer, activeStakingEvent.StakingTxHashHex)
+	require.Equal(t, 2, len(stateChanges), "expected the creation and the unbonding request")
+	for _, stateChange := range stateChanges {
+		assert.Equal(t, activeStakingEvent.StakingTxHashHex, stateChange.StakingTxHashHex)
+	}
+	assert.Equal(t, "", stateChanges[0].FromState)
+	assert.Equal(t, types.Active.ToString(), stateChanges[0].ToState)
+	assert.Equal(t, types.Active.ToString(), stateChanges[1].FromState)
+	assert.Equal(t, types.UnbondingRequested.ToString(), stateChanges[1].ToState)
+
+	otherStateChanges := getDelegationStateHistory(t, testServer, otherActiveStakingEvents[0].StakingTxHashHex)
+	require.Equal(t, 1, len(otherStateChanges), "expected only the creation")
+	assert.Equal(t, types.Active.ToString(), otherStateChanges[0].ToState)
+
+	// The base delegation response is left unchanged
+	delegationResp, err := http.Get(testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex)
+	require.NoError(t, err)
+	defer delegationResp.Body.Close()
+	assert.Equal(t, http.StatusOK, delegationResp.StatusCode)
+	bodyBytes, err := io.ReadAll(delegationResp.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bodyBytes), "from_state")
+
+	_, unknownTxHash := randomBytes(r, 32)
+	notFoundResp, err := http.Get(testServer.Server.URL + delegationStateHistoryPath + "?staking_tx_hash_hex=" + unknownTxHash)
+	require.NoError(t, err)
+	defer notFoundResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, notFoundResp.StatusCode)
+
+	invalidResp, err := http.Get(testServer.Server.URL + delegationStateHistoryPath + "?staking_tx_hash_hex=invalid")
+	require.NoError(t, err)
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
+func TestDelegationStateHistoryBeforeStateChangesRecording(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, createdBeforeTxHash := randomBytes(r, 32)
+	_, transitionedSinceTxHash := randomBytes(r, 32)
+	stakingTx := &model.TimelockTransaction{StartHeight: 100, StartTimestamp: 1700000000}
+	mockDB := new(testmock.DBClient)
+	for _, txHash := range []string{createdBeforeTxHash, transitionedSinceTxHash} {
+		mockDB.On("FindDelegationByTxHashHex", mock.Anything, txHash).Return(&model.DelegationDocument{
+			StakingTxHashHex: txHash,
+			State:            types.UnbondingRequested,
+			StakingTx:        stakingTx,
+		}, nil)
+	}
+	mockDB.On("FindDelegationStateChangesByTxHashHex", mock.Anything, createdBeforeTxHash).
+		Return([]model.DelegationStateChangeDocument{}, nil)
+	mockDB.On("FindDelegationStateChangesByTxHashHex", mock.Anything, transitionedSinceTxHash).
+		Return([]model.DelegationStateChangeDocument{{
+			StakingTxHashHex: transitionedSinceTxHash,
+			FromState:        types.Active,
+			ToState:          types.UnbondingRequested,
+			Timestamp:        1800000000,
+		}}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	// The creation is derived from the staking tx when none is recorded
+	stateChanges := getDelegationStateHistory(t, testServer, createdBeforeTxHash)
+	require.Equal(t, 1, len(stateChanges), "expected the synthesized creation")
+	assert.True(t, stateChanges[0].Synthesized)
+	assert.Equal(t, "", stateChanges[0].FromState)
+	assert.Equal(t, types.Active.ToString(), stateChanges[0].ToState)
+	require.NotNil(t, stateChanges[0].BtcHeight)
+	assert.Equal(t, uint64(100), *stateChanges[0].BtcHeight)
+	timestamp, err := time.Parse(time.RFC3339, stateChanges[0].Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), timestamp.Unix())
+
+	// The recorded transitions follow the synthesized creation
+	stateChanges = getDelegationStateHistory(t, testServer, transitionedSinceTxHash)
+	require.Equal(t, 2, len(stateChanges), "expected the synthesized creation and the recorded transition")
+	assert.True(t, stateChanges[0].Synthesized)
+	assert.Equal(t, types.Active.ToString(), stateChanges[0].ToState)
+	assert.False(t, stateChanges[1].Synthesized)
+	assert.Equal(t, types.Active.ToString(), stateChanges[1].FromState)
+	assert.Equal(t, types.UnbondingRequested.ToString(), stateChanges[1].ToState)
+}
@@ -1,11 +1,13 @@
 package tests
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -16,12 +18,20 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
+	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
-	finalityProvidersPath = "/v1/finality-providers"
+	finalityProvidersPath      = "/v1/finality-providers"
+	finalityProvidersBatchPath = "/v1/finality-providers/batch"
+	fpDelegationCountsPath     = "/v1/finality-providers/delegation-counts"
+	fpStakerCountsPath         = "/v1/finality-providers/staker-counts"
+	fpDelegationsPath          = "/v1/finality-provider/delegations"
+	finalityProviderPath       = "/v1/finality-provider"
+	fpStakeStatsPath           = "/v1/finality-provider/stats"
 )
 
 func shouldGetFinalityProvidersSuccessfully(t *testing.T, testServer *TestServer) {
@@ -63,7 +73,7 @@ func TestGetFinalityProvidersSuccessfully(t *testing.T) {
 
 func TestGetFinalityProviderShouldNotFailInCaseOfDbFailure(t *testing.T) {
 	mockDB := new(testmock.DBClient)
-	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(nil, errors.New("just an error"))
+	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("just an error"))
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
 	shouldGetFinalityProvidersSuccessfully(t, testServer)
@@ -75,15 +85,71 @@ func TestGetFinalityProviderShouldReturnFallbackToGlobalParams(t *testing.T) {
 		PaginationToken: "",
 	}
 	mockDB := new(testmock.DBClient)
-	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedResultMap, nil)
+	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything).Return(mockedResultMap, nil)
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
 	shouldGetFinalityProvidersSuccessfully(t, testServer)
 }
 
+func TestGetFinalityProvidersShouldBeTruncatedBeyondMaxUnpaginatedResults(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpParams := generateRandomFinalityProviderDetail(t, r, 4)
+	// The last registered finality provider has no stats, hence it's appended
+	// to the last page
+	var fpStats []*model.FinalityProviderStatsDocument
+	for i, fp := range fpParams[:3] {
+		stats := generateFinalityProviderStatsDocument(r, fp.BtcPk)
+		stats.ActiveTvl = int64(3 - i)
+		fpStats = append(fpStats, stats)
+	}
+	mockDB := new(testmock.DBClient)
+	mockDB.On("FindFinalityProviderStats", mock.Anything, "", mock.Anything).
+		Return(&db.DbResultMap[*model.FinalityProviderStatsDocument]{Data: fpStats}, nil)
+	// Resumed after the last finality provider of the truncated page
+	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.MatchedBy(func(token string) bool { return token != "" }), mock.Anything).
+		Return(&db.DbResultMap[*model.FinalityProviderStatsDocument]{Data: fpStats[2:]}, nil)
+	mockDB.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, mock.Anything).Return(fpStats, nil)
+	mockDB.On("FindFinalityProviderSelfStakeByFinalityProviderPkHex", mock.Anything, mock.Anything).
+		Return([]*model.FinalityProviderSelfStakeDocument{}, nil)
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.MaxUnpaginatedResults = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{
+		MockDbClient: mockDB, ConfigOverrides: cfg, MockedFinalityProviders: fpParams,
+	})
+	defer testServer.Close()
+	fetchPage := func(paginationKey string) handlers.PublicResponse[[]services.FpDetailsPublic] {
+		resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "?pagination_key=" + paginationKey)
+		assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		var responseBody handlers.PublicResponse[[]services.FpDetailsPublic]
+		err = json.NewDecoder(resp.Body).Decode(&responseBody)
+		assert.NoError(t, err, "decoding response body should not fail")
+		return responseBody
+	}
+
+	firstPage := fetchPage("")
+	require.Equal(t, 2, len(firstPage.Data))
+	assert.NotEmpty(t, firstPage.Warning, "expected a warning for the truncated result")
+	require.NotEmpty(t, firstPage.Pagination.NextKey, "expected the truncated page to be resumable")
+
+	// No finality provider is lost by the truncation
+	secondPage := fetchPage(firstPage.Pagination.NextKey)
+	require.Equal(t, 2, len(secondPage.Data))
+	assert.Empty(t, secondPage.Warning)
+	assert.Empty(t, secondPage.Pagination.NextKey)
+	for i, fp := range append(firstPage.Data, secondPage.Data...) {
+		assert.Equal(t, fpParams[i].BtcPk, fp.BtcPk)
+	}
+}
+
 func TestGetFinalityProviderReturn4xxErrorIfPageTokenInvalid(t *testing.T) {
 	mockDB := new(testmock.DBClient)
-	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(nil, &db.InvalidPaginationTokenError{})
+	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything).Return(nil, &db.InvalidPaginationTokenError{})
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
 	url := testServer.Server.URL + finalityProvidersPath
@@ -96,6 +162,90 @@ func TestGetFinalityProviderReturn4xxErrorIfPageTokenInvalid(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
+func TestGetFinalityProvidersBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		FinalityProviders:  fpPks[:1],
+		Stakers:            generatePks(t, 1),
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	postBatch := func(pks []string) (*http.Response, error) {
+		payload, err := json.Marshal(handlers.FinalityProvidersBatchRequestPayload{FinalityProviderPkHexes: pks})
+		assert.NoError(t, err)
+		return http.Post(testServer.Server.URL+finalityProvidersBatchPath, "application/json", bytes.NewReader(payload))
+	}
+
+	// The second pk is unknown and shall be omitted
+	resp, err := postBatch(fpPks)
+	assert.NoError(t, err, "making POST request to finality providers batch endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]services.FpDetailsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, 1, len(response.Data))
+	assert.Equal(t, int64(activeStakingEvents[0].StakingValue), response.Data[fpPks[0]].ActiveTvl)
+
+	invalidResp, err := postBatch([]string{"invalid"})
+	assert.NoError(t, err, "making POST request to finality providers batch endpoint should not fail")
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
+func TestGetFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		FinalityProviders:  fpPks[:1],
+		Stakers:            generatePks(t, 1),
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + finalityProviderPath + "?finality_provider_pk_hex=" + fpPks[0])
+	assert.NoError(t, err, "making GET request to finality provider endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.FpDetailsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, fpPks[0], response.Data.BtcPk)
+	assert.Equal(t, int64(activeStakingEvents[0].StakingValue), response.Data.ActiveTvl)
+	assert.Equal(t, int64(1), response.Data.ActiveDelegations)
+
+	// A registered finality provider without any delegation is still returned
+	registeredFp := "0d2f9728abc45c0cdeefdd73f52a0e0102470e35fb689fc5bc681959a61b021f"
+	registeredResp, err := http.Get(testServer.Server.URL + finalityProviderPath + "?finality_provider_pk_hex=" + registeredFp)
+	assert.NoError(t, err)
+	defer registeredResp.Body.Close()
+	assert.Equal(t, http.StatusOK, registeredResp.StatusCode)
+
+	notFoundResp, err := http.Get(testServer.Server.URL + finalityProviderPath + "?finality_provider_pk_hex=" + fpPks[1])
+	assert.NoError(t, err)
+	defer notFoundResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, notFoundResp.StatusCode)
+
+	invalidResp, err := http.Get(testServer.Server.URL + finalityProviderPath + "?finality_provider_pk_hex=invalid")
+	assert.NoError(t, err)
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
 func FuzzGetFinalityProviderShouldReturnAllRegisteredFps(f *testing.F) {
 	attachRandomSeedsToFuzzer(f, 100)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -111,7 +261,9 @@ func FuzzGetFinalityProviderShouldReturnAllRegisteredFps(f *testing.F) {
 			Data:            append(registeredFpsStats, notRegisteredFpsStats...),
 			PaginationToken: "",
 		}
-		mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
+		mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
+		mockDB.On("FindFinalityProviderSelfStakeByFinalityProviderPkHex", mock.Anything, mock.Anything).
+			Return([]*model.FinalityProviderSelfStakeDocument{}, nil)
 
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, MockedFinalityProviders: fpParams})
 
@@ -234,6 +386,551 @@ func FuzzTestGetFinalityProviderWithPaginationResponse(f *testing.F) {
 	})
 }
 
+func TestGetFinalityProvidersSortedByActiveStakerCount(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       20,
+		FinalityProviders: generatePks(t, 5),
+		Stakers:           generatePks(t, 10),
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Db.MaxPaginationLimit = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(5 * time.Second)
+
+	// Compute the expected distinct staker count per finality provider
+	stakersByFp := make(map[string]map[string]bool)
+	for _, event := range activeStakingEvents {
+		if stakersByFp[event.FinalityProviderPkHex] == nil {
+			stakersByFp[event.FinalityProviderPkHex] = make(map[string]bool)
+		}
+		stakersByFp[event.FinalityProviderPkHex][event.StakerPkHex] = true
+	}
+
+	var paginationKey string
+	var allDataCollected []services.FpDetailsPublic
+	for {
+		url := testServer.Server.URL + finalityProvidersPath + "?sort_by=active_staker_count&pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.FpDetailsPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+
+		allDataCollected = append(allDataCollected, response.Data...)
+		if response.Pagination.NextKey == "" {
+			break
+		}
+		paginationKey = response.Pagination.NextKey
+	}
+
+	var fpsWithStakers []services.FpDetailsPublic
+	for _, fp := range allDataCollected {
+		assert.NotNil(t, fp.ActiveStakerCount)
+		if stakers, ok := stakersByFp[fp.BtcPk]; ok {
+			assert.Equal(t, int64(len(stakers)), *fp.ActiveStakerCount)
+			fpsWithStakers = append(fpsWithStakers, fp)
+		}
+	}
+	assert.Equal(t, len(stakersByFp), len(fpsWithStakers), "expected all finality providers with stakers to be returned once")
+	for i := 0; i < len(fpsWithStakers)-1; i++ {
+		current, next := fpsWithStakers[i], fpsWithStakers[i+1]
+		assert.True(t, *current.ActiveStakerCount >= *next.ActiveStakerCount)
+		if *current.ActiveStakerCount == *next.ActiveStakerCount {
+			assert.True(t, current.BtcPk < next.BtcPk, "expected ties to be sorted by pk hex")
+		}
+	}
+}
+
+func TestGetFinalityProvidersSortedByActiveStakerCountWithUnbondingRequested(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	assert.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	// The only delegation of the finality provider requests unbonding
+	resp, _ := postUnbondingRequest(
+		t, testServer, "", "", getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex),
+	)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+
+	resp, err = http.Get(testServer.Server.URL + finalityProvidersPath + "?sort_by=active_staker_count")
+	assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[[]services.FpDetailsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Its stake is still active until the unbonding tx is confirmed
+	var found bool
+	for _, fp := range response.Data {
+		if fp.BtcPk == activeStakingEvent.FinalityProviderPkHex {
+			found = true
+			assert.NotNil(t, fp.ActiveStakerCount)
+			assert.Equal(t, int64(1), *fp.ActiveStakerCount)
+		}
+	}
+	assert.True(t, found, "expected the finality provider to be returned")
+}
+
+func TestGetFinalityProviderDelegationCounts(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       20,
+		FinalityProviders: generatePks(t, 5),
+		Stakers:           generatePks(t, 10),
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Db.MaxPaginationLimit = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(5 * time.Second)
+
+	fetchAllCounts := func(query string) map[string]services.FpDelegationCountPublic {
+		var paginationKey string
+		allDataCollected := make(map[string]services.FpDelegationCountPublic)
+		for {
+			url := testServer.Server.URL + fpDelegationCountsPath + "?pagination_key=" + paginationKey + query
+			resp, err := http.Get(url)
+			assert.NoError(t, err, "making GET request to delegation counts endpoint should not fail")
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+			bodyBytes, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err, "reading response body should not fail")
+			resp.Body.Close()
+			var response handlers.PublicResponse[map[string]services.FpDelegationCountPublic]
+			err = json.Unmarshal(bodyBytes, &response)
+			assert.NoError(t, err, "unmarshalling response body should not fail")
+
+			for fpPkHex, counts := range response.Data {
+				_, duplicated := allDataCollected[fpPkHex]
+				assert.False(t, duplicated, "expected each finality provider to be returned once")
+				allDataCollected[fpPkHex] = counts
+			}
+			if response.Pagination.NextKey == "" {
+				break
+			}
+			paginationKey = response.Pagination.NextKey
+		}
+		return allDataCollected
+	}
+
+	// The overflow delegations are included by the test config, unless excluded
+	for _, includeOverflow := range []bool{true, false} {
+		// Compute the expected counts per finality provider
+		delegationsByFp := make(map[string]int64)
+		stakersByFp := make(map[string]map[string]bool)
+		for _, event := range activeStakingEvents {
+			if event.IsOverflow && !includeOverflow {
+				continue
+			}
+			delegationsByFp[event.FinalityProviderPkHex]++
+			if stakersByFp[event.FinalityProviderPkHex] == nil {
+				stakersByFp[event.FinalityProviderPkHex] = make(map[string]bool)
+			}
+			stakersByFp[event.FinalityProviderPkHex][event.StakerPkHex] = true
+		}
+
+		query := ""
+		if !includeOverflow {
+			query = "&include_overflow=false"
+		}
+		allDataCollected := fetchAllCounts(query)
+		assert.Equal(t, len(delegationsByFp), len(allDataCollected))
+		for fpPkHex, counts := range allDataCollected {
+			assert.Equal(t, delegationsByFp[fpPkHex], counts.ActiveDelegations)
+			assert.Equal(t, int64(len(stakersByFp[fpPkHex])), counts.ActiveStakerCount)
+		}
+	}
+}
+
+func TestGetFinalityProviderDelegationCountsDistinguishStakers(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 1)
+	// All the delegations are from the same staker
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  fpPks,
+		Stakers:            generatePks(t, 1),
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + fpDelegationCountsPath)
+	assert.NoError(t, err, "making GET request to delegation counts endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]services.FpDelegationCountPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Each delegation is counted, whereas the staker is only counted once
+	assert.Equal(t, int64(3), response.Data[fpPks[0]].ActiveDelegations)
+	assert.Equal(t, int64(1), response.Data[fpPks[0]].ActiveStakerCount)
+}
+
+func TestGetFinalityProviderDelegationCountsWithUnbondingRequested(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	assert.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	// The only delegation of the finality provider requests unbonding
+	resp, _ := postUnbondingRequest(
+		t, testServer, "", "", getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex),
+	)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+
+	resp, err = http.Get(testServer.Server.URL + fpDelegationCountsPath)
+	assert.NoError(t, err, "making GET request to delegation counts endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]services.FpDelegationCountPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Its stake is still active until the unbonding tx is confirmed, as for
+	// the finality providers sorted by active staker count
+	counts, ok := response.Data[activeStakingEvent.FinalityProviderPkHex]
+	if assert.True(t, ok, "expected the finality provider to be returned") {
+		assert.Equal(t, int64(1), counts.ActiveDelegations)
+		assert.Equal(t, int64(1), counts.ActiveStakerCount)
+	}
+}
+
+func TestGetFinalityProviderStakerCounts(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       20,
+		FinalityProviders: generatePks(t, 5),
+		Stakers:           generatePks(t, 10),
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Db.MaxPaginationLimit = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(5 * time.Second)
+
+	stakersByFp := make(map[string]map[string]bool)
+	for _, event := range activeStakingEvents {
+		if stakersByFp[event.FinalityProviderPkHex] == nil {
+			stakersByFp[event.FinalityProviderPkHex] = make(map[string]bool)
+		}
+		stakersByFp[event.FinalityProviderPkHex][event.StakerPkHex] = true
+	}
+
+	var paginationKey string
+	allDataCollected := make(map[string]int64)
+	for {
+		url := testServer.Server.URL + fpStakerCountsPath + "?pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err, "making GET request to staker counts endpoint should not fail")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		resp.Body.Close()
+		var response handlers.PublicResponse[map[string]int64]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+
+		for fpPkHex, count := range response.Data {
+			_, duplicated := allDataCollected[fpPkHex]
+			assert.False(t, duplicated, "expected each finality provider to be returned once")
+			allDataCollected[fpPkHex] = count
+		}
+		if response.Pagination.NextKey == "" {
+			break
+		}
+		paginationKey = response.Pagination.NextKey
+	}
+
+	assert.Equal(t, len(stakersByFp), len(allDataCollected))
+	for fpPkHex, count := range allDataCollected {
+		assert.Equal(t, int64(len(stakersByFp[fpPkHex])), count)
+	}
+
+	badResp, err := http.Get(testServer.Server.URL + fpStakerCountsPath + "?pagination_key=invalid")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestGetFinalityProviderDelegations(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        8,
+		FinalityProviders:  fpPks,
+		Stakers:            generatePks(t, 3),
+		EnforceNotOverflow: true,
+	})
+	var expectedHashes []string
+	for _, event := range activeStakingEvents {
+		if event.FinalityProviderPkHex == fpPks[0] {
+			expectedHashes = append(expectedHashes, event.StakingTxHashHex)
+		}
+	}
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Db.MaxPaginationLimit = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchAll := func(query string) []string {
+		hashes := []string{}
+		var paginationKey string
+		for {
+			url := testServer.Server.URL + fpDelegationsPath + "?finality_provider_pk_hex=" + fpPks[0] +
+				query + "&pagination_key=" + paginationKey
+			resp, err := http.Get(url)
+			assert.NoError(t, err, "making GET request to finality provider delegations endpoint should not fail")
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+			bodyBytes, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err, "reading response body should not fail")
+			resp.Body.Close()
+			var response handlers.PublicResponse[[]services.DelegationPublic]
+			err = json.Unmarshal(bodyBytes, &response)
+			assert.NoError(t, err, "unmarshalling response body should not fail")
+			for _, d := range response.Data {
+				assert.Equal(t, fpPks[0], d.FinalityProviderPkHex)
+				hashes = append(hashes, d.StakingTxHashHex)
+			}
+			if response.Pagination.NextKey == "" {
+				return hashes
+			}
+			paginationKey = response.Pagination.NextKey
+		}
+	}
+
+	assert.ElementsMatch(t, expectedHashes, fetchAll(""))
+	assert.ElementsMatch(t, expectedHashes, fetchAll("&state=active"))
+	assert.Empty(t, fetchAll("&state=unbonded"))
+
+	badResp, err := http.Get(testServer.Server.URL + fpDelegationsPath + "?finality_provider_pk_hex=invalid")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestGetFinalityProviderStakeStats(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 1)
+	stakerPks := generatePks(t, 3)
+	var activeStakingEvents []*client.ActiveStakingEvent
+	for i, numOfEvents := range []int{2, 1, 1} {
+		activeStakingEvents = append(activeStakingEvents, generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+			NumOfEvents:        numOfEvents,
+			FinalityProviders:  fpPks,
+			Stakers:            stakerPks[i : i+1],
+			EnforceNotOverflow: true,
+		})...)
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	// The second staker only has an unbonded delegation, while the first one
+	// still has an active one
+	var expiredStakingEvents []client.ExpiredStakingEvent
+	for _, e := range activeStakingEvents[1:3] {
+		expiredStakingEvents = append(expiredStakingEvents, client.NewExpiredStakingEvent(e.StakingTxHashHex, types.ActiveTxType.ToString()))
+	}
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, expiredStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + fpStakeStatsPath + "?finality_provider_pk_hex=" + fpPks[0])
+	assert.NoError(t, err, "making GET request to finality provider stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.FpStakeStatsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, fpPks[0], response.Data.FinalityProviderPkHex)
+	assert.Equal(t, int64(2), response.Data.ActiveStakerCount)
+	assert.Equal(t, int64(activeStakingEvents[0].StakingValue+activeStakingEvents[3].StakingValue), response.Data.TotalStake)
+
+	badResp, err := http.Get(testServer.Server.URL + fpStakeStatsPath + "?finality_provider_pk_hex=invalid")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestGetFinalityProvidersSortedBySelfStake(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 3)
+	// Each of the first two finality providers delegates to itself
+	var activeStakingEvents []*client.ActiveStakingEvent
+	for i, fpPk := range fpPks[:2] {
+		events := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+			NumOfEvents:        1,
+			FinalityProviders:  []string{fpPk},
+			Stakers:            []string{fpPk},
+			EnforceNotOverflow: true,
+		})
+		events[0].StakingValue = uint64(1000 * (i + 1))
+		activeStakingEvents = append(activeStakingEvents, events...)
+	}
+	// A delegation from another staker is not part of the self stake
+	activeStakingEvents = append(activeStakingEvents, generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		FinalityProviders:  fpPks[2:],
+		Stakers:            generatePks(t, 1),
+		EnforceNotOverflow: true,
+	})...)
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(query string) (int, []services.FpDetailsPublic) {
+		resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "?" + query)
+		assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+		defer resp.Body.Close()
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var responseBody handlers.PublicResponse[[]services.FpDetailsPublic]
+		json.Unmarshal(bodyBytes, &responseBody)
+		return resp.StatusCode, responseBody.Data
+	}
+
+	statusCode, fps := fetch("sort_by=self_stake&min_self_stake=1")
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 2, len(fps))
+	assert.Equal(t, fpPks[1], fps[0].BtcPk)
+	assert.Equal(t, int64(2000), fps[0].SelfStake)
+	assert.Equal(t, fpPks[0], fps[1].BtcPk)
+	assert.Equal(t, int64(1000), fps[1].SelfStake)
+
+	// The self stake is also returned when sorting by active tvl
+	statusCode, fps = fetch("")
+	assert.Equal(t, http.StatusOK, statusCode)
+	for _, fp := range fps {
+		if fp.BtcPk == fpPks[2] {
+			assert.Equal(t, int64(0), fp.SelfStake)
+		}
+		if fp.BtcPk == fpPks[0] {
+			assert.Equal(t, int64(1000), fp.SelfStake)
+		}
+	}
+
+	statusCode, _ = fetch("min_self_stake=1")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestGetFinalityProvidersFilteredByName(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	getByName := func(name string) []services.FpDetailsPublic {
+		resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "?name=" + url.QueryEscape(name))
+		assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.FpDetailsPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		assert.Empty(t, response.Pagination.NextKey)
+		return response.Data
+	}
+
+	fps := getByName("foundation 2")
+	assert.Equal(t, 1, len(fps))
+	assert.Equal(t, "Babylon Foundation 2", fps[0].Description.Moniker)
+
+	assert.Equal(t, 4, len(getByName("BABYLON")))
+
+	fps = getByName("unknown")
+	assert.NotNil(t, fps)
+	assert.Empty(t, fps)
+}
+
+func TestGetFinalityProvidersFilteredByNameBeforePaginating(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpParams := generateRandomFinalityProviderDetail(t, r, 3)
+	fpParams[1].Description.Moniker = "Matching Provider"
+
+	mockDB := new(testmock.DBClient)
+	// Only the matching finality provider is queried, so a full page of other
+	// finality providers can't push it out of the page
+	mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, []string{fpParams[1].BtcPk}).
+		Return(&db.DbResultMap[*model.FinalityProviderStatsDocument]{
+			Data: []*model.FinalityProviderStatsDocument{generateFinalityProviderStatsDocument(r, fpParams[1].BtcPk)},
+		}, nil)
+	mockDB.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, mock.Anything).
+		Return([]*model.FinalityProviderStatsDocument{}, nil)
+	mockDB.On("FindFinalityProviderSelfStakeByFinalityProviderPkHex", mock.Anything, mock.Anything).
+		Return([]*model.FinalityProviderSelfStakeDocument{}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, MockedFinalityProviders: fpParams})
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "?name=matching")
+	assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[[]services.FpDetailsPublic]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err, "decoding response body should not fail")
+	assert.Equal(t, 1, len(response.Data))
+	assert.Equal(t, fpParams[1].BtcPk, response.Data[0].BtcPk)
+	assert.Empty(t, response.Pagination.NextKey)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetFinalityProvidersReturn4xxErrorIfSortByInvalid(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	url := testServer.Server.URL + finalityProvidersPath + "?sort_by=unknown"
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to finality providers endpoint should not fail")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func FuzzGetFinalityProviderShouldNotReturnRegisteredFpWithoutStakingForPaginatedDbResponse(f *testing.F) {
 	attachRandomSeedsToFuzzer(f, 100)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -249,7 +946,9 @@ func FuzzGetFinalityProviderShouldNotReturnRegisteredFpWithoutStakingForPaginate
 			Data:            append(registeredFpsStats, notRegisteredFpsStats...),
 			PaginationToken: "abcd",
 		}
-		mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
+		mockDB.On("FindFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
+		mockDB.On("FindFinalityProviderSelfStakeByFinalityProviderPkHex", mock.Anything, mock.Anything).
+			Return([]*model.FinalityProviderSelfStakeDocument{}, nil)
 
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, MockedFinalityProviders: fpParams})
 
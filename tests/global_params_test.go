@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
@@ -18,7 +19,8 @@ import (
 )
 
 const (
-	globalParamsPath = "/v1/global-params"
+	globalParamsPath  = "/v1/global-params"
+	stakingParamsPath = "/v1/staking/params"
 )
 
 func TestGlobalParams(t *testing.T) {
@@ -78,6 +80,126 @@ func TestGlobalParams(t *testing.T) {
 	assert.Equal(t, uint64(10), versionedGlobalParam2.ConfirmationDepth)
 }
 
+func TestGlobalParamsByVersion(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	fetch := func(version string, expectedStatus int) []services.VersionedGlobalParamsPublic {
+		resp, err := http.Get(testServer.Server.URL + globalParamsPath + "?version=" + version)
+		assert.NoError(t, err, "making GET request to global params endpoint should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var responseBody handlers.PublicResponse[services.GlobalParamsPublic]
+		err = json.Unmarshal(bodyBytes, &responseBody)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return responseBody.Data.Versions
+	}
+
+	versions := fetch("1", http.StatusOK)
+	assert.Equal(t, 1, len(versions))
+	assert.Equal(t, uint64(1), versions[0].Version)
+	assert.Equal(t, uint64(200), versions[0].ActivationHeight)
+	assert.Equal(t, 4, len(versions[0].CovenantPks))
+
+	versions = fetch("0", http.StatusOK)
+	assert.Equal(t, 1, len(versions))
+	assert.Equal(t, uint64(0), versions[0].Version)
+
+	fetch("2", http.StatusNotFound)
+	fetch("latest", http.StatusBadRequest)
+}
+
+func TestGlobalParamsConditionalRequest(t *testing.T) {
+	getWithETag := func(testServer *TestServer, query, etag string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, testServer.Server.URL+globalParamsPath+query, nil)
+		assert.NoError(t, err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err, "making GET request to global params endpoint should not fail")
+		resp.Body.Close()
+		return resp
+	}
+
+	testServer := setupTestServer(t, nil)
+	resp := getWithETag(testServer, "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	resp = getWithETag(testServer, "", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode, "expected HTTP 304 status")
+
+	// A single version has its own ETag
+	resp = getWithETag(testServer, "?version=1", etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+	testServer.Close()
+
+	// The ETag is the same after a restart with the same params
+	restartedServer := setupTestServer(t, nil)
+	defer restartedServer.Close()
+	resp = getWithETag(restartedServer, "", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode, "expected HTTP 304 status")
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+}
+
+func TestStakingParams(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	fetch := func(expectedStatus int) *services.StakingParamsPublic {
+		resp, err := http.Get(testServer.Server.URL + stakingParamsPath)
+		assert.NoError(t, err, "making GET request to staking params endpoint should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var responseBody handlers.PublicResponse[services.StakingParamsPublic]
+		err = json.Unmarshal(bodyBytes, &responseBody)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return &responseBody.Data
+	}
+
+	// The btc tip height is not known yet
+	fetch(http.StatusServiceUnavailable)
+
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    150,
+	}})
+	time.Sleep(2 * time.Second)
+	params := fetch(http.StatusOK)
+	assert.Equal(t, uint64(0), params.Version)
+	assert.Equal(t, "01020304", params.Tag)
+	assert.Equal(t, 5, len(params.CovenantPks))
+	assert.Equal(t, uint64(3), params.CovenantQuorum)
+	assert.Equal(t, services.RangePublic{Min: 3000, Max: 300000}, params.StakingAmount)
+	assert.Equal(t, services.RangePublic{Min: 100, Max: 10000}, params.StakingTime)
+	assert.Equal(t, uint64(1000), params.UnbondingTime)
+	assert.Equal(t, uint64(10000), params.UnbondingFee)
+	assert.Equal(t, uint64(10), params.ConfirmationDepth)
+
+	// The next btc block activates the second version
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    199,
+	}})
+	time.Sleep(2 * time.Second)
+	params = fetch(http.StatusOK)
+	assert.Equal(t, uint64(1), params.Version)
+	assert.Equal(t, services.RangePublic{Min: 2000, Max: 200000}, params.StakingAmount)
+}
+
 var defaultParam = types.VersionedGlobalParams{
 	Version:          0,
 	ActivationHeight: 100,
@@ -1,18 +1,25 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/services"
 	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 const (
-	healthCheckPath = "/healthcheck"
+	healthCheckPath      = "/healthcheck"
+	healthCheckLivePath  = "/healthcheck/live"
+	healthCheckReadyPath = "/healthcheck/ready"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -70,6 +77,74 @@ func TestHealthCheckDBError(t *testing.T) {
 	assert.Equal(t, "{\"errorCode\":\"INTERNAL_SERVICE_ERROR\",\"message\":\"Internal service error\"}", responseBody, "expected response body to match")
 }
 
+func getReadiness(t *testing.T, testServer *TestServer, expectedStatus int) services.ReadinessPublic {
+	resp, err := http.Get(testServer.Server.URL + healthCheckReadyPath)
+	assert.NoError(t, err, "making GET request to readiness endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[services.ReadinessPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	return responseBody.Data
+}
+
+func TestHealthCheckReady(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	readiness := getReadiness(t, testServer, http.StatusOK)
+	assert.Equal(t, services.HealthStatusUp, readiness.Status)
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["db"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["cache"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["queue"])
+}
+
+func TestHealthCheckLiveAndReadyDBError(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	mockDB.On("Ping", mock.Anything).Return(io.EOF)
+
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	// The liveness does not depend on the db
+	resp, err := http.Get(testServer.Server.URL + healthCheckLivePath)
+	assert.NoError(t, err, "making GET request to liveness endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	readiness := getReadiness(t, testServer, http.StatusServiceUnavailable)
+	assert.Equal(t, services.HealthStatusDown, readiness.Status)
+	assert.Equal(t, services.HealthStatusDown, readiness.Components["db"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["cache"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["queue"])
+}
+
+func TestHealthCheckReadyTimesOutHangingDependency(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.ReadinessCheckTimeout = 100 * time.Millisecond
+	mockDB := new(testmock.DBClient)
+	// The db never answers, until the check gives up on it
+	mockDB.On("Ping", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded)
+
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	start := time.Now()
+	readiness := getReadiness(t, testServer, http.StatusServiceUnavailable)
+	assert.Less(t, time.Since(start), 5*time.Second, "expected the hanging db not to hold the readiness check")
+	assert.Equal(t, services.HealthStatusDown, readiness.Status)
+	assert.Equal(t, services.HealthStatusDown, readiness.Components["db"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["cache"])
+	assert.Equal(t, services.HealthStatusUp, readiness.Components["queue"])
+}
+
 func TestOptionsRequest(t *testing.T) {
 	testServer := setupTestServer(t, nil)
 	defer testServer.Close()
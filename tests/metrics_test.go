@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
+)
+
+func TestHttpRequestMetrics(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + globalParamsPath)
+	assert.NoError(t, err, "making GET request to global params endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	body := fetchMetrics(t, testServer)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/v1/global-params",status="200"}`)
+	assert.Contains(t, body, "http_requests_in_flight")
+}
+
+func TestIndexedBtcLagMetrics(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	// Above any height processed by the other tests, as the metrics are
+	// shared by the whole test process
+	activeStakingEvents[0].StakingStartHeight = 10_000_000
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	err = sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    10_000_005,
+	}})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	body := fetchMetrics(t, testServer)
+	assert.Contains(t, body, "\nindexed_btc_height "+strconv.FormatFloat(10_000_005, 'g', -1, 64)+"\n")
+	assert.Contains(t, body, "\nindexed_btc_block_lag 5\n")
+	assert.Contains(t, body, "indexed_btc_info_lag_seconds")
+}
+
+func TestIndexedBtcLagMetricsSeededFromPersistedState(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	// Above the heights of TestIndexedBtcLagMetrics, as the metrics are shared
+	// by the whole test process
+	mockDB.On("GetLatestBtcInfo", mock.Anything).Return(&model.BtcInfo{
+		BtcHeight: 20_000_005,
+		UpdatedAt: time.Now().Add(-time.Minute).Unix(),
+	}, nil)
+	mockDB.On("FindLatestDelegationStakingHeight", mock.Anything).Return(uint64(20_000_000), nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	// As on startup, before any event is processed
+	err := (&services.Services{DbClient: mockDB}).InitBtcLagMetrics(context.Background())
+	require.NoError(t, err)
+
+	body := fetchMetrics(t, testServer)
+	assert.Contains(t, body, "\nindexed_btc_height "+strconv.FormatFloat(20_000_005, 'g', -1, 64)+"\n")
+	assert.Contains(t, body, "\nindexed_btc_block_lag 5\n")
+	// Measured from the persisted update time
+	match := regexp.MustCompile(`\nindexed_btc_info_lag_seconds (\S+)\n`).FindStringSubmatch(body)
+	require.Len(t, match, 2)
+	lagSeconds, err := strconv.ParseFloat(match[1], 64)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, lagSeconds, float64(60))
+}
+
+func fetchMetrics(t *testing.T, testServer *TestServer) string {
+	metricsUrl := fmt.Sprintf("http://localhost:%d/metrics", testServer.Config.Metrics.GetMetricsPort())
+	metricsResp, err := http.Get(metricsUrl)
+	require.NoError(t, err, "making GET request to metrics endpoint should not fail")
+	defer metricsResp.Body.Close()
+	assert.Equal(t, http.StatusOK, metricsResp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(metricsResp.Body)
+	require.NoError(t, err, "reading response body should not fail")
+	return string(bodyBytes)
+}
@@ -18,27 +18,563 @@ type DBClient struct {
 	mock.Mock
 }
 
+// AggregateDailyUnbondingStats provides a mock function with given fields: ctx, fromTimestamp, toTimestamp
+func (_m *DBClient) AggregateDailyUnbondingStats(ctx context.Context, fromTimestamp int64, toTimestamp int64) ([]model.DailyUnbondingStats, error) {
+	ret := _m.Called(ctx, fromTimestamp, toTimestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateDailyUnbondingStats")
+	}
+
+	var r0 []model.DailyUnbondingStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]model.DailyUnbondingStats, error)); ok {
+		return rf(ctx, fromTimestamp, toTimestamp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []model.DailyUnbondingStats); ok {
+		r0 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DailyUnbondingStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateFinalityProviderStakeStats provides a mock function with given fields: ctx, fpPkHex, extraFilter
+func (_m *DBClient) AggregateFinalityProviderStakeStats(ctx context.Context, fpPkHex string, extraFilter *db.DelegationFilter) (*model.FinalityProviderStakeStats, error) {
+	ret := _m.Called(ctx, fpPkHex, extraFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateFinalityProviderStakeStats")
+	}
+
+	var r0 *model.FinalityProviderStakeStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) (*model.FinalityProviderStakeStats, error)); ok {
+		return rf(ctx, fpPkHex, extraFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) *model.FinalityProviderStakeStats); ok {
+		r0 = rf(ctx, fpPkHex, extraFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FinalityProviderStakeStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, fpPkHex, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateFinalityProviderStatsByMembership provides a mock function with given fields: ctx, memberFinalityProviderPkHex
+func (_m *DBClient) AggregateFinalityProviderStatsByMembership(ctx context.Context, memberFinalityProviderPkHex []string) ([]model.FinalityProviderMembershipStakeAggregate, error) {
+	ret := _m.Called(ctx, memberFinalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateFinalityProviderStatsByMembership")
+	}
+
+	var r0 []model.FinalityProviderMembershipStakeAggregate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.FinalityProviderMembershipStakeAggregate, error)); ok {
+		return rf(ctx, memberFinalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.FinalityProviderMembershipStakeAggregate); ok {
+		r0 = rf(ctx, memberFinalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FinalityProviderMembershipStakeAggregate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, memberFinalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateOverallStatsByFinalityProviders provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *DBClient) AggregateOverallStatsByFinalityProviders(ctx context.Context, finalityProviderPkHex []string) (*model.OverallStatsDocument, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateOverallStatsByFinalityProviders")
+	}
+
+	var r0 *model.OverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*model.OverallStatsDocument, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *model.OverallStatsDocument); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateStakerDailyActivity provides a mock function with given fields: ctx, stakerPkHex, fromTimestamp, toTimestamp
+func (_m *DBClient) AggregateStakerDailyActivity(ctx context.Context, stakerPkHex string, fromTimestamp int64, toTimestamp int64) ([]model.StakerDailyActivity, error) {
+	ret := _m.Called(ctx, stakerPkHex, fromTimestamp, toTimestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateStakerDailyActivity")
+	}
+
+	var r0 []model.StakerDailyActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]model.StakerDailyActivity, error)); ok {
+		return rf(ctx, stakerPkHex, fromTimestamp, toTimestamp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []model.StakerDailyActivity); ok {
+		r0 = rf(ctx, stakerPkHex, fromTimestamp, toTimestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StakerDailyActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, stakerPkHex, fromTimestamp, toTimestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateStakerFinalityProviderStats provides a mock function with given fields: ctx, stakerPkHex, fpPkHex
+func (_m *DBClient) AggregateStakerFinalityProviderStats(ctx context.Context, stakerPkHex string, fpPkHex string) (*model.StakerFinalityProviderStats, error) {
+	ret := _m.Called(ctx, stakerPkHex, fpPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateStakerFinalityProviderStats")
+	}
+
+	var r0 *model.StakerFinalityProviderStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.StakerFinalityProviderStats, error)); ok {
+		return rf(ctx, stakerPkHex, fpPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.StakerFinalityProviderStats); ok {
+		r0 = rf(ctx, stakerPkHex, fpPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StakerFinalityProviderStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakerPkHex, fpPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateStakingHistory provides a mock function with given fields: ctx, fromTimestamp, toTimestamp, bucketSeconds
+func (_m *DBClient) AggregateStakingHistory(ctx context.Context, fromTimestamp int64, toTimestamp int64, bucketSeconds int64) (*model.StakingHistory, error) {
+	ret := _m.Called(ctx, fromTimestamp, toTimestamp, bucketSeconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateStakingHistory")
+	}
+
+	var r0 *model.StakingHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (*model.StakingHistory, error)); ok {
+		return rf(ctx, fromTimestamp, toTimestamp, bucketSeconds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *model.StakingHistory); ok {
+		r0 = rf(ctx, fromTimestamp, toTimestamp, bucketSeconds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StakingHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, fromTimestamp, toTimestamp, bucketSeconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggregateStakingValueDistribution provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *DBClient) AggregateStakingValueDistribution(ctx context.Context, finalityProviderPkHex []string) (*model.StakingValueDistribution, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateStakingValueDistribution")
+	}
+
+	var r0 *model.StakingValueDistribution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*model.StakingValueDistribution, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *model.StakingValueDistribution); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StakingValueDistribution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckDelegationExistByStakerTaprootAddress provides a mock function with given fields: ctx, address, extraFilter
 func (_m *DBClient) CheckDelegationExistByStakerTaprootAddress(ctx context.Context, address string, extraFilter *db.DelegationFilter) (bool, error) {
 	ret := _m.Called(ctx, address, extraFilter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckDelegationExistByStakerTaprootAddress")
+		panic("no return value specified for CheckDelegationExistByStakerTaprootAddress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) (bool, error)); ok {
+		return rf(ctx, address, extraFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) bool); ok {
+		r0 = rf(ctx, address, extraFilter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, address, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDelegationsByState provides a mock function with given fields: ctx, extraFilter
+func (_m *DBClient) CountDelegationsByState(ctx context.Context, extraFilter *db.DelegationFilter) ([]model.DelegationStateCount, error) {
+	ret := _m.Called(ctx, extraFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDelegationsByState")
+	}
+
+	var r0 []model.DelegationStateCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) ([]model.DelegationStateCount, error)); ok {
+		return rf(ctx, extraFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) []model.DelegationStateCount); ok {
+		r0 = rf(ctx, extraFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationStateCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDistinctFinalityProviders provides a mock function with given fields: ctx, extraFilter
+func (_m *DBClient) CountDistinctFinalityProviders(ctx context.Context, extraFilter *db.DelegationFilter) (int64, error) {
+	ret := _m.Called(ctx, extraFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDistinctFinalityProviders")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) (int64, error)); ok {
+		return rf(ctx, extraFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) int64); ok {
+		r0 = rf(ctx, extraFilter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountStakersRankedAboveByActiveTvl provides a mock function with given fields: ctx, stakerStats
+func (_m *DBClient) CountStakersRankedAboveByActiveTvl(ctx context.Context, stakerStats *model.StakerStatsDocument) (int64, error) {
+	ret := _m.Called(ctx, stakerStats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStakersRankedAboveByActiveTvl")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.StakerStatsDocument) (int64, error)); ok {
+		return rf(ctx, stakerStats)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.StakerStatsDocument) int64); ok {
+		r0 = rf(ctx, stakerStats)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.StakerStatsDocument) error); ok {
+		r1 = rf(ctx, stakerStats)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationByStakingOutput provides a mock function with given fields: ctx, stakerPkHex, stakingTxHashHex, outputIndex
+func (_m *DBClient) FindDelegationByStakingOutput(ctx context.Context, stakerPkHex string, stakingTxHashHex string, outputIndex uint64) (*model.DelegationDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex, stakingTxHashHex, outputIndex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationByStakingOutput")
+	}
+
+	var r0 *model.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) (*model.DelegationDocument, error)); ok {
+		return rf(ctx, stakerPkHex, stakingTxHashHex, outputIndex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) *model.DelegationDocument); ok {
+		r0 = rf(ctx, stakerPkHex, stakingTxHashHex, outputIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
+		r1 = rf(ctx, stakerPkHex, stakingTxHashHex, outputIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
+func (_m *DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*model.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationByTxHashHex")
+	}
+
+	var r0 *model.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationStateChanges provides a mock function with given fields: ctx, paginationToken
+func (_m *DBClient) FindDelegationStateChanges(ctx context.Context, paginationToken string) (*db.DbResultMap[model.DelegationStateChangeDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationStateChanges")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationStateChangeDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[model.DelegationStateChangeDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[model.DelegationStateChangeDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationStateChangeDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationStateChangesByTxHashHex provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *DBClient) FindDelegationStateChangesByTxHashHex(ctx context.Context, stakingTxHashHex string) ([]model.DelegationStateChangeDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationStateChangesByTxHashHex")
+	}
+
+	var r0 []model.DelegationStateChangeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.DelegationStateChangeDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.DelegationStateChangeDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationStateChangeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByFinalityProviderPk provides a mock function with given fields: ctx, fpPkHex, extraFilter, paginationToken, limit
+func (_m *DBClient) FindDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, extraFilter *db.DelegationFilter, paginationToken string, limit int64) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, fpPkHex, extraFilter, paginationToken, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByFinalityProviderPk")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, string, int64) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, fpPkHex, extraFilter, paginationToken, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, string, int64) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, fpPkHex, extraFilter, paginationToken, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter, string, int64) error); ok {
+		r1 = rf(ctx, fpPkHex, extraFilter, paginationToken, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields
+func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *db.DelegationFilter, sort *db.DelegationSort, paginationToken string, limit int64, fields []string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPk")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, *db.DelegationSort, string, int64, []string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, *db.DelegationSort, string, int64, []string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter, *db.DelegationSort, string, int64, []string) error); ok {
+		r1 = rf(ctx, stakerPk, extraFilter, sort, paginationToken, limit, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPks provides a mock function with given fields: ctx, stakerPks, extraFilter, limit
+func (_m *DBClient) FindDelegationsByStakerPks(ctx context.Context, stakerPks []string, extraFilter *db.DelegationFilter, limit int64) (map[string]*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPks, extraFilter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPks")
 	}
 
-	var r0 bool
+	var r0 map[string]*db.DbResultMap[model.DelegationDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) (bool, error)); ok {
-		return rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *db.DelegationFilter, int64) (map[string]*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPks, extraFilter, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) bool); ok {
-		r0 = rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *db.DelegationFilter, int64) map[string]*db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPks, extraFilter, limit)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*db.DbResultMap[model.DelegationDocument])
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter) error); ok {
-		r1 = rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, *db.DelegationFilter, int64) error); ok {
+		r1 = rf(ctx, stakerPks, extraFilter, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,29 +582,29 @@ func (_m *DBClient) CheckDelegationExistByStakerTaprootAddress(ctx context.Conte
 	return r0, r1
 }
 
-// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
-func (_m *DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*model.DelegationDocument, error) {
-	ret := _m.Called(ctx, txHashHex)
+// FindDelegationsByTxHashHexes provides a mock function with given fields: ctx, txHashHexes
+func (_m *DBClient) FindDelegationsByTxHashHexes(ctx context.Context, txHashHexes []string) ([]model.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHexes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationByTxHashHex")
+		panic("no return value specified for FindDelegationsByTxHashHexes")
 	}
 
-	var r0 *model.DelegationDocument
+	var r0 []model.DelegationDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.DelegationDocument, error)); ok {
-		return rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHexes)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.DelegationDocument); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHexes)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.DelegationDocument)
+			r0 = ret.Get(0).([]model.DelegationDocument)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, txHashHexes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,29 +612,29 @@ func (_m *DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex str
 	return r0, r1
 }
 
-// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, paginationToken
-func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
-	ret := _m.Called(ctx, stakerPk, paginationToken)
+// FindFinalityProviderDelegationCounts provides a mock function with given fields: ctx, paginationToken, extraFilter
+func (_m *DBClient) FindFinalityProviderDelegationCounts(ctx context.Context, paginationToken string, extraFilter *db.DelegationFilter) (*db.DbResultMap[*model.FinalityProviderDelegationCountDocument], error) {
+	ret := _m.Called(ctx, paginationToken, extraFilter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationsByStakerPk")
+		panic("no return value specified for FindFinalityProviderDelegationCounts")
 	}
 
-	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r0 *db.DbResultMap[*model.FinalityProviderDelegationCountDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
-		return rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) (*db.DbResultMap[*model.FinalityProviderDelegationCountDocument], error)); ok {
+		return rf(ctx, paginationToken, extraFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[model.DelegationDocument]); ok {
-		r0 = rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter) *db.DbResultMap[*model.FinalityProviderDelegationCountDocument]); ok {
+		r0 = rf(ctx, paginationToken, extraFilter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderDelegationCountDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, paginationToken, extraFilter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -106,9 +642,39 @@ func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk stri
 	return r0, r1
 }
 
-// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken
-func (_m *DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*model.FinalityProviderStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// FindFinalityProviderSelfStakeByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *DBClient) FindFinalityProviderSelfStakeByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*model.FinalityProviderSelfStakeDocument, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderSelfStakeByFinalityProviderPkHex")
+	}
+
+	var r0 []*model.FinalityProviderSelfStakeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.FinalityProviderSelfStakeDocument, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.FinalityProviderSelfStakeDocument); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FinalityProviderSelfStakeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken, finalityProviderPkHex
+func (_m *DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string, finalityProviderPkHex []string) (*db.DbResultMap[*model.FinalityProviderStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken, finalityProviderPkHex)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindFinalityProviderStats")
@@ -116,19 +682,19 @@ func (_m *DBClient) FindFinalityProviderStats(ctx context.Context, paginationTok
 
 	var r0 *db.DbResultMap[*model.FinalityProviderStatsDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*model.FinalityProviderStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*db.DbResultMap[*model.FinalityProviderStatsDocument], error)); ok {
+		return rf(ctx, paginationToken, finalityProviderPkHex)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*model.FinalityProviderStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *db.DbResultMap[*model.FinalityProviderStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken, finalityProviderPkHex)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderStatsDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, paginationToken, finalityProviderPkHex)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -166,9 +732,185 @@ func (_m *DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context
 	return r0, r1
 }
 
-// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
-func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*model.StakerStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// FindFinalityProvidersByActiveStakerCount provides a mock function with given fields: ctx, paginationToken, finalityProviderPkHex
+func (_m *DBClient) FindFinalityProvidersByActiveStakerCount(ctx context.Context, paginationToken string, finalityProviderPkHex []string) (*db.DbResultMap[*model.FinalityProviderStakerCountDocument], error) {
+	ret := _m.Called(ctx, paginationToken, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProvidersByActiveStakerCount")
+	}
+
+	var r0 *db.DbResultMap[*model.FinalityProviderStakerCountDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*db.DbResultMap[*model.FinalityProviderStakerCountDocument], error)); ok {
+		return rf(ctx, paginationToken, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *db.DbResultMap[*model.FinalityProviderStakerCountDocument]); ok {
+		r0 = rf(ctx, paginationToken, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderStakerCountDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, paginationToken, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProvidersBySelfStake provides a mock function with given fields: ctx, minSelfStake, paginationToken, finalityProviderPkHex
+func (_m *DBClient) FindFinalityProvidersBySelfStake(ctx context.Context, minSelfStake int64, paginationToken string, finalityProviderPkHex []string) (*db.DbResultMap[*model.FinalityProviderSelfStakeDocument], error) {
+	ret := _m.Called(ctx, minSelfStake, paginationToken, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProvidersBySelfStake")
+	}
+
+	var r0 *db.DbResultMap[*model.FinalityProviderSelfStakeDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) (*db.DbResultMap[*model.FinalityProviderSelfStakeDocument], error)); ok {
+		return rf(ctx, minSelfStake, paginationToken, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) *db.DbResultMap[*model.FinalityProviderSelfStakeDocument]); ok {
+		r0 = rf(ctx, minSelfStake, paginationToken, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderSelfStakeDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, []string) error); ok {
+		r1 = rf(ctx, minSelfStake, paginationToken, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestDelegationStakingHeight provides a mock function with given fields: ctx
+func (_m *DBClient) FindLatestDelegationStakingHeight(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestDelegationStakingHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerPkByTaprootAddress provides a mock function with given fields: ctx, address
+func (_m *DBClient) FindStakerPkByTaprootAddress(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerPkByTaprootAddress")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStatsByStakerPk provides a mock function with given fields: ctx, stakerPkHex
+func (_m *DBClient) FindStakerStatsByStakerPk(ctx context.Context, stakerPkHex string) (*model.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStatsByStakerPk")
+	}
+
+	var r0 *model.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerUnbondingRequests provides a mock function with given fields: ctx, stakerPkHex, states, paginationToken
+func (_m *DBClient) FindStakerUnbondingRequests(ctx context.Context, stakerPkHex string, states []types.DelegationState, paginationToken string) (*db.DbResultMap[model.StakerUnbondingRequestDocument], error) {
+	ret := _m.Called(ctx, stakerPkHex, states, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerUnbondingRequests")
+	}
+
+	var r0 *db.DbResultMap[model.StakerUnbondingRequestDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState, string) (*db.DbResultMap[model.StakerUnbondingRequestDocument], error)); ok {
+		return rf(ctx, stakerPkHex, states, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState, string) *db.DbResultMap[model.StakerUnbondingRequestDocument]); ok {
+		r0 = rf(ctx, stakerPkHex, states, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.StakerUnbondingRequestDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []types.DelegationState, string) error); ok {
+		r1 = rf(ctx, stakerPkHex, states, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken, limit
+func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string, limit int64) (*db.DbResultMap[*model.StakerStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindTopStakersByTvl")
@@ -176,19 +918,79 @@ func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken str
 
 	var r0 *db.DbResultMap[*model.StakerStatsDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*model.StakerStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*db.DbResultMap[*model.StakerStatsDocument], error)); ok {
+		return rf(ctx, paginationToken, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*model.StakerStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *db.DbResultMap[*model.StakerStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*db.DbResultMap[*model.StakerStatsDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, paginationToken, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingDelegations provides a mock function with given fields: ctx, extraFilter, paginationToken
+func (_m *DBClient) FindUnbondingDelegations(ctx context.Context, extraFilter *db.DelegationFilter, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, extraFilter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingDelegations")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, extraFilter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter, string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, extraFilter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.DelegationFilter, string) error); ok {
+		r1 = rf(ctx, extraFilter, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingDelegationsByExpireHeight provides a mock function with given fields: ctx, extraFilter, paginationToken
+func (_m *DBClient) FindUnbondingDelegationsByExpireHeight(ctx context.Context, extraFilter *db.DelegationFilter, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, extraFilter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingDelegationsByExpireHeight")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, extraFilter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter, string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, extraFilter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.DelegationFilter, string) error); ok {
+		r1 = rf(ctx, extraFilter, paginationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -484,6 +1286,62 @@ func (_m *DBClient) SubtractStakerStats(ctx context.Context, stakingTxHashHex st
 	return r0
 }
 
+// SumDelegationsStakingValue provides a mock function with given fields: ctx, extraFilter
+func (_m *DBClient) SumDelegationsStakingValue(ctx context.Context, extraFilter *db.DelegationFilter) (uint64, error) {
+	ret := _m.Called(ctx, extraFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumDelegationsStakingValue")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) (uint64, error)); ok {
+		return rf(ctx, extraFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.DelegationFilter) uint64); ok {
+		r0 = rf(ctx, extraFilter)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.DelegationFilter) error); ok {
+		r1 = rf(ctx, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumStakingValueBeforeInCapOrder provides a mock function with given fields: ctx, stakingTxHashHex, startHeight, fromHeight, toHeight
+func (_m *DBClient) SumStakingValueBeforeInCapOrder(ctx context.Context, stakingTxHashHex string, startHeight uint64, fromHeight uint64, toHeight uint64) (uint64, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, startHeight, fromHeight, toHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumStakingValueBeforeInCapOrder")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, uint64) (uint64, error)); ok {
+		return rf(ctx, stakingTxHashHex, startHeight, fromHeight, toHeight)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, uint64) uint64); ok {
+		r0 = rf(ctx, stakingTxHashHex, startHeight, fromHeight, toHeight)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64, uint64) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, startHeight, fromHeight, toHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TransitionToUnbondedState provides a mock function with given fields: ctx, stakingTxHashHex, eligiblePreviousState
 func (_m *DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState) error {
 	ret := _m.Called(ctx, stakingTxHashHex, eligiblePreviousState)
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+func TestRateLimit(t *testing.T) {
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.RateLimitRps = 1
+	cfg.Server.RateLimitBurst = 2
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(testServer.Server.URL + path)
+		assert.NoError(t, err, "making GET request should not fail")
+		return resp
+	}
+
+	// Align on the start of a 2 seconds window so that all the requests below
+	// fall into the same window
+	window := (2 * time.Second).Nanoseconds()
+	windowStart := time.Unix(0, (time.Now().UnixNano()/window+1)*window)
+	time.Sleep(time.Until(windowStart))
+
+	// The burst is allowed
+	for i := 0; i < 2; i++ {
+		resp := get(globalParamsPath)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		assert.Equal(t, "2", resp.Header.Get(middlewares.RateLimitLimitHeader))
+		// The window ends 2 seconds after its start
+		assert.Equal(t, strconv.FormatInt(windowStart.Add(2*time.Second).Unix(), 10), resp.Header.Get(middlewares.RateLimitResetHeader))
+	}
+
+	resp := get(globalParamsPath)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var errorResponse respond.ErrorResponse
+	err = json.Unmarshal(bodyBytes, &errorResponse)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.TooManyRequests.String(), errorResponse.ErrorCode)
+
+	// The healthcheck is not rate limited
+	healthResp := get(healthCheckPath)
+	defer healthResp.Body.Close()
+	assert.Equal(t, http.StatusOK, healthResp.StatusCode, "expected HTTP 200 OK status")
+
+	// The limit is reset in the next window
+	time.Sleep(time.Until(windowStart.Add(2 * time.Second)))
+	resetResp := get(globalParamsPath)
+	defer resetResp.Body.Close()
+	assert.Equal(t, http.StatusOK, resetResp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestRateLimitTrustedProxy(t *testing.T) {
+	getFrom := func(testServer *TestServer, forwardedFor string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, testServer.Server.URL+globalParamsPath, nil)
+		assert.NoError(t, err, "creating request should not fail")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err, "making GET request should not fail")
+		resp.Body.Close()
+		return resp
+	}
+
+	for _, trusted := range []bool{true, false} {
+		cfg, err := config.New("./config/config-test.yml")
+		if err != nil {
+			t.Fatalf("Failed to load test config: %v", err)
+		}
+		cfg.Server.RateLimitRps = 0.5
+		cfg.Server.RateLimitBurst = 1
+		if trusted {
+			cfg.Server.TrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+			assert.NoError(t, cfg.Server.Validate())
+		}
+		testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+
+		// Align on the start of a 2 seconds window so that all the requests below
+		// fall into the same window
+		window := (2 * time.Second).Nanoseconds()
+		windowStart := time.Unix(0, (time.Now().UnixNano()/window+1)*window)
+		time.Sleep(time.Until(windowStart))
+
+		assert.Equal(t, http.StatusOK, getFrom(testServer, "203.0.113.1").StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, getFrom(testServer, "203.0.113.1").StatusCode)
+		if trusted {
+			// The clients behind the proxy are limited separately
+			assert.Equal(t, http.StatusOK, getFrom(testServer, "203.0.113.2").StatusCode)
+		} else {
+			// The forwarded IP is ignored when the proxy is not trusted
+			assert.Equal(t, http.StatusTooManyRequests, getFrom(testServer, "203.0.113.2").StatusCode)
+		}
+		testServer.Close()
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	// Slow db call that only ends once the request is cancelled
+	slowFindDelegation := func(ctx context.Context, txHashHex string) (*model.DelegationDocument, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	mockDB.On("FindDelegationByTxHashHex", mock.Anything, mock.Anything).Return(slowFindDelegation)
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.RequestTimeout = 100 * time.Millisecond
+
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	_, txHash := randomBytes(rand.New(rand.NewSource(time.Now().UnixNano())), 32)
+	start := time.Now()
+	resp, err := http.Get(testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + txHash)
+	assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+	defer resp.Body.Close()
+	assert.Less(t, time.Since(start), 5*time.Second, "the request should be cancelled once the timeout is exceeded")
+
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode, "expected HTTP 504 Gateway Timeout status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response respond.ErrorResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.RequestTimeout.String(), response.ErrorCode)
+}
+
+func TestRequestTimeoutAbortsStreamedExport(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	// The first page is sent before the slow db call of the next one
+	firstPage := &db.DbResultMap[model.DelegationDocument]{
+		Data:            []model.DelegationDocument{{StakingTxHashHex: "first", State: types.Active}},
+		PaginationToken: "next",
+	}
+	mockDB.On("FindDelegationsByStakerPk", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "", mock.Anything, mock.Anything).
+		Return(firstPage, nil)
+	slowFindDelegations := func(
+		ctx context.Context, stakerPk string, extraFilter *db.DelegationFilter, sort *db.DelegationSort,
+		paginationToken string, limit int64, fields []string,
+	) (*db.DbResultMap[model.DelegationDocument], error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	mockDB.On("FindDelegationsByStakerPk", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "next", mock.Anything, mock.Anything).
+		Return(slowFindDelegations)
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.RequestTimeout = 100 * time.Millisecond
+
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + stakerDelegations + "?format=csv&staker_btc_pk=" + generatePks(t, 1)[0])
+	assert.NoError(t, err, "making GET request to staker delegations should not fail")
+	defer resp.Body.Close()
+	// The status is sent along with the first page, the export is then aborted
+	// so that it can't be mistaken for a complete one
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "expected the export to be cut short")
+}
@@ -116,6 +116,7 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 	if err != nil {
 		t.Fatalf("Failed to setup test queue: %v", err)
 	}
+	services.AddReadinessCheck("queue", queues.Ping)
 
 	// Create an httptest server
 	server := httptest.NewServer(r)
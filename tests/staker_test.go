@@ -1,24 +1,43 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
+	"slices"
+	"sort"
 	"testing"
 	"time"
 
-	"github.com/babylonchain/staking-api-service/internal/api"
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
+	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
 	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
 	checkStakerDelegationUrl = "/v1/staker/delegation/check"
+	stakerProviderStatsUrl   = "/v1/staker/provider-stats"
+	stakerDailyActivityUrl   = "/v1/staker/activity/daily"
+	stakerDelegationsBatch   = "/v1/staker/delegations/batch"
+	stakerDelegationsExport  = "/v1/staker/delegations/export"
+	stakerTotalStakeUrl      = "/v1/staker/total-stake"
+	stakerDashboardUrl       = "/v1/staker/dashboard"
+	stakerDelegationSummary  = "/v1/staker/delegation-summary"
+	stakerLinkageUrl         = "/v1/staker/linked"
 )
 
 func FuzzTestStakerDelegationsWithPaginationResponse(f *testing.F) {
@@ -64,6 +83,8 @@ func FuzzTestStakerDelegationsWithPaginationResponse(f *testing.F) {
 			for _, d := range response.Data {
 				assert.Equal(t, stakerPk, d.StakerPkHex, "expected response body to match")
 			}
+			assert.Equal(t, len(response.Data), response.Pagination.Count, "expected pagination count to match the page size")
+			assert.Equal(t, response.Pagination.NextKey != "", response.Pagination.HasNext, "expected has_next to reflect the next key")
 			allDataCollected = append(allDataCollected, response.Data...)
 			if response.Pagination.NextKey != "" {
 				paginationKey = response.Pagination.NextKey
@@ -117,7 +138,7 @@ func TestActiveStakingFetchedByStakerPkWithInvalidPaginationKey(t *testing.T) {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	assert.NoError(t, err, "reading response body should not fail")
 
-	var response api.ErrorResponse
+	var response respond.ErrorResponse
 	err = json.Unmarshal(bodyBytes, &response)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
 
@@ -259,6 +280,137 @@ func FuzzCheckStakerActiveDelegationsForToday(f *testing.F) {
 	})
 }
 
+func TestTimeframeBoundaryTimestamps(t *testing.T) {
+	// 2024-05-15T13:45:30Z
+	now := time.Date(2024, time.May, 15, 13, 45, 30, 0, time.UTC)
+
+	// today starts at 12AM UTC, regardless of the location of the clock
+	assert.Equal(t, int64(1715731200), utils.GetDayStartTimestampInSeconds(now))
+	assert.Equal(t, int64(1715731200), utils.GetDayStartTimestampInSeconds(now.In(time.FixedZone("UTC+11", 11*3600))))
+	// week and month are rolling windows ending now
+	assert.Equal(t, int64(1715175930), utils.GetDaysAgoTimestampInSeconds(now, 7))
+	assert.Equal(t, int64(1713188730), utils.GetDaysAgoTimestampInSeconds(now, 30))
+
+	// 2024-05-15T02:00:00Z is still 2024-05-14 in New York (UTC-4)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	earlyNow := time.Date(2024, time.May, 15, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(1715659200), utils.GetDayStartTimestampInLocation(earlyNow, newYork))
+	assert.Equal(t, int64(1715731200), utils.GetDayStartTimestampInLocation(earlyNow, time.UTC))
+}
+
+func TestCheckStakerDelegationWithinWeekAndMonth(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	now := time.Now()
+	// The delegation started 9 to 11 days ago
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+		AfterTimestamp:     utils.GetDaysAgoTimestampInSeconds(now, 11),
+		BeforeTimestamp:    utils.GetDaysAgoTimestampInSeconds(now, 9),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	taprootAddress, err := utils.GetTaprootAddressFromPk(stakerPk[0], testServer.Config.Server.BTCNetParam)
+	assert.NoError(t, err, "failed to get taproot address from staker pk")
+	assert.False(t, fetchCheckStakerActiveDelegations(t, testServer, taprootAddress, "today"))
+	assert.False(t, fetchCheckStakerActiveDelegations(t, testServer, taprootAddress, "week"))
+	assert.True(t, fetchCheckStakerActiveDelegations(t, testServer, taprootAddress, "month"))
+
+	badResp, err := http.Get(testServer.Server.URL + checkStakerDelegationUrl + "?address=" + taprootAddress + "&timeframe=year")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status")
+}
+
+func TestCheckStakerDelegationTodayInTimezone(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 2)
+	now := time.Now().Unix()
+	// Started right now, hence today in any timezone
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		Stakers:            stakerPks[:1],
+		EnforceNotOverflow: true,
+		AfterTimestamp:     now - 1,
+	})
+	// Started two days ago, hence not today in any timezone
+	activeStakingEvents = append(activeStakingEvents, generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		Stakers:            stakerPks[1:],
+		EnforceNotOverflow: true,
+		AfterTimestamp:     now - 3*24*3600,
+		BeforeTimestamp:    now - 2*24*3600,
+	})...)
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(stakerPk, tz string, expectedStatus int) bool {
+		taprootAddress, err := utils.GetTaprootAddressFromPk(stakerPk, testServer.Config.Server.BTCNetParam)
+		assert.NoError(t, err, "failed to get taproot address from staker pk")
+		resp, err := http.Get(
+			testServer.Server.URL + checkStakerDelegationUrl + "?address=" + taprootAddress + "&timeframe=today&tz=" + tz,
+		)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		var response handlers.PublicResponse[bool]
+		if expectedStatus == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		}
+		return response.Data
+	}
+	for _, tz := range []string{"Asia/Tokyo", "America/Los_Angeles"} {
+		assert.True(t, fetch(stakerPks[0], tz, http.StatusOK))
+		assert.False(t, fetch(stakerPks[1], tz, http.StatusOK))
+	}
+	fetch(stakerPks[0], "Mars/Olympus_Mons", http.StatusBadRequest)
+}
+
+func TestCheckStakerDelegationAfterTimestamp(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	taprootAddress, err := utils.GetTaprootAddressFromPk(stakerPk[0], testServer.Config.Server.BTCNetParam)
+	assert.NoError(t, err, "failed to get taproot address from staker pk")
+	url := testServer.Server.URL + checkStakerDelegationUrl + "?address=" + taprootAddress
+	fetch := func(query string, expectedStatus int) bool {
+		resp, err := http.Get(url + query)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		var response handlers.PublicResponse[bool]
+		if expectedStatus == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		}
+		return response.Data
+	}
+
+	startTimestamp := activeStakingEvents[0].StakingStartTimestamp
+	assert.True(t, fetch(fmt.Sprintf("&after=%d", startTimestamp-1), http.StatusOK))
+	assert.False(t, fetch(fmt.Sprintf("&after=%d", startTimestamp+1), http.StatusOK))
+
+	fetch("&after=-1", http.StatusBadRequest)
+	fetch("&after=yesterday", http.StatusBadRequest)
+	fetch(fmt.Sprintf("&after=%d&timeframe=today", startTimestamp), http.StatusBadRequest)
+}
+
 func TestGetDelegationReturnEmptySliceWhenNoDelegation(t *testing.T) {
 	testServer := setupTestServer(t, nil)
 	defer testServer.Close()
@@ -283,6 +435,899 @@ func TestGetDelegationReturnEmptySliceWhenNoDelegation(t *testing.T) {
 	assert.Equal(t, 0, len(response.Data), "expected response body to have no data")
 }
 
+func TestGetStakerFinalityProviderStats(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        10,
+		FinalityProviders:  fpPks,
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	var expectedTvl, expectedDelegations int64
+	for _, event := range activeStakingEvents {
+		if event.FinalityProviderPkHex == fpPks[0] {
+			expectedTvl += int64(event.StakingValue)
+			expectedDelegations++
+		}
+	}
+
+	url := testServer.Server.URL + stakerProviderStatsUrl +
+		"?staker_btc_pk=" + stakerPk[0] + "&finality_provider_pk_hex=" + fpPks[0]
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.StakerFinalityProviderStatsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, expectedTvl, response.Data.ActiveTvl)
+	assert.Equal(t, expectedTvl, response.Data.TotalTvl)
+	assert.Equal(t, expectedDelegations, response.Data.ActiveDelegations)
+	assert.Equal(t, expectedDelegations, response.Data.TotalDelegations)
+}
+
+func TestGetStakerDelegationsFilteredByUnbondingType(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       2,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	// Let the staking timelock of the first delegation expire
+	expiredStakingEvent := client.NewExpiredStakingEvent(
+		activeStakingEvents[0].StakingTxHashHex, types.ActiveTxType.ToString(),
+	)
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	fetch := func(unbondingType string) (int, []services.DelegationPublic) {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + "&unbonding_type=" + unbondingType
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		json.Unmarshal(bodyBytes, &response)
+		return resp.StatusCode, response.Data
+	}
+
+	statusCode, delegations := fetch(types.NaturalExpiry.ToString())
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 1, len(delegations))
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, delegations[0].StakingTxHashHex)
+	assert.NotNil(t, delegations[0].UnbondingType)
+	assert.Equal(t, types.NaturalExpiry.ToString(), *delegations[0].UnbondingType)
+
+	statusCode, delegations = fetch(types.EarlyUnbonding.ToString())
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 0, len(delegations))
+
+	statusCode, _ = fetch("invalid")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestStakerDelegationsHideShallowDelegations(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       2,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	activeStakingEvents[0].StakingStartHeight = 100
+	activeStakingEvents[1].StakingStartHeight = 105
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.MinDisplayConfirmations = 6
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	// The delegation at height 100 has 6 confirmations at height 105
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    105,
+	}})
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0]
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[[]services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, 1, len(response.Data))
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, response.Data[0].StakingTxHashHex)
+
+	// The shallow delegation can still be looked up by its tx hash
+	resp, err = http.Get(testServer.Server.URL + "/v1/delegation?staking_tx_hash_hex=" + activeStakingEvents[1].StakingTxHashHex)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestStakerDelegationsFilteredByMinConfirmations(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	activeStakingEvents[0].StakingStartHeight = 100
+	activeStakingEvents[1].StakingStartHeight = 103
+	activeStakingEvents[2].StakingStartHeight = 105
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.MinDisplayConfirmations = 2
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    105,
+	}})
+	time.Sleep(2 * time.Second)
+
+	fetch := func(query string, expectedStatus int) []string {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + query
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes
+	}
+
+	// At height 105, the delegations have 6, 3 and 1 confirmations respectively
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[1].StakingTxHashHex,
+	}, fetch("", http.StatusOK))
+	assert.ElementsMatch(t, []string{activeStakingEvents[0].StakingTxHashHex}, fetch("&min_confirmations=4", http.StatusOK))
+	// The configured minimum applies when stricter than the requested one
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[1].StakingTxHashHex,
+	}, fetch("&min_confirmations=1", http.StatusOK))
+	assert.Empty(t, fetch("&min_confirmations=7", http.StatusOK))
+	fetch("&min_confirmations=-1", http.StatusBadRequest)
+}
+
+func TestStakerDelegationsHideOverflowByDefault(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       2,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	activeStakingEvents[0].IsOverflow = false
+	activeStakingEvents[1].IsOverflow = true
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.HideOverflowByDefault = true
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(query string) []services.DelegationPublic {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + query
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return response.Data
+	}
+
+	delegations := fetch("")
+	assert.Equal(t, 1, len(delegations))
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, delegations[0].StakingTxHashHex)
+
+	assert.Equal(t, 2, len(fetch("&include_overflow=true")))
+	assert.Equal(t, 1, len(fetch("&include_overflow=false")))
+}
+
+func TestStakerDelegationsFilteredByState(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	// Make sure the pagination stays consistent with the filter
+	cfg.Db.MaxPaginationLimit = 1
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvents[0].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	fetchAll := func(states string, expectedStatus int) []string {
+		var hashes []string
+		var paginationKey string
+		for {
+			url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] +
+				"&state=" + states + "&pagination_key=" + paginationKey
+			resp, err := http.Get(url)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, expectedStatus, resp.StatusCode)
+			if expectedStatus != http.StatusOK {
+				return nil
+			}
+			bodyBytes, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err, "reading response body should not fail")
+			var response handlers.PublicResponse[[]services.DelegationPublic]
+			err = json.Unmarshal(bodyBytes, &response)
+			assert.NoError(t, err, "unmarshalling response body should not fail")
+			for _, d := range response.Data {
+				hashes = append(hashes, d.StakingTxHashHex)
+			}
+			if response.Pagination.NextKey == "" {
+				return hashes
+			}
+			paginationKey = response.Pagination.NextKey
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[1].StakingTxHashHex, activeStakingEvents[2].StakingTxHashHex,
+	}, fetchAll("active", http.StatusOK))
+	assert.ElementsMatch(t, []string{activeStakingEvents[0].StakingTxHashHex}, fetchAll("unbonded", http.StatusOK))
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[1].StakingTxHashHex,
+		activeStakingEvents[2].StakingTxHashHex,
+	}, fetchAll("active,unbonded", http.StatusOK))
+	assert.Empty(t, fetchAll("withdrawn", http.StatusOK))
+	fetchAll("active,pending", http.StatusBadRequest)
+}
+
+func TestStakerDelegationsFilteredByMinStakingAmount(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	// The min staking amount is 3000 in the first params version and 2000 in the second one
+	activeStakingEvents[0].StakingStartHeight = 150
+	activeStakingEvents[0].StakingValue = 3000
+	activeStakingEvents[1].StakingStartHeight = 150
+	activeStakingEvents[1].StakingValue = 3001
+	activeStakingEvents[2].StakingStartHeight = 250
+	activeStakingEvents[2].StakingValue = 2000
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(belowMin string) []string {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + "&below_min=" + belowMin
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes
+	}
+
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[2].StakingTxHashHex,
+	}, fetch("true"))
+	assert.ElementsMatch(t, []string{activeStakingEvents[1].StakingTxHashHex}, fetch("false"))
+}
+
+func TestStakerDelegationsFilteredByStakingTimelock(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	// The allowed staking time is within [100, 20000] across the params versions
+	activeStakingEvents[0].StakingTimeLock = 150
+	activeStakingEvents[1].StakingTimeLock = 300
+	activeStakingEvents[2].StakingTimeLock = 500
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(query string, expectedStatus int) []string {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + query
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes
+	}
+
+	assert.ElementsMatch(t, []string{activeStakingEvents[1].StakingTxHashHex}, fetch("&staking_timelock=300", http.StatusOK))
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[1].StakingTxHashHex, activeStakingEvents[2].StakingTxHashHex,
+	}, fetch("&min_staking_timelock=200", http.StatusOK))
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[1].StakingTxHashHex,
+	}, fetch("&min_staking_timelock=150&max_staking_timelock=300", http.StatusOK))
+	assert.Empty(t, fetch("&staking_timelock=200", http.StatusOK))
+
+	// Out of the allowed staking time
+	fetch("&staking_timelock=50", http.StatusBadRequest)
+	fetch("&max_staking_timelock=30000", http.StatusBadRequest)
+	// Conflicting bounds
+	fetch("&staking_timelock=300&min_staking_timelock=200", http.StatusBadRequest)
+	fetch("&min_staking_timelock=300&max_staking_timelock=200", http.StatusBadRequest)
+	fetch("&staking_timelock=abc", http.StatusBadRequest)
+}
+
+func TestStakerDelegationsSortedByStakingAmount(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk,
+	})
+	activeStakingEvents[0].StakingValue = 4000
+	// Equal amounts are sorted by the staking tx hash
+	activeStakingEvents[1].StakingValue = 3000
+	activeStakingEvents[2].StakingValue = 3000
+	tied := []string{activeStakingEvents[1].StakingTxHashHex, activeStakingEvents[2].StakingTxHashHex}
+	sort.Strings(tied)
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	// Make sure the pagination stays consistent with the sort
+	cfg.Db.MaxPaginationLimit = 1
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchPage := func(query, paginationKey string, expectedStatus int) ([]string, string) {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] +
+			query + "&pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil, ""
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes, response.Pagination.NextKey
+	}
+	fetchAll := func(query string) []string {
+		var hashes []string
+		var paginationKey string
+		for {
+			page, nextKey := fetchPage(query, paginationKey, http.StatusOK)
+			hashes = append(hashes, page...)
+			if nextKey == "" {
+				return hashes
+			}
+			paginationKey = nextKey
+		}
+	}
+
+	assert.Equal(t, []string{activeStakingEvents[0].StakingTxHashHex, tied[0], tied[1]},
+		fetchAll("&sort_by=staking_amount"))
+	assert.Equal(t, []string{tied[0], tied[1], activeStakingEvents[0].StakingTxHashHex},
+		fetchAll("&sort_by=staking_amount&sort_order=asc"))
+
+	// The pagination key cannot be resumed with another sort
+	_, paginationKey := fetchPage("&sort_by=staking_amount", "", http.StatusOK)
+	fetchPage("&sort_by=staking_amount&sort_order=asc", paginationKey, http.StatusBadRequest)
+	fetchPage("&sort_by=start_height", paginationKey, http.StatusBadRequest)
+
+	fetchPage("&sort_by=staking_value", "", http.StatusBadRequest)
+	fetchPage("&sort_by=staking_amount&sort_order=up", "", http.StatusBadRequest)
+	fetchPage("&sort_order=asc", "", http.StatusBadRequest)
+}
+
+func TestGetStakerDelegationsBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        2,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPks[:1],
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	postBatch := func(pks []string) *http.Response {
+		payload, err := json.Marshal(handlers.StakerDelegationsBatchRequestPayload{StakerBtcPks: pks})
+		assert.NoError(t, err)
+		resp, err := http.Post(testServer.Server.URL+stakerDelegationsBatch, "application/json", bytes.NewReader(payload))
+		assert.NoError(t, err, "making POST request to staker delegations batch endpoint should not fail")
+		return resp
+	}
+
+	resp := postBatch(stakerPks)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]services.StakerDelegationsPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, 2, len(response.Data))
+	var hashes []string
+	for _, d := range response.Data[stakerPks[0]].Delegations {
+		hashes = append(hashes, d.StakingTxHashHex)
+	}
+	assert.ElementsMatch(t, []string{
+		activeStakingEvents[0].StakingTxHashHex, activeStakingEvents[1].StakingTxHashHex,
+	}, hashes)
+	// The staker without delegations is still included
+	assert.NotNil(t, response.Data[stakerPks[1]].Delegations)
+	assert.Empty(t, response.Data[stakerPks[1]].Delegations)
+
+	emptyResp := postBatch([]string{})
+	defer emptyResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, emptyResp.StatusCode)
+
+	tooManyResp := postBatch(generatePks(t, 51))
+	defer tooManyResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, tooManyResp.StatusCode)
+
+	invalidResp := postBatch([]string{stakerPks[0], "invalid"})
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
+func TestStakerDelegationsMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        2,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvents[0].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + "&minimal=true"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	// Only the minimal fields are returned
+	var rawResponse handlers.PublicResponse[[]map[string]interface{}]
+	err = json.Unmarshal(bodyBytes, &rawResponse)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	for _, d := range rawResponse.Data {
+		assert.Equal(t, 3, len(d))
+	}
+
+	var response handlers.PublicResponse[[]services.DelegationMinimalPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	states := make(map[string]string)
+	for _, d := range response.Data {
+		states[d.DelegationId] = d.State
+		updatedAt, err := time.Parse(time.RFC3339, d.UpdatedAt)
+		assert.NoError(t, err, "updated_at should be an ISO timestamp")
+		assert.WithinDuration(t, time.Now(), updatedAt, time.Minute)
+	}
+	assert.Equal(t, map[string]string{
+		activeStakingEvents[0].StakingTxHashHex: types.Unbonded.ToString(),
+		activeStakingEvents[1].StakingTxHashHex: types.Active.ToString(),
+	}, states)
+
+	badResp, err := http.Get(testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] + "&minimal=yes")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestStakerDelegationsMinimalOnlyFetchesItsFields(t *testing.T) {
+	stakerPk := generatePks(t, 1)[0]
+	mockDB := new(testmock.DBClient)
+	minimalFields := mock.MatchedBy(func(fields []string) bool {
+		return slices.Contains(fields, "state") && slices.Contains(fields, "updated_at")
+	})
+	mockDB.On("FindDelegationsByStakerPk", mock.Anything, stakerPk, mock.Anything, mock.Anything, "", mock.Anything, minimalFields).
+		Return(&db.DbResultMap[model.DelegationDocument]{
+			Data: []model.DelegationDocument{{
+				StakingTxHashHex: "txhash",
+				State:            types.Active,
+				StakingTx:        &model.TimelockTransaction{StartTimestamp: 1700000000},
+			}},
+		}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk + "&minimal=true")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[[]services.DelegationMinimalPublic]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err, "decoding response body should not fail")
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "txhash", response.Data[0].DelegationId)
+	assert.Equal(t, types.Active.ToString(), response.Data[0].State)
+	// The delegations not updated since it's been tracked default to their staking time
+	updatedAt, err := time.Parse(time.RFC3339, response.Data[0].UpdatedAt)
+	assert.NoError(t, err, "updated_at should be an ISO timestamp")
+	assert.Equal(t, int64(1700000000), updatedAt.Unix())
+	mockDB.AssertExpectations(t)
+}
+
+func TestStakerTotalStake(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPks[:1],
+		EnforceNotOverflow: true,
+	})
+	// Another staker's delegations are not accounted for
+	otherStakerEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPks[1:],
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, append(activeStakingEvents, otherStakerEvents...))
+	time.Sleep(2 * time.Second)
+	// The unbonded delegation is excluded
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvents[0].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + stakerTotalStakeUrl + "?staker_btc_pk=" + stakerPks[0])
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.StakerTotalStakePublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, stakerPks[0], response.Data.StakerPkHex)
+	assert.Equal(t, activeStakingEvents[1].StakingValue+activeStakingEvents[2].StakingValue, response.Data.TotalStake)
+
+	badResp, err := http.Get(testServer.Server.URL + stakerTotalStakeUrl + "?staker_btc_pk=invalid")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestStakerDelegationsFetchedByAddress(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        2,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            generatePks(t, 1),
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetch := func(query string, expectedStatus int) []string {
+		resp, err := http.Get(testServer.Server.URL + stakerDelegations + "?" + query)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus != http.StatusOK {
+			return nil
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		assert.NotNil(t, response.Data)
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes
+	}
+
+	stakerPk := activeStakingEvents[0].StakerPkHex
+	taprootAddress, err := utils.GetTaprootAddressFromPk(stakerPk, testServer.Config.Server.BTCNetParam)
+	assert.NoError(t, err, "failed to get taproot address from staker pk")
+	assert.ElementsMatch(t, fetch("staker_btc_pk="+stakerPk, http.StatusOK), fetch("address="+taprootAddress, http.StatusOK))
+	assert.Equal(t, 2, len(fetch("address="+taprootAddress, http.StatusOK)))
+
+	// An address without delegations has no delegation listed
+	stakerPkWithoutDelegation, err := randomPk()
+	if err != nil {
+		t.Fatalf("failed to generate random public key for staker: %v", err)
+	}
+	addressWithoutDelegation, err := utils.GetTaprootAddressFromPk(
+		stakerPkWithoutDelegation, testServer.Config.Server.BTCNetParam,
+	)
+	assert.NoError(t, err, "failed to get taproot address from staker pk")
+	assert.Empty(t, fetch("address="+addressWithoutDelegation, http.StatusOK))
+
+	// Exactly one of the staker pk or the address is required
+	fetch("staker_btc_pk="+stakerPk+"&address="+taprootAddress, http.StatusBadRequest)
+	fetch("", http.StatusBadRequest)
+	fetch("address=invalid", http.StatusBadRequest)
+}
+
+func TestStakerDashboard(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 2)
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  fpPks,
+		Stakers:            stakerPks[:1],
+		EnforceNotOverflow: true,
+	})
+	activeStakingEvents[0].FinalityProviderPkHex = fpPks[0]
+	activeStakingEvents[1].FinalityProviderPkHex = fpPks[0]
+	activeStakingEvents[2].FinalityProviderPkHex = fpPks[1]
+	otherStakerEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        1,
+		FinalityProviders:  fpPks,
+		Stakers:            stakerPks[1:],
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, append(activeStakingEvents, otherStakerEvents...))
+	time.Sleep(2 * time.Second)
+	// The only delegation to the second finality provider is no longer locked
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvents[2].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + stakerDashboardUrl + "?staker_btc_pk=" + stakerPks[0])
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.StakerDashboardPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	activeStake := activeStakingEvents[0].StakingValue + activeStakingEvents[1].StakingValue
+	otherStake := otherStakerEvents[0].StakingValue
+	expectedRank := int64(2)
+	if activeStake > otherStake || (activeStake == otherStake && stakerPks[0] > stakerPks[1]) {
+		expectedRank = 1
+	}
+	dashboard := response.Data
+	assert.Equal(t, stakerPks[0], dashboard.StakerPkHex)
+	assert.Equal(t, activeStake, dashboard.TotalStake)
+	assert.Equal(t, activeStake, dashboard.EligibleStake)
+	assert.Equal(t, int64(1), dashboard.FinalityProviderCount)
+	assert.NotNil(t, dashboard.Rank)
+	assert.Equal(t, expectedRank, *dashboard.Rank)
+	assert.Equal(t, 30, len(dashboard.RecentActivity))
+
+	// A staker without any delegation has no rank
+	stakerPkWithoutDelegation, err := randomPk()
+	if err != nil {
+		t.Fatalf("failed to generate random public key for staker: %v", err)
+	}
+	emptyResp, err := http.Get(testServer.Server.URL + stakerDashboardUrl + "?staker_btc_pk=" + stakerPkWithoutDelegation)
+	assert.NoError(t, err)
+	defer emptyResp.Body.Close()
+	assert.Equal(t, http.StatusOK, emptyResp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err = io.ReadAll(emptyResp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Nil(t, response.Data.Rank)
+	assert.Equal(t, uint64(0), response.Data.TotalStake)
+}
+
+func TestStakerDelegationSummary(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvents[0].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + stakerDelegationSummary + "?staker_btc_pk=" + stakerPk[0])
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[map[string]int64]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// The states without delegations are included with a zero count
+	assert.Equal(t, map[string]int64{
+		types.Active.ToString():             2,
+		types.UnbondingRequested.ToString(): 0,
+		types.Unbonding.ToString():          0,
+		types.Unbonded.ToString():           1,
+		types.Withdrawn.ToString():          0,
+	}, response.Data)
+
+	badResp, err := http.Get(testServer.Server.URL + stakerDelegationSummary + "?staker_btc_pk=invalid")
+	assert.NoError(t, err)
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestStakerDailyActivity(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       4,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk[:1],
+	})
+	activeStakingEvents[0].StakingStartTimestamp = time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC).Unix()
+	activeStakingEvents[1].StakingStartTimestamp = time.Date(2024, 5, 3, 2, 0, 0, 0, time.UTC).Unix()
+	activeStakingEvents[2].StakingStartTimestamp = time.Date(2024, 5, 3, 23, 59, 59, 0, time.UTC).Unix()
+	// Out of the requested range
+	activeStakingEvents[3].StakingStartTimestamp = time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC).Unix()
+	// Another staker's delegation on the same day is not accounted for
+	otherStakerEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           stakerPk[1:],
+	})
+	otherStakerEvents[0].StakingStartTimestamp = activeStakingEvents[0].StakingStartTimestamp
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, append(activeStakingEvents, otherStakerEvents...))
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + stakerDailyActivityUrl + "?staker_btc_pk=" + stakerPk[0] + "&from=2024-05-01&to=2024-05-03"
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to staker daily activity endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[[]services.StakerDailyActivityPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Days without delegation are filled with zero values
+	assert.Equal(t, []services.StakerDailyActivityPublic{
+		{Date: "2024-05-01", StakingValue: int64(activeStakingEvents[0].StakingValue), Delegations: 1},
+		{Date: "2024-05-02"},
+		{
+			Date:         "2024-05-03",
+			StakingValue: int64(activeStakingEvents[1].StakingValue + activeStakingEvents[2].StakingValue),
+			Delegations:  2,
+		},
+	}, response.Data)
+
+	// The range is capped
+	url = testServer.Server.URL + stakerDailyActivityUrl + "?staker_btc_pk=" + stakerPk[0] + "&from=2023-01-01&to=2024-05-03"
+	badResp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to staker daily activity endpoint should not fail")
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status")
+}
+
 func fetchCheckStakerActiveDelegations(
 	t *testing.T, testServer *TestServer, btcAddress string, timeframe string,
 ) bool {
@@ -305,3 +1350,447 @@ func fetchCheckStakerActiveDelegations(
 
 	return response.Data
 }
+
+func TestStakerDelegationsPageSize(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        5,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.DefaultPageSize = 2
+	cfg.Server.MaxPageSize = 4
+	assert.NoError(t, cfg.Validate())
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchPage := func(query, paginationKey string, expectedStatus int) ([]string, string) {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] +
+			query + "&pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode, "unexpected status for %s", query)
+		if expectedStatus != http.StatusOK {
+			return nil, ""
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		var hashes []string
+		for _, d := range response.Data {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes, response.Pagination.NextKey
+	}
+
+	// The configured default page size applies without limit
+	firstPage, paginationKey := fetchPage("", "", http.StatusOK)
+	assert.Len(t, firstPage, 2)
+	assert.NotEmpty(t, paginationKey)
+
+	// The pagination key can be resumed with another page size
+	secondPage, paginationKey := fetchPage("&limit=4", paginationKey, http.StatusOK)
+	assert.Len(t, secondPage, 3)
+	assert.Empty(t, paginationKey)
+
+	var expectedHashes []string
+	for _, event := range activeStakingEvents {
+		expectedHashes = append(expectedHashes, event.StakingTxHashHex)
+	}
+	assert.ElementsMatch(t, expectedHashes, append(firstPage, secondPage...))
+
+	// Pages of any size within the max can be chained
+	page, paginationKey := fetchPage("&limit=1", "", http.StatusOK)
+	assert.Len(t, page, 1)
+	assert.NotEmpty(t, paginationKey)
+	page, _ = fetchPage("&limit=4", paginationKey, http.StatusOK)
+	assert.Len(t, page, 4)
+
+	// Limits out of range are rejected rather than clamped
+	fetchPage("&limit=5", "", http.StatusBadRequest)
+	fetchPage("&limit=0", "", http.StatusBadRequest)
+	fetchPage("&limit=abc", "", http.StatusBadRequest)
+
+	cfg.Server.DefaultPageSize = 5
+	assert.Error(t, cfg.Validate(), "default page size above the max page size should be invalid")
+}
+
+func TestStakerDelegationsPaginationKeyIntegrity(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchPage := func(paginationKey string, expectedStatus int) (int, string) {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] +
+			"&limit=1&pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode, "unexpected status for pagination key %s", paginationKey)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		if expectedStatus != http.StatusOK {
+			var response respond.ErrorResponse
+			err = json.Unmarshal(bodyBytes, &response)
+			assert.NoError(t, err, "unmarshalling response body should not fail")
+			assert.Equal(t, "invalid pagination key format", response.Message)
+			return 0, ""
+		}
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return len(response.Data), response.Pagination.NextKey
+	}
+
+	_, paginationKey := fetchPage("", http.StatusOK)
+	assert.NotEmpty(t, paginationKey)
+
+	// A valid pagination key resumes the listing
+	count, _ := fetchPage(paginationKey, http.StatusOK)
+	assert.Equal(t, 1, count)
+
+	// A truncated pagination key is rejected
+	fetchPage(paginationKey[:len(paginationKey)-4], http.StatusBadRequest)
+
+	// A pagination key with a tampered payload is rejected
+	tokenBytes, err := base64.URLEncoding.DecodeString(paginationKey)
+	assert.NoError(t, err)
+	tokenBytes[1] ^= 0x01
+	fetchPage(base64.URLEncoding.EncodeToString(tokenBytes), http.StatusBadRequest)
+
+	// A pagination key crafted from the payload without the MAC is rejected
+	forged, err := json.Marshal(model.DelegationByStakerPagination{
+		StakingTxHashHex:   activeStakingEvents[0].StakingTxHashHex,
+		StakingStartHeight: activeStakingEvents[0].StakingStartHeight,
+	})
+	assert.NoError(t, err)
+	fetchPage(base64.URLEncoding.EncodeToString(forged), http.StatusBadRequest)
+}
+
+func TestStakerDelegationsCsvExport(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	// Make sure the export spans several pages
+	cfg.Server.DefaultPageSize = 1
+	cfg.Server.MaxPageSize = 1
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0]
+	fetchCsv := func(req *http.Request) [][]string {
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		assert.NoError(t, err, "reading the CSV response should not fail")
+		return records
+	}
+
+	queryReq, err := http.NewRequest(http.MethodGet, url+"&format=csv", nil)
+	assert.NoError(t, err)
+	headerReq, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	headerReq.Header.Set("Accept", "text/csv")
+
+	expectedRecords := make(map[string][]string)
+	for _, event := range activeStakingEvents {
+		expectedRecords[event.StakingTxHashHex] = []string{
+			event.StakingTxHashHex, types.Active.ToString(),
+			fmt.Sprint(event.StakingValue), fmt.Sprint(event.StakingStartHeight),
+			event.FinalityProviderPkHex,
+		}
+	}
+	for _, req := range []*http.Request{queryReq, headerReq} {
+		records := fetchCsv(req)
+		if !assert.Len(t, records, len(activeStakingEvents)+1) {
+			continue
+		}
+		assert.Equal(t, []string{
+			"staking_tx_hash_hex", "state", "staking_value", "staking_start_height", "finality_provider_pk_hex",
+		}, records[0])
+		for _, record := range records[1:] {
+			assert.Equal(t, expectedRecords[record[0]], record)
+		}
+	}
+
+	// JSON stays the default format
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	invalidResp, err := http.Get(url + "&format=xml")
+	assert.NoError(t, err)
+	defer invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
+func TestStakerDelegationsAsyncExport(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        3,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	// Make sure the export spans several pages
+	cfg.Server.DefaultPageSize = 1
+	cfg.Server.MaxPageSize = 1
+
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	resp, err := http.Post(
+		testServer.Server.URL+stakerDelegationsExport+"?staker_btc_pk="+stakerPk[0], "application/json", nil,
+	)
+	assert.NoError(t, err, "making POST request to the export endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+	var createResponse handlers.PublicResponse[services.ExportJobPublic]
+	err = json.NewDecoder(resp.Body).Decode(&createResponse)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, services.ExportJobPending, createResponse.Data.Status)
+	assert.Empty(t, createResponse.Data.DownloadUrl)
+	jobUrl := testServer.Server.URL + stakerDelegationsExport + "/" + createResponse.Data.JobId
+
+	// The export is generated in the background
+	var job services.ExportJobPublic
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get(jobUrl)
+		if !assert.NoError(t, err) {
+			return false
+		}
+		defer resp.Body.Close()
+		var jobResponse handlers.PublicResponse[services.ExportJobPublic]
+		if !assert.NoError(t, json.NewDecoder(resp.Body).Decode(&jobResponse)) {
+			return false
+		}
+		job = jobResponse.Data
+		return job.Status != services.ExportJobPending
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, services.ExportJobCompleted, job.Status)
+	assert.Equal(t, createResponse.Data.ExpiresAt, job.ExpiresAt)
+	assert.Equal(t, stakerDelegationsExport+"/"+job.JobId+"/download", job.DownloadUrl)
+
+	downloadResp, err := http.Get(testServer.Server.URL + job.DownloadUrl)
+	assert.NoError(t, err, "making GET request to the download link should not fail")
+	defer downloadResp.Body.Close()
+	assert.Equal(t, http.StatusOK, downloadResp.StatusCode, "expected HTTP 200 OK status")
+	assert.Contains(t, downloadResp.Header.Get("Content-Type"), "text/csv")
+	records, err := csv.NewReader(downloadResp.Body).ReadAll()
+	assert.NoError(t, err, "reading the CSV file should not fail")
+	if assert.Len(t, records, len(activeStakingEvents)+1) {
+		assert.Equal(t, []string{
+			"staking_tx_hash_hex", "state", "staking_value", "staking_start_height", "finality_provider_pk_hex",
+		}, records[0])
+		expectedRecords := make(map[string][]string)
+		for _, event := range activeStakingEvents {
+			expectedRecords[event.StakingTxHashHex] = []string{
+				event.StakingTxHashHex, types.Active.ToString(),
+				fmt.Sprint(event.StakingValue), fmt.Sprint(event.StakingStartHeight),
+				event.FinalityProviderPkHex,
+			}
+		}
+		for _, record := range records[1:] {
+			assert.Equal(t, expectedRecords[record[0]], record)
+		}
+	}
+
+	// Unknown and malformed job ids
+	_, unknownJobId := randomBytes(r, 16)
+	for url, expectedStatus := range map[string]int{
+		testServer.Server.URL + stakerDelegationsExport + "/" + unknownJobId:               http.StatusNotFound,
+		testServer.Server.URL + stakerDelegationsExport + "/" + unknownJobId + "/download": http.StatusNotFound,
+		testServer.Server.URL + stakerDelegationsExport + "/not-a-job-id":                  http.StatusBadRequest,
+	} {
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode, "unexpected status for %s", url)
+	}
+}
+
+func TestStakerDelegationsFieldSelection(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        2,
+		FinalityProviders:  generatePks(t, 1),
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0]
+	resp, err := http.Get(url + "&fields=staking_tx_hash_hex,state,staking_value")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[[]map[string]interface{}]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	stakingValues := make(map[string]float64)
+	for _, event := range activeStakingEvents {
+		stakingValues[event.StakingTxHashHex] = float64(event.StakingValue)
+	}
+	assert.Len(t, response.Data, 2)
+	for _, d := range response.Data {
+		assert.Len(t, d, 3, "only the selected fields should be returned")
+		txHash, _ := d["staking_tx_hash_hex"].(string)
+		assert.Equal(t, stakingValues[txHash], d["staking_value"])
+		assert.Equal(t, types.Active.ToString(), d["state"])
+	}
+	assert.Equal(t, 2, response.Pagination.Count)
+
+	for _, query := range []string{"&fields=staking_tx_hash_hex,unknown", "&fields=state,", "&fields=state&minimal=true"} {
+		invalidResp, err := http.Get(url + query)
+		assert.NoError(t, err)
+		defer invalidResp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode, "expected HTTP 400 for %s", query)
+	}
+}
+
+func TestStakerDelegationsFilteredByFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPk := generatePks(t, 1)
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        5,
+		FinalityProviders:  fpPks,
+		Stakers:            stakerPk,
+		EnforceNotOverflow: true,
+	})
+	var expectedHashes []string
+	for i, event := range activeStakingEvents {
+		event.FinalityProviderPkHex = fpPks[i%2]
+		if i%2 == 0 {
+			expectedHashes = append(expectedHashes, event.StakingTxHashHex)
+		}
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchPage := func(query, paginationKey string, expectedStatus int) ([]services.DelegationPublic, string) {
+		url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk[0] +
+			query + "&pagination_key=" + paginationKey
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatus, resp.StatusCode, "unexpected status for %s", query)
+		if expectedStatus != http.StatusOK {
+			return nil, ""
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return response.Data, response.Pagination.NextKey
+	}
+
+	// The pagination stays within the delegations to the finality provider
+	var hashes []string
+	var paginationKey string
+	for {
+		page, nextKey := fetchPage("&limit=1&finality_provider_pk_hex="+fpPks[0], paginationKey, http.StatusOK)
+		for _, d := range page {
+			assert.Equal(t, fpPks[0], d.FinalityProviderPkHex)
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		if nextKey == "" {
+			break
+		}
+		paginationKey = nextKey
+	}
+	assert.ElementsMatch(t, expectedHashes, hashes)
+
+	// No delegation to an unrelated finality provider
+	page, _ := fetchPage("&finality_provider_pk_hex="+generatePks(t, 1)[0], "", http.StatusOK)
+	assert.Empty(t, page)
+
+	fetchPage("&finality_provider_pk_hex=invalid", "", http.StatusBadRequest)
+}
+
+func TestStakerLinkageIsUnsupported(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	stakerPks := generatePks(t, 2)
+	url := testServer.Server.URL + stakerLinkageUrl
+
+	resp, err := http.Get(url + "?staker_btc_pk=" + stakerPks[0] + "&staker_btc_pk=" + stakerPks[1])
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[services.StakerLinkagePublic]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err, "decoding response body should not fail")
+	assert.Equal(t, stakerPks, response.Data.StakerPkHexes)
+	// Unknown rather than not linked
+	assert.False(t, response.Data.Supported)
+	assert.Nil(t, response.Data.Linked)
+
+	for _, query := range []string{
+		"?staker_btc_pk=" + stakerPks[0],
+		"?staker_btc_pk=" + stakerPks[0] + "&staker_btc_pk=invalid",
+	} {
+		badResp, err := http.Get(url + query)
+		assert.NoError(t, err)
+		badResp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+}
@@ -2,10 +2,14 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"math"
 	"math/rand"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -13,14 +17,25 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
 )
 
 const (
 	overallStatsEndpoint = "/v1/stats"
 	topStakerStatsPath   = "/v1/stats/staker"
+	inactiveFpStakePath  = "/v1/stats/inactive-provider-stake"
+	dailyUnbondingPath   = "/v1/stats/unbonding/daily"
+	activeSetStakePath   = "/v1/stats/active-set"
+	fpCountPath          = "/v1/stats/finality-providers/count"
+	tvlPath              = "/v1/stats/tvl"
+	statsHistoryPath     = "/v1/stats/history"
+	stakerRankPath       = "/v1/stats/staker/rank"
 )
 
 func TestStatsShouldBeShardedInDb(t *testing.T) {
@@ -210,6 +225,9 @@ func TestStatsEndpoints(t *testing.T) {
 	assert.Equal(t, uint64(1), overallStats.TotalStakers)
 	// We have not yet sent any UnconfirmedInfoEvent, hence no recrod in db
 	assert.Equal(t, uint64(0), overallStats.UnconfirmedTvl)
+	assert.Equal(t, services.DataPartial, overallStats.DataCompleteness)
+	_, err := time.Parse(time.RFC3339, overallStats.ComputedAt)
+	assert.NoError(t, err, "expected computed_at to be in RFC3339 format")
 
 	// Test the top staker stats endpoint
 	stakerStats, _ := fetchStakerStatsEndpoint(t, testServer)
@@ -308,9 +326,340 @@ func TestStatsEndpoints(t *testing.T) {
 	time.Sleep(2 * time.Second)
 
 	overallStats = fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, services.DataComplete, overallStats.DataCompleteness)
 	assert.Equal(t, uint64(100), overallStats.UnconfirmedTvl)
 }
 
+func TestInactiveProviderStakeEndpoint(t *testing.T) {
+	// The finality providers of the generated events are not registered
+	activeStakingEvents := buildActiveStakingEvent(t, 3)
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	var expectedTvl, expectedFps int64
+	for _, event := range activeStakingEvents {
+		expectedTvl += int64(event.StakingValue)
+		if event.StakingValue > 0 {
+			expectedFps++
+		}
+	}
+
+	url := testServer.Server.URL + inactiveFpStakePath
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to inactive provider stake endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[services.InactiveProviderStakePublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, expectedTvl, responseBody.Data.ActiveTvl)
+	assert.Equal(t, expectedFps, responseBody.Data.FinalityProviders)
+}
+
+func TestActiveSetStakeBreakdownEndpoint(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        6,
+		FinalityProviders:  fpPks,
+		Stakers:            generatePks(t, 3),
+		EnforceNotOverflow: true,
+	})
+	// Only the first finality provider is in the active set
+	testServer := setupTestServer(t, &TestServerDependency{
+		MockedFinalityProviders: []types.FinalityProviderDetails{{BtcPk: fpPks[0]}},
+	})
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	var inSetTvl, inSetDelegations, outOfSetTvl, outOfSetDelegations int64
+	for _, event := range activeStakingEvents {
+		if event.FinalityProviderPkHex == fpPks[0] {
+			inSetTvl += int64(event.StakingValue)
+			inSetDelegations++
+		} else {
+			outOfSetTvl += int64(event.StakingValue)
+			outOfSetDelegations++
+		}
+	}
+
+	resp, err := http.Get(testServer.Server.URL + activeSetStakePath)
+	assert.NoError(t, err, "making GET request to active set stake endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[services.ActiveSetStakeBreakdownPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, inSetTvl, responseBody.Data.InActiveSet.ActiveTvl)
+	assert.Equal(t, inSetDelegations, responseBody.Data.InActiveSet.ActiveDelegations)
+	assert.Equal(t, outOfSetTvl, responseBody.Data.OutOfActiveSet.ActiveTvl)
+	assert.Equal(t, outOfSetDelegations, responseBody.Data.OutOfActiveSet.ActiveDelegations)
+}
+
+func TestOverallStatsConditionalRequest(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	url := testServer.Server.URL + overallStatsEndpoint
+
+	getWithETag := func(etag string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := getWithETag("")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// The stats are unchanged, even though they are computed again
+	resp = getWithETag(etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode, "expected HTTP 304 status")
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, buildActiveStakingEvent(t, 1))
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	resp = getWithETag(etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status once the stats changed")
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+}
+
+func TestOverallStatsStakingValueDistribution(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	// No active delegation yet
+	overallStats := fetchOverallStatsEndpoint(t, testServer)
+	require.NotNil(t, overallStats.MedianStakingValue)
+	assert.Equal(t, int64(0), *overallStats.MedianStakingValue)
+	assert.Equal(t, int64(0), *overallStats.P90StakingValue)
+	assert.Equal(t, int64(0), *overallStats.AverageStakingValue)
+
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        5,
+		FinalityProviders:  generatePks(t, 2),
+		Stakers:            generatePks(t, 2),
+		EnforceNotOverflow: true,
+	})
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	var totalValue uint64
+	minValue, maxValue := activeStakingEvents[0].StakingValue, activeStakingEvents[0].StakingValue
+	for _, event := range activeStakingEvents {
+		totalValue += event.StakingValue
+		minValue = min(minValue, event.StakingValue)
+		maxValue = max(maxValue, event.StakingValue)
+	}
+	overallStats = fetchOverallStatsEndpoint(t, testServer)
+	require.NotNil(t, overallStats.MedianStakingValue)
+	expectedAverage := int64(math.Round(float64(totalValue) / float64(len(activeStakingEvents))))
+	assert.Equal(t, expectedAverage, *overallStats.AverageStakingValue)
+	// The percentiles are approximated, hence only bounded
+	assert.GreaterOrEqual(t, *overallStats.MedianStakingValue, int64(minValue))
+	assert.GreaterOrEqual(t, *overallStats.P90StakingValue, *overallStats.MedianStakingValue)
+	assert.LessOrEqual(t, *overallStats.P90StakingValue, int64(maxValue))
+}
+
+func TestOverallStatsStakingValueDistributionIsBestEffortAndCached(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	mockDB.On("GetLatestBtcInfo", mock.Anything).Return(&model.BtcInfo{}, nil)
+	mockDB.On("GetOverallStats", mock.Anything).Return(&model.OverallStatsDocument{ActiveTvl: 10}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	// e.g. $percentile is not supported by the MongoDB server
+	mockDB.On("AggregateStakingValueDistribution", mock.Anything, mock.Anything).
+		Return(nil, errors.New("unsupported accumulator")).Once()
+	overallStats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(10), overallStats.ActiveTvl)
+	assert.Nil(t, overallStats.MedianStakingValue)
+	assert.Nil(t, overallStats.P90StakingValue)
+	assert.Nil(t, overallStats.AverageStakingValue)
+
+	// Once aggregated, the distribution is served from the cache
+	mockDB.On("AggregateStakingValueDistribution", mock.Anything, mock.Anything).
+		Return(&model.StakingValueDistribution{Median: 100, P90: 900, Average: 250.4}, nil).Once()
+	for i := 0; i < 2; i++ {
+		overallStats = fetchOverallStatsEndpoint(t, testServer)
+		require.NotNil(t, overallStats.MedianStakingValue)
+		assert.Equal(t, int64(100), *overallStats.MedianStakingValue)
+		assert.Equal(t, int64(900), *overallStats.P90StakingValue)
+		assert.Equal(t, int64(250), *overallStats.AverageStakingValue)
+	}
+	mockDB.AssertNumberOfCalls(t, "AggregateStakingValueDistribution", 2)
+}
+
+func TestOverallStatsShouldFallbackToCacheOnTimeout(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	mockDB.On("GetLatestBtcInfo", mock.Anything).Return(&model.BtcInfo{UnconfirmedTvl: 100}, nil)
+	mockDB.On("AggregateStakingValueDistribution", mock.Anything, mock.Anything).
+		Return(&model.StakingValueDistribution{}, nil)
+	// Slow computation that only ends once the deadline is exceeded
+	slowGetOverallStats := func(ctx context.Context) (*model.OverallStatsDocument, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.StatsComputationTimeout = 100 * time.Millisecond
+
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB, ConfigOverrides: cfg})
+	defer testServer.Close()
+	url := testServer.Server.URL + overallStatsEndpoint
+
+	// No stats have been computed yet, hence nothing to fallback to
+	mockDB.On("GetOverallStats", mock.Anything).Return(slowGetOverallStats).Once()
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "expected HTTP 503 status")
+
+	mockDB.On("GetOverallStats", mock.Anything).Return(&model.OverallStatsDocument{ActiveTvl: 10}, nil).Once()
+	overallStats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(10), overallStats.ActiveTvl)
+	assert.Equal(t, services.DataComplete, overallStats.DataCompleteness)
+
+	mockDB.On("GetOverallStats", mock.Anything).Return(slowGetOverallStats).Once()
+	staleStats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(10), staleStats.ActiveTvl)
+	assert.Equal(t, services.DataStale, staleStats.DataCompleteness)
+	assert.Equal(t, overallStats.ComputedAt, staleStats.ComputedAt)
+}
+
+func TestDailyUnbondingStats(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	mockDB.On("AggregateDailyUnbondingStats", mock.Anything, from.Unix(), to.AddDate(0, 0, 1).Unix()).
+		Return([]model.DailyUnbondingStats{
+			{Date: "2024-05-02", UnbondingTvl: 300, UnbondingRequests: 2},
+		}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	url := testServer.Server.URL + dailyUnbondingPath + "?from=2024-05-01&to=2024-05-03"
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to daily unbonding stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[[]services.DailyUnbondingStatsPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// Days without unbonding are filled with zero values
+	assert.Equal(t, []services.DailyUnbondingStatsPublic{
+		{Date: "2024-05-01"},
+		{Date: "2024-05-02", UnbondingTvl: 300, UnbondingRequests: 2},
+		{Date: "2024-05-03"},
+	}, responseBody.Data)
+
+	// The range is capped
+	url = testServer.Server.URL + dailyUnbondingPath + "?from=2024-01-01&to=2024-05-03"
+	badResp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to daily unbonding stats endpoint should not fail")
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status")
+}
+
+func TestStatsHistory(t *testing.T) {
+	mockDB := new(testmock.DBClient)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	day := int64(24 * time.Hour / time.Second)
+	mockDB.On("AggregateStakingHistory", mock.Anything, from.Unix(), to.AddDate(0, 0, 1).Unix(), day).
+		Return(&model.StakingHistory{
+			StakingValueBefore: 1000,
+			DelegationsBefore:  4,
+			Buckets: []model.StakingHistoryBucket{
+				{BucketStart: from.Unix(), StakingValue: 100, Delegations: 1},
+				{BucketStart: to.Unix(), StakingValue: 300, Delegations: 2},
+			},
+		}, nil)
+	// No activity at all within the hourly range
+	hourFrom := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockDB.On("AggregateStakingHistory", mock.Anything, hourFrom.Unix(), hourFrom.AddDate(0, 0, 1).Unix(), int64(3600)).
+		Return(&model.StakingHistory{StakingValueBefore: 1000, DelegationsBefore: 4}, nil)
+	// The weekly range starts from the Monday of the week of 2024-05-01
+	weekFrom := time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)
+	weekTo := time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)
+	mockDB.On("AggregateStakingHistory", mock.Anything, weekFrom.Unix(), weekTo.AddDate(0, 0, 1).Unix(), 7*day).
+		Return(&model.StakingHistory{
+			Buckets: []model.StakingHistoryBucket{
+				{BucketStart: weekFrom.AddDate(0, 0, 7).Unix(), StakingValue: 200, Delegations: 2},
+			},
+		}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	fetchHistory := func(query string) []services.StakingHistoryPointPublic {
+		resp, err := http.Get(testServer.Server.URL + statsHistoryPath + query)
+		assert.NoError(t, err, "making GET request to stats history endpoint should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var responseBody handlers.PublicResponse[[]services.StakingHistoryPointPublic]
+		err = json.Unmarshal(bodyBytes, &responseBody)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		assert.NotNil(t, responseBody.Data, "expected an empty series rather than null")
+		return responseBody.Data
+	}
+
+	// The totals accumulate on top of the delegations before the range
+	assert.Equal(t, []services.StakingHistoryPointPublic{
+		{Timestamp: "2024-05-01T00:00:00Z", NewTvl: 100, NewDelegations: 1, TotalTvl: 1100, TotalDelegations: 5},
+		{Timestamp: "2024-05-03T00:00:00Z", NewTvl: 300, NewDelegations: 2, TotalTvl: 1400, TotalDelegations: 7},
+	}, fetchHistory("?from=2024-05-01&to=2024-05-03"))
+	assert.Empty(t, fetchHistory("?from=2024-06-01&to=2024-06-01&granularity=hour"))
+	assert.Equal(t, []services.StakingHistoryPointPublic{
+		{Timestamp: "2024-05-06T00:00:00Z", NewTvl: 200, NewDelegations: 2, TotalTvl: 200, TotalDelegations: 2},
+	}, fetchHistory("?from=2024-05-01&to=2024-05-14&granularity=week"))
+
+	// Unknown granularity and ranges beyond the cap of the granularity are rejected
+	for _, query := range []string{
+		"?granularity=minute",
+		"?from=2023-01-01&to=2024-05-03",
+		"?from=2024-05-01&to=2024-05-10&granularity=hour",
+		"?from=2018-01-01&to=2024-05-03&granularity=week",
+	} {
+		badResp, err := http.Get(testServer.Server.URL + statsHistoryPath + query)
+		assert.NoError(t, err, "making GET request to stats history endpoint should not fail")
+		badResp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status for %s", query)
+	}
+}
+
 func FuzzStatsEndpointReturnHighestUnconfirmedTvlFromEvents(f *testing.F) {
 	attachRandomSeedsToFuzzer(f, 5)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -412,6 +761,108 @@ func FuzzTestTopStakersWithPaginationResponse(f *testing.F) {
 	})
 }
 
+func TestTopStakersWithLimit(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 5)
+	events := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        len(stakerPks),
+		Stakers:            stakerPks,
+		EnforceNotOverflow: true,
+	})
+	// Make sure every staker has a delegation
+	for i, event := range events {
+		event.StakerPkHex = stakerPks[i]
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, events)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + topStakerStatsPath + "?limit=2"
+	var paginationKey string
+	var pageSizes []int
+	for {
+		resp, err := http.Get(url + "&pagination_key=" + paginationKey)
+		assert.NoError(t, err, "making GET request to staker stats endpoint should not fail")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.StakerStatsPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		pageSizes = append(pageSizes, len(response.Data))
+		if response.Pagination.NextKey == "" {
+			break
+		}
+		paginationKey = response.Pagination.NextKey
+	}
+	assert.Equal(t, []int{2, 2, 1}, pageSizes)
+
+	_, maxPageSize := testServer.Config.PageSizeLimits()
+	for _, limit := range []string{"abc", "0", "-1", strconv.FormatInt(maxPageSize+1, 10)} {
+		resp, err := http.Get(testServer.Server.URL + topStakerStatsPath + "?limit=" + limit)
+		assert.NoError(t, err, "making GET request to staker stats endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for limit %s", limit)
+	}
+}
+
+func TestStakerRank(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := generatePks(t, 3)
+	events := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        len(stakerPks),
+		Stakers:            stakerPks,
+		EnforceNotOverflow: true,
+	})
+	for i, event := range events {
+		event.StakerPkHex = stakerPks[i]
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, events)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	for _, event := range events {
+		// Stakers are ranked by active tvl and then by staker pk, both descending
+		expectedRank := int64(1)
+		for _, other := range events {
+			if other.StakingValue > event.StakingValue ||
+				(other.StakingValue == event.StakingValue && other.StakerPkHex > event.StakerPkHex) {
+				expectedRank++
+			}
+		}
+		resp, err := http.Get(testServer.Server.URL + stakerRankPath + "?staker_btc_pk=" + event.StakerPkHex)
+		assert.NoError(t, err, "making GET request to staker rank endpoint should not fail")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[services.StakerRankPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		assert.Equal(t, expectedRank, response.Data.Rank)
+		assert.Equal(t, event.StakerPkHex, response.Data.StakerPkHex)
+		assert.Equal(t, int64(event.StakingValue), response.Data.ActiveTvl)
+	}
+
+	// A staker without any active stake has no rank
+	stakerPkWithoutDelegation, err := randomPk()
+	require.NoError(t, err)
+	resp, err := http.Get(testServer.Server.URL + stakerRankPath + "?staker_btc_pk=" + stakerPkWithoutDelegation)
+	assert.NoError(t, err, "making GET request to staker rank endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 status")
+
+	resp, err = http.Get(testServer.Server.URL + stakerRankPath + "?staker_btc_pk=invalid")
+	assert.NoError(t, err, "making GET request to staker rank endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 status")
+}
+
 func fetchFinalityEndpoint(t *testing.T, testServer *TestServer) []services.FpDetailsPublic {
 	url := testServer.Server.URL + finalityProvidersPath
 	// Make a GET request to the finality providers endpoint
@@ -472,3 +923,153 @@ func fetchStakerStatsEndpoint(t *testing.T, testServer *TestServer) ([]services.
 
 	return responseBody.Data, responseBody.Pagination.NextKey
 }
+
+func TestFinalityProviderCountEndpoint(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 3)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        10,
+		FinalityProviders:  fpPks,
+		Stakers:            generatePks(t, 3),
+		EnforceNotOverflow: true,
+	})
+	testServer := setupTestServer(t, &TestServerDependency{
+		MockedFinalityProviders: []types.FinalityProviderDetails{{BtcPk: fpPks[0]}, {BtcPk: fpPks[1]}},
+	})
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	stakedFps := make(map[string]struct{})
+	for _, event := range activeStakingEvents {
+		stakedFps[event.FinalityProviderPkHex] = struct{}{}
+	}
+
+	resp, err := http.Get(testServer.Server.URL + fpCountPath)
+	assert.NoError(t, err, "making GET request to finality provider count endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[services.FinalityProviderCountPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, int64(len(stakedFps)), responseBody.Data.ActiveFinalityProviders)
+	assert.Equal(t, int64(2), responseBody.Data.RegisteredFinalityProviders)
+}
+
+func TestTvlEndpoint(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       10,
+		FinalityProviders: fpPks,
+		Stakers:           generatePks(t, 3),
+	})
+	// Make sure both the overflow and the non overflow delegations are covered
+	activeStakingEvents[0].IsOverflow = false
+	activeStakingEvents[1].IsOverflow = true
+
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Server.TvlExcludeInactiveProviders = true
+
+	// Only the first finality provider is in the active set
+	testServer := setupTestServer(t, &TestServerDependency{
+		ConfigOverrides:         cfg,
+		MockedFinalityProviders: []types.FinalityProviderDetails{{BtcPk: fpPks[0]}},
+	})
+	defer testServer.Close()
+	err = sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	var activeTvl int64
+	var totalLocked uint64
+	for _, event := range activeStakingEvents {
+		totalLocked += event.StakingValue
+		if !event.IsOverflow && event.FinalityProviderPkHex == fpPks[0] {
+			activeTvl += int64(event.StakingValue)
+		}
+	}
+
+	resp, err := http.Get(testServer.Server.URL + tvlPath)
+	assert.NoError(t, err, "making GET request to tvl endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[services.TvlPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, activeTvl, responseBody.Data.ActiveTvl)
+	assert.Equal(t, totalLocked, responseBody.Data.TotalLocked)
+	assert.NotEmpty(t, responseBody.Data.Definitions["active_tvl"])
+	assert.NotEmpty(t, responseBody.Data.Definitions["total_locked"])
+}
+
+func TestOverallStatsRespectingAllowlist(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        8,
+		FinalityProviders:  fpPks,
+		Stakers:            generatePks(t, 3),
+		EnforceNotOverflow: true,
+	})
+	// Only the first finality provider is allowlisted
+	testServer := setupTestServer(t, &TestServerDependency{
+		MockedFinalityProviders: []types.FinalityProviderDetails{{BtcPk: fpPks[0]}},
+	})
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	var allowlistedTvl, allowlistedDelegations int64
+	allowlistedStakers := make(map[string]struct{})
+	for _, event := range activeStakingEvents {
+		if event.FinalityProviderPkHex == fpPks[0] {
+			allowlistedTvl += int64(event.StakingValue)
+			allowlistedDelegations++
+			allowlistedStakers[event.StakerPkHex] = struct{}{}
+		}
+	}
+
+	resp, err := http.Get(testServer.Server.URL + overallStatsEndpoint + "?respect_allowlist=true")
+	assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var responseBody handlers.PublicResponse[services.OverallStatsPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	scoped := responseBody.Data
+	assert.Equal(t, allowlistedTvl, scoped.ActiveTvl)
+	assert.Equal(t, allowlistedTvl, scoped.TotalTvl)
+	assert.Equal(t, allowlistedDelegations, scoped.ActiveDelegations)
+	assert.Equal(t, allowlistedDelegations, scoped.TotalDelegations)
+	assert.Equal(t, uint64(len(allowlistedStakers)), scoped.TotalStakers)
+	// The unconfirmed tvl cannot be scoped to the allowlist
+	assert.Equal(t, uint64(0), scoped.UnconfirmedTvl)
+	assert.Equal(t, services.DataPartial, scoped.DataCompleteness)
+
+	// Network-wide by default
+	overallStats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(len(activeStakingEvents)), overallStats.TotalDelegations)
+
+	badResp, err := http.Get(testServer.Server.URL + overallStatsEndpoint + "?respect_allowlist=maybe")
+	assert.NoError(t, err, "making GET request to stats endpoint should not fail")
+	defer badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode, "expected HTTP 400 status")
+}
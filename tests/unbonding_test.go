@@ -3,9 +3,11 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"math/rand"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,17 +16,21 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"github.com/babylonchain/staking-api-service/internal/api"
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/api/respond"
+	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
 )
 
 const (
 	unbondingEligibilityPath = "/v1/unbonding/eligibility"
 	unbondingPath            = "/v1/unbonding"
+	unbondingDelegationsPath = "/v1/delegations/unbonding"
+	stakerUnbondingsPath     = "/v1/staker/unbondings"
 )
 
 func TestUnbondingRequest(t *testing.T) {
@@ -69,11 +75,12 @@ func TestUnbondingRequest(t *testing.T) {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	assert.NoError(t, err, "reading response body should not fail")
 
-	var response api.ErrorResponse
-	err = json.Unmarshal(bodyBytes, &response)
+	var eligibilityResponse handlers.PublicResponse[services.UnbondingEligibilityPublic]
+	err = json.Unmarshal(bodyBytes, &eligibilityResponse)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
-	assert.Equal(t, "FORBIDDEN", response.ErrorCode, "expected error code to be FORBIDDEN")
-	assert.Equal(t, "delegation state is not active", response.Message, "expected error message to be 'delegation state is not active'")
+	assert.False(t, eligibilityResponse.Data.Eligible)
+	assert.Equal(t, services.UnbondingAlreadyRequested, eligibilityResponse.Data.Reason)
+	assert.NotEmpty(t, eligibilityResponse.Data.Message)
 
 	// Let's make a POST request to the unbonding endpoint again
 	resp, err = http.Post(unbondingUrl, "application/json", bytes.NewReader(requestBodyBytes))
@@ -87,6 +94,7 @@ func TestUnbondingRequest(t *testing.T) {
 	bodyBytes, err = io.ReadAll(resp.Body)
 	assert.NoError(t, err, "reading response body should not fail")
 
+	var response respond.ErrorResponse
 	err = json.Unmarshal(bodyBytes, &response)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
 	assert.Equal(t, "FORBIDDEN", response.ErrorCode, "expected error code to be FORBIDDEN")
@@ -130,6 +138,171 @@ func TestUnbondingRequest(t *testing.T) {
 	assert.Equal(t, activeStakingEvent.StakingValue, results[0].StakingAmount)
 }
 
+func TestUnbondingRequestWithIdempotencyKey(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	postUnbonding := func(idempotencyKey string, payload handlers.UnbondDelegationRequestPayload) *http.Response {
+		resp, _ := postUnbondingRequest(t, testServer, "", idempotencyKey, payload)
+		return resp
+	}
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	resp := postUnbonding("retry-key", requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+
+	// The retry gets the original outcome rather than the already submitted error
+	resp = postUnbonding("retry-key", requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected the original HTTP 202 Accepted status")
+	results, err := inspectDbDocuments[model.UnbondingDocument](t, model.UnbondingCollection)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Equal(t, 1, len(results), "expected a single unbonding document in the DB")
+
+	// Reusing the key for a different request is a conflict
+	conflictingBody := requestBody
+	conflictingBody.StakerSignedSignatureHex = strings.Repeat("a", len(requestBody.StakerSignedSignatureHex))
+	resp = postUnbonding("retry-key", conflictingBody)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "expected HTTP 409 Conflict status")
+
+	// Another key goes through the regular processing
+	resp = postUnbonding("another-key", requestBody)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "expected HTTP 403 Forbidden status")
+}
+
+func TestUnbondingRequestWithIdempotencyKeyRetriedAfterInternalError(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	mockDB := new(testmock.DBClient)
+	mockDB.On("FindDelegationByTxHashHex", mock.Anything, activeStakingEvent.StakingTxHashHex).Return(
+		&model.DelegationDocument{
+			StakingTxHashHex:      activeStakingEvent.StakingTxHashHex,
+			StakerPkHex:           activeStakingEvent.StakerPkHex,
+			FinalityProviderPkHex: activeStakingEvent.FinalityProviderPkHex,
+			StakingValue:          activeStakingEvent.StakingValue,
+			State:                 types.Active,
+			StakingTx: &model.TimelockTransaction{
+				TxHex:          activeStakingEvent.StakingTxHex,
+				OutputIndex:    activeStakingEvent.StakingOutputIndex,
+				StartTimestamp: activeStakingEvent.StakingStartTimestamp,
+				StartHeight:    activeStakingEvent.StakingStartHeight,
+				TimeLock:       activeStakingEvent.StakingTimeLock,
+			},
+			IsOverflow: activeStakingEvent.IsOverflow,
+		}, nil,
+	)
+	// The first attempt fails on the database, the retry goes through
+	mockDB.On("SaveUnbondingTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("transient database error")).Once()
+	mockDB.On("SaveUnbondingTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	postUnbonding := func(payload handlers.UnbondDelegationRequestPayload) *http.Response {
+		resp, _ := postUnbondingRequest(t, testServer, "", "retry-after-error-key", payload)
+		return resp
+	}
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	resp := postUnbonding(requestBody)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "expected HTTP 500 Internal Server Error status")
+
+	// The failed attempt neither stored its outcome nor kept the key locked
+	resp = postUnbonding(requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+	mockDB.AssertNumberOfCalls(t, "SaveUnbondingTx", 2)
+
+	// The successful outcome is replayed from now on
+	resp = postUnbonding(requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected the stored HTTP 202 Accepted status")
+	mockDB.AssertNumberOfCalls(t, "SaveUnbondingTx", 2)
+}
+
+func TestUnbondingRequestSignatureVerification(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	resp, bodyBytes := postUnbondingRequest(t, testServer, "", "", tamperUnbondingSignature(requestBody))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	var response respond.ErrorResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.InvalidSignature.String(), response.ErrorCode)
+	// Nothing is queued for the tampered request
+	results, err := inspectDbDocuments[model.UnbondingDocument](t, model.UnbondingCollection)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Empty(t, results)
+
+	resp, _ = postUnbondingRequest(t, testServer, "", "", requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+}
+
+func TestUnbondingRequestDryRun(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	postUnbonding := func(query string, payload handlers.UnbondDelegationRequestPayload) (*http.Response, []byte) {
+		return postUnbondingRequest(t, testServer, query, "dry-run-key", payload)
+	}
+	assertDelegationState := func(expected types.DelegationState) {
+		delegations, err := inspectDbDocuments[model.DelegationDocument](t, model.DelegationCollection)
+		assert.NoError(t, err, "failed to inspect DB documents")
+		require.Equal(t, 1, len(delegations))
+		assert.Equal(t, expected, delegations[0].State)
+	}
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	resp, bodyBytes := postUnbonding("?dry_run=true", requestBody)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[services.UnbondingDryRunPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, requestBody.StakingTxHashHex, response.Data.StakingTxHashHex)
+	assert.Equal(t, requestBody.UnbondingTxHashHex, response.Data.UnbondingTxHashHex)
+	assert.Equal(t, types.UnbondingRequested.ToString(), response.Data.State)
+
+	// Nothing is saved by the dry run
+	results, err := inspectDbDocuments[model.UnbondingDocument](t, model.UnbondingCollection)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Empty(t, results)
+	assertDelegationState(types.Active)
+
+	// The dry run goes through the same verification
+	resp, bodyBytes = postUnbonding("?dry_run=true", tamperUnbondingSignature(requestBody))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	var errResponse respond.ErrorResponse
+	err = json.Unmarshal(bodyBytes, &errResponse)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.InvalidSignature.String(), errResponse.ErrorCode)
+
+	resp, _ = postUnbonding("?dry_run=invalid", requestBody)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+
+	// The idempotency key is not consumed by the dry runs
+	resp, _ = postUnbonding("?dry_run=false", requestBody)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+	assertDelegationState(types.UnbondingRequested)
+
+	// The delegation is no longer eligible once the request is submitted
+	resp, _ = postUnbonding("?dry_run=true", requestBody)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "expected HTTP 403 Forbidden status")
+}
+
 func TestUnbondingRequestEligibilityWhenNoMatchingDelegation(t *testing.T) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	activeStakingEvent := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
@@ -154,10 +327,56 @@ func TestUnbondingRequestEligibilityWhenNoMatchingDelegation(t *testing.T) {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	assert.NoError(t, err, "reading response body should not fail")
 
-	var response api.ErrorResponse
+	var response handlers.PublicResponse[services.UnbondingEligibilityPublic]
 	err = json.Unmarshal(bodyBytes, &response)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
-	assert.Equal(t, "NOT_FOUND", response.ErrorCode, "expected error code to be NOT_FOUND")
+	assert.False(t, response.Data.Eligible)
+	assert.Equal(t, services.DelegationNotFound, response.Data.Reason, "expected reason to be DELEGATION_NOT_FOUND")
+}
+
+func TestUnbondingEligibilityReasons(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	mockDB := new(testmock.DBClient)
+	testCases := []struct {
+		state    types.DelegationState
+		eligible bool
+		reason   services.UnbondingIneligibilityReason
+	}{
+		{types.Active, true, ""},
+		{types.UnbondingRequested, false, services.UnbondingAlreadyRequested},
+		{types.Unbonding, false, services.DelegationUnbonding},
+		{types.Unbonded, false, services.DelegationEnded},
+		{types.Withdrawn, false, services.DelegationEnded},
+	}
+	stakingTxHashHexes := make([]string, len(testCases))
+	for i, tc := range testCases {
+		_, stakingTxHashHexes[i] = randomBytes(r, 32)
+		mockDB.On("FindDelegationByTxHashHex", mock.Anything, stakingTxHashHexes[i]).
+			Return(&model.DelegationDocument{StakingTxHashHex: stakingTxHashHexes[i], State: tc.state}, nil)
+	}
+	testServer := setupTestServer(t, &TestServerDependency{MockDbClient: mockDB})
+	defer testServer.Close()
+
+	for i, tc := range testCases {
+		resp, err := http.Get(testServer.Server.URL + unbondingEligibilityPath + "?staking_tx_hash_hex=" + stakingTxHashHexes[i])
+		assert.NoError(t, err, "making GET request to unbonding eligibility check endpoint should not fail")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[services.UnbondingEligibilityPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+
+		assert.Equal(t, tc.eligible, response.Data.Eligible, "unexpected eligibility in state %s", tc.state)
+		assert.Equal(t, tc.reason, response.Data.Reason, "unexpected reason in state %s", tc.state)
+		if tc.eligible {
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+			assert.Empty(t, response.Data.Message)
+		} else {
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, "expected HTTP 403 Forbidden status")
+			assert.NotEmpty(t, response.Data.Message)
+		}
+	}
 }
 
 func getTestActiveStakingEvent() *client.ActiveStakingEvent {
@@ -185,6 +404,41 @@ func getTestUnbondDelegationRequestPayload(stakingTxHashHex string) handlers.Unb
 	}
 }
 
+// postUnbondingRequest submits the unbonding request, along with the
+// idempotency key if not empty, and returns the response with its body read
+func postUnbondingRequest(
+	t *testing.T, testServer *TestServer, query, idempotencyKey string,
+	payload handlers.UnbondDelegationRequestPayload,
+) (*http.Response, []byte) {
+	requestBodyBytes, err := json.Marshal(payload)
+	assert.NoError(t, err, "marshalling request body should not fail")
+	req, err := http.NewRequest(http.MethodPost, testServer.Server.URL+unbondingPath+query, bytes.NewReader(requestBodyBytes))
+	assert.NoError(t, err, "creating POST request to unbonding endpoint should not fail")
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "making POST request to unbonding endpoint should not fail")
+	defer resp.Body.Close()
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	return resp, bodyBytes
+}
+
+// tamperUnbondingSignature flips the last nibble of the signature of the
+// request, keeping it well formed
+func tamperUnbondingSignature(payload handlers.UnbondDelegationRequestPayload) handlers.UnbondDelegationRequestPayload {
+	sig := []byte(payload.StakerSignedSignatureHex)
+	if sig[len(sig)-1] == '0' {
+		sig[len(sig)-1] = '1'
+	} else {
+		sig[len(sig)-1] = '0'
+	}
+	payload.StakerSignedSignatureHex = string(sig)
+	return payload
+}
+
 func TestProcessUnbondingStakingEvent(t *testing.T) {
 	activeStakingEvent := getTestActiveStakingEvent()
 	testServer := setupTestServer(t, nil)
@@ -254,6 +508,11 @@ func TestProcessUnbondingStakingEvent(t *testing.T) {
 
 	_, err = time.Parse(time.RFC3339, getStakerDelegationResponse.Data[0].UnbondingTx.StartTimestamp)
 	assert.NoError(t, err, "expected timestamp to be in RFC3339 format")
+	assert.Equal(t,
+		unbondingEvent.UnbondingStartTimestamp-activeStakingEvent.StakingStartTimestamp,
+		getStakerDelegationResponse.Data[0].StakedDurationSeconds,
+		"expected the staked duration to end at the unbonding",
+	)
 
 	// Let's also fetch the DB to make sure the expired check is processed
 	timeLockResults, err := inspectDbDocuments[model.TimeLockDocument](t, model.TimeLockCollection)
@@ -269,6 +528,148 @@ func TestProcessUnbondingStakingEvent(t *testing.T) {
 	assert.Equal(t, types.UnbondingTxType.ToString(), timeLockResults[1].TxType)
 }
 
+func TestGetUnbondingDelegations(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	unbondingUrl := testServer.Server.URL + unbondingDelegationsPath
+	// No btc info has been processed yet
+	resp, err := http.Get(unbondingUrl)
+	assert.NoError(t, err, "making GET request to unbonding delegations endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "expected HTTP 503 status")
+
+	unbondingStartHeight := activeStakingEvent.StakingStartHeight + 100
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType: client.BtcInfoEventType,
+		Height:    unbondingStartHeight + 4,
+	}
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+
+	unbondingEvent := client.UnbondingStakingEvent{
+		EventType:               client.UnbondingStakingEventType,
+		StakingTxHashHex:        activeStakingEvent.StakingTxHashHex,
+		UnbondingTxHashHex:      "0x1234567890abcdef",
+		UnbondingTxHex:          "0x1234567890abcdef",
+		UnbondingTimeLock:       10,
+		UnbondingStartTimestamp: time.Now().Unix(),
+		UnbondingStartHeight:    unbondingStartHeight,
+		UnbondingOutputIndex:    1,
+	}
+	sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(unbondingUrl)
+	assert.NoError(t, err, "making GET request to unbonding delegations endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var response handlers.PublicResponse[[]services.UnbondingDelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, 1, len(response.Data), "expected 1 delegation in the response")
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, response.Data[0].StakingTxHashHex)
+	assert.Equal(t, types.Unbonding.ToString(), response.Data[0].State)
+	assert.Equal(t, uint64(6), response.Data[0].RemainingBlocks)
+	_, err = time.Parse(time.RFC3339, response.Data[0].EstimatedCompletionTimestamp)
+	assert.NoError(t, err, "expected timestamp to be in RFC3339 format")
+}
+
+func TestGetUnbondingDelegationsSortedByRemainingBlocks(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       2,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 2),
+	})
+	cfg, err := config.New("./config/config-test.yml")
+	if err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+	cfg.Db.MaxPaginationLimit = 1
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{{
+		EventType: client.BtcInfoEventType,
+		Height:    315,
+	}})
+	time.Sleep(2 * time.Second)
+
+	// The first delegation starts unbonding earlier but completes later
+	unbondingEvents := []client.UnbondingStakingEvent{
+		{
+			EventType:               client.UnbondingStakingEventType,
+			StakingTxHashHex:        activeStakingEvents[0].StakingTxHashHex,
+			UnbondingTxHashHex:      "0x1234567890abcdef",
+			UnbondingTxHex:          "0x1234567890abcdef",
+			UnbondingTimeLock:       50,
+			UnbondingStartTimestamp: time.Now().Unix(),
+			UnbondingStartHeight:    300,
+			UnbondingOutputIndex:    1,
+		},
+		{
+			EventType:               client.UnbondingStakingEventType,
+			StakingTxHashHex:        activeStakingEvents[1].StakingTxHashHex,
+			UnbondingTxHashHex:      "0xabcdef1234567890",
+			UnbondingTxHex:          "0xabcdef1234567890",
+			UnbondingTimeLock:       10,
+			UnbondingStartTimestamp: time.Now().Unix(),
+			UnbondingStartHeight:    310,
+			UnbondingOutputIndex:    1,
+		},
+	}
+	sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, unbondingEvents)
+	time.Sleep(2 * time.Second)
+
+	fetchAll := func(sortBy string) []services.UnbondingDelegationPublic {
+		var all []services.UnbondingDelegationPublic
+		var paginationKey string
+		for {
+			url := testServer.Server.URL + unbondingDelegationsPath + "?sort_by=" + sortBy + "&pagination_key=" + paginationKey
+			resp, err := http.Get(url)
+			assert.NoError(t, err, "making GET request to unbonding delegations endpoint should not fail")
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+			bodyBytes, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err, "reading response body should not fail")
+			var response handlers.PublicResponse[[]services.UnbondingDelegationPublic]
+			err = json.Unmarshal(bodyBytes, &response)
+			assert.NoError(t, err, "unmarshalling response body should not fail")
+			all = append(all, response.Data...)
+			if response.Pagination.NextKey == "" {
+				return all
+			}
+			paginationKey = response.Pagination.NextKey
+		}
+	}
+
+	delegations := fetchAll(string(services.UnbondingSortByStartHeight))
+	assert.Equal(t, 2, len(delegations))
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, delegations[0].StakingTxHashHex)
+
+	delegations = fetchAll(string(services.UnbondingSortByRemainingBlocks))
+	assert.Equal(t, 2, len(delegations))
+	assert.Equal(t, activeStakingEvents[1].StakingTxHashHex, delegations[0].StakingTxHashHex)
+	assert.Equal(t, uint64(5), delegations[0].RemainingBlocks)
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, delegations[1].StakingTxHashHex)
+	assert.Equal(t, uint64(35), delegations[1].RemainingBlocks)
+
+	resp, err := http.Get(testServer.Server.URL + unbondingDelegationsPath + "?sort_by=invalid")
+	assert.NoError(t, err, "making GET request to unbonding delegations endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 status")
+}
+
 func TestProcessUnbondingStakingEventDuringBootstrap(t *testing.T) {
 	activeStakingEvent := getTestActiveStakingEvent()
 	testServer := setupTestServer(t, nil)
@@ -521,9 +922,79 @@ func TestUnbondingRequestValidation(t *testing.T) {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	assert.NoError(t, err, "reading response body should not fail")
 
-	var unbondingResponse api.ErrorResponse
+	var unbondingResponse respond.ErrorResponse
 	err = json.Unmarshal(bodyBytes, &unbondingResponse)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
 	assert.Equal(t, types.ValidationError.String(), unbondingResponse.ErrorCode)
 	assert.Equal(t, "unbonding_tx_hash_hex must match the hash calculated from the provided unbonding tx", unbondingResponse.Message)
 }
+
+func TestGetStakerUnbondings(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	fetchUnbondings := func(query string) []services.StakerUnbondingRequestPublic {
+		url := testServer.Server.URL + stakerUnbondingsPath + "?staker_btc_pk=" + activeStakingEvent.StakerPkHex + query
+		resp, err := http.Get(url)
+		assert.NoError(t, err, "making GET request to staker unbondings should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "reading response body should not fail")
+		var response handlers.PublicResponse[[]services.StakerUnbondingRequestPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+		return response.Data
+	}
+	assert.Empty(t, fetchUnbondings(""))
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	requestBodyBytes, err := json.Marshal(requestBody)
+	assert.NoError(t, err, "marshalling request body should not fail")
+	resp, err := http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(requestBodyBytes))
+	assert.NoError(t, err, "making POST request to unbonding endpoint should not fail")
+	resp.Body.Close()
+
+	unbondings := fetchUnbondings("")
+	require.Equal(t, 1, len(unbondings))
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, unbondings[0].StakingTxHashHex)
+	assert.Equal(t, requestBody.UnbondingTxHashHex, unbondings[0].UnbondingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingValue, unbondings[0].StakingValue)
+	assert.Equal(t, types.UnbondingRequested.ToString(), unbondings[0].State)
+	_, err = time.Parse(time.RFC3339, unbondings[0].RequestedTimestamp)
+	assert.NoError(t, err, "expected timestamp to be in RFC3339 format")
+	assert.Empty(t, unbondings[0].UnbondingStartTimestamp)
+	// The pending unbondings are the ones still requested
+	assert.Equal(t, 1, len(fetchUnbondings("&state=unbonding_requested")))
+	assert.Empty(t, fetchUnbondings("&state=unbonding"))
+
+	unbondingEvent := client.UnbondingStakingEvent{
+		EventType:               client.UnbondingStakingEventType,
+		StakingTxHashHex:        requestBody.StakingTxHashHex,
+		UnbondingTxHashHex:      requestBody.UnbondingTxHashHex,
+		UnbondingTxHex:          requestBody.UnbondingTxHex,
+		UnbondingTimeLock:       10,
+		UnbondingStartTimestamp: time.Now().Unix(),
+		UnbondingStartHeight:    activeStakingEvent.StakingStartHeight + 100,
+		UnbondingOutputIndex:    1,
+	}
+	sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	unbondings = fetchUnbondings("")
+	require.Equal(t, 1, len(unbondings))
+	assert.Equal(t, types.Unbonding.ToString(), unbondings[0].State)
+	assert.Equal(t, utils.ParseTimestampToIsoFormat(unbondingEvent.UnbondingStartTimestamp), unbondings[0].UnbondingStartTimestamp)
+	assert.Empty(t, fetchUnbondings("&state=unbonding_requested"))
+
+	// Invalid states are rejected
+	resp, err = http.Get(testServer.Server.URL + stakerUnbondingsPath + "?staker_btc_pk=" + activeStakingEvent.StakerPkHex + "&state=invalid")
+	assert.NoError(t, err, "making GET request to staker unbondings should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 status")
+}